@@ -0,0 +1,684 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/senzing-garage/go-messaging/go/typedef"
+	"github.com/senzing-garage/go-messaging/messenger"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A flattened field of a message.  Value is a string, an int64, or a []string.
+type column struct {
+	name  string
+	value interface{}
+}
+
+// Writes messages in one output format.
+type encoder interface {
+	encode(message *typedef.SenzingMessage) error
+	flush() error
+}
+
+// The fields to convert.
+type selection struct {
+	allDetails bool
+	details    []string // "detail.<key|position>" columns, in flag order.
+	fields     map[string]bool
+}
+
+type cloudEventsEncoder struct {
+	selection *selection
+	source    string
+	writer    io.Writer
+}
+
+// Buffers messages if the detail columns must be discovered before writing the header.
+type csvEncoder struct {
+	columns         []string
+	isHeaderWritten bool
+	messages        []*typedef.SenzingMessage
+	selection       *selection
+	writer          *csv.Writer
+}
+
+type flatJSONEncoder struct {
+	selection *selection
+	writer    io.Writer
+}
+
+type logfmtEncoder struct {
+	selection *selection
+	writer    io.Writer
+}
+
+type otlpEncoder struct {
+	selection *selection
+	writer    io.Writer
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Values of the convert -to flag.
+const (
+	formatCloudEvents = "cloudevents"
+	formatCSV         = "csv"
+	formatFlatJSON    = "flat"
+	formatLogfmt      = "logfmt"
+	formatOTLP        = "otlp"
+)
+
+// Prefix of detail columns, e.g. "detail.DATA_SOURCE" or "detail.2".
+const detailPrefix = "detail."
+
+// Defaults of the convert -source and OTLP scope.
+const (
+	defaultSource = "urn:senzing:szmessages"
+	otlpScopeName = "github.com/senzing-garage/go-messaging"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// OpenTelemetry severity numbers of levels.  PANIC is FATAL4, the most severe.
+var otlpSeverityNumbers = map[string]int{
+	messenger.LevelTraceName: 1,
+	messenger.LevelDebugName: 5,
+	messenger.LevelInfoName:  9,
+	messenger.LevelWarnName:  13,
+	messenger.LevelErrorName: 17,
+	messenger.LevelFatalName: 21,
+	messenger.LevelPanicName: 24,
+}
+
+// Fields of a message in MessageFormat order, including NamedMessageFields such as "caller" after "location".
+var messageFields = slices.Insert(
+	slices.Clone(messenger.AllMessageFields),
+	slices.Index(messenger.AllMessageFields, "location")+1,
+	messenger.NamedMessageFields...,
+)
+
+// Columns of the "caller" field.
+var callerColumns = []string{"caller.package", "caller.function", "caller.file", "caller.line"}
+
+// ----------------------------------------------------------------------------
+// Command
+// ----------------------------------------------------------------------------
+
+// The "convert" command.
+func runConvert(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	var (
+		flagSet = newFlagSet("convert", "[file ...]", stderr)
+		fields  = flagSet.String("fields", "", "Comma-separated fields, as in messenger.AllMessageFields and messenger.NamedMessageFields, and detail.<key|position> columns.  Default: all fields.")
+		source  = flagSet.String("source", defaultSource, "The CloudEvents source.")
+		to      = flagSet.String("to", formatFlatJSON, "Output format: logfmt, csv, otlp, cloudevents, or flat.")
+	)
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	aSelection, err := newSelection(*fields)
+	if err != nil {
+		return usageError(err)
+	}
+
+	var anEncoder encoder
+
+	switch *to {
+	case formatCloudEvents:
+		anEncoder = &cloudEventsEncoder{selection: aSelection, source: *source, writer: stdout}
+	case formatCSV:
+		anEncoder = newCSVEncoder(aSelection, stdout)
+	case formatFlatJSON:
+		anEncoder = &flatJSONEncoder{selection: aSelection, writer: stdout}
+	case formatLogfmt:
+		anEncoder = &logfmtEncoder{selection: aSelection, writer: stdout}
+	case formatOTLP:
+		anEncoder = &otlpEncoder{selection: aSelection, writer: stdout}
+	default:
+		return usageError(fmt.Errorf("-to %q: %w", *to, errInvalidValue))
+	}
+
+	err = scanFiles(flagSet.Args(), stdin, func(source lineSource) error {
+		if source.Message() == nil {
+			return nil
+		}
+
+		return anEncoder.encode(source.Message())
+	})
+	if err != nil {
+		return err
+	}
+
+	return anEncoder.flush()
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Flatten the selected fields of a message, in messageFields order, omitting empty values.
+func (aSelection *selection) columns(message *typedef.SenzingMessage) []column {
+	result := []column{}
+	add := func(name string, value interface{}) {
+		if value != "" && value != int64(0) {
+			result = append(result, column{name: name, value: value})
+		}
+	}
+
+	for _, field := range messageFields {
+		if field == "details" {
+			result = append(result, aSelection.detailColumns(message)...)
+
+			continue
+		}
+
+		if !aSelection.fields[field] {
+			continue
+		}
+
+		switch field {
+		case "caller":
+			if message.Caller != nil {
+				add(callerColumns[0], message.Caller.Package)
+				add(callerColumns[1], message.Caller.Function)
+				add(callerColumns[2], message.Caller.File)
+				add(callerColumns[3], int64(message.Caller.Line))
+			}
+		case "duration":
+			add(field, message.Duration)
+		case "errors":
+			if len(message.Errors) > 0 {
+				add(field, message.Errors)
+			}
+		case "stack":
+			if len(message.Stack) > 0 {
+				stack, _ := json.Marshal(message.Stack)
+				add(field, string(stack))
+			}
+		case "time":
+			if !message.Time.IsZero() {
+				add(field, message.Time.Format(time.RFC3339Nano))
+			}
+		default:
+			add(field, stringField(message, field))
+		}
+	}
+
+	return result
+}
+
+// The selected details of a message as "detail.<key|position>" columns.
+func (aSelection *selection) detailColumns(message *typedef.SenzingMessage) []column {
+	result := []column{}
+
+	for _, detail := range message.Details {
+		name := detailColumnName(detail)
+		if aSelection.allDetails || slices.Contains(aSelection.details, name) {
+			result = append(result, column{name: name, value: detail.Value})
+		}
+	}
+
+	return result
+}
+
+// A copy of a message without the fields and details that are not selected.
+func (aSelection *selection) filter(message *typedef.SenzingMessage) *typedef.SenzingMessage {
+	result := &typedef.SenzingMessage{}
+
+	for _, field := range messageFields {
+		if !aSelection.fields[field] {
+			continue
+		}
+
+		switch field {
+		case "caller":
+			result.Caller = message.Caller
+		case "code":
+			result.Code = message.Code
+		case "duration":
+			result.Duration = message.Duration
+		case "errors":
+			result.Errors = message.Errors
+		case "help":
+			result.Help = message.Help
+		case "id":
+			result.ID = message.ID
+		case "level":
+			result.Level = message.Level
+		case "location":
+			result.Location = message.Location
+		case "reason":
+			result.Reason = message.Reason
+		case "remediation":
+			result.Remediation = message.Remediation
+		case "stack":
+			result.Stack = message.Stack
+		case "status":
+			result.Status = message.Status
+		case "text":
+			result.Text = message.Text
+		case "time":
+			result.Time = message.Time
+		}
+	}
+
+	for _, detail := range message.Details {
+		if aSelection.allDetails || slices.Contains(aSelection.details, detailColumnName(detail)) {
+			result.Details = append(result.Details, detail)
+		}
+	}
+
+	return result
+}
+
+// Write a CloudEvents 1.0 event in structured JSON mode, whose data is the selected fields of the message.
+func (anEncoder *cloudEventsEncoder) encode(message *typedef.SenzingMessage) error {
+	data, err := messenger.MarshalSenzingMessage(anEncoder.selection.filter(message))
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	event := struct {
+		SpecVersion     string          `json:"specversion"`
+		ID              string          `json:"id"`
+		Source          string          `json:"source"`
+		Type            string          `json:"type"`
+		Subject         string          `json:"subject,omitempty"`
+		Time            string          `json:"time,omitempty"`
+		DataContentType string          `json:"datacontenttype"`
+		Data            json.RawMessage `json:"data"`
+	}{
+		SpecVersion:     "1.0",
+		ID:              eventID(message),
+		Source:          anEncoder.source,
+		Type:            "com.senzing.message." + strings.ToLower(message.Level),
+		Subject:         message.ID,
+		DataContentType: "application/json",
+		Data:            json.RawMessage(data),
+	}
+
+	if len(message.Level) == 0 {
+		event.Type = "com.senzing.message"
+	}
+
+	if !message.Time.IsZero() {
+		event.Time = message.Time.Format(time.RFC3339Nano)
+	}
+
+	return writeJSONLine(anEncoder.writer, event)
+}
+
+func (anEncoder *cloudEventsEncoder) flush() error {
+	return nil
+}
+
+func (anEncoder *csvEncoder) encode(message *typedef.SenzingMessage) error {
+	if anEncoder.columns == nil {
+		anEncoder.messages = append(anEncoder.messages, message)
+
+		return nil
+	}
+
+	return anEncoder.writeRow(message)
+}
+
+// Write the header, if not yet written, and any buffered messages.
+func (anEncoder *csvEncoder) flush() error {
+	if anEncoder.columns == nil {
+		anEncoder.columns = anEncoder.header(anEncoder.messages)
+	}
+
+	err := anEncoder.writeHeader()
+	if err != nil {
+		return err
+	}
+
+	for _, message := range anEncoder.messages {
+		err = anEncoder.writeRow(message)
+		if err != nil {
+			return err
+		}
+	}
+
+	anEncoder.messages = nil
+	anEncoder.writer.Flush()
+
+	err = anEncoder.writer.Error()
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// The column names of the selected fields, with detail columns discovered from messages in order of appearance.
+func (anEncoder *csvEncoder) header(messages []*typedef.SenzingMessage) []string {
+	aSelection := anEncoder.selection
+	result := []string{}
+
+	for _, field := range messageFields {
+		switch {
+		case field == "details":
+			result = append(result, aSelection.details...)
+
+			for _, message := range messages {
+				for _, detail := range message.Details {
+					if name := detailColumnName(detail); aSelection.allDetails && !slices.Contains(result, name) {
+						result = append(result, name)
+					}
+				}
+			}
+		case !aSelection.fields[field]:
+			continue
+		case field == "caller":
+			result = append(result, callerColumns...)
+		default:
+			result = append(result, field)
+		}
+	}
+
+	return result
+}
+
+func (anEncoder *csvEncoder) writeHeader() error {
+	if anEncoder.isHeaderWritten {
+		return nil
+	}
+
+	anEncoder.isHeaderWritten = true
+
+	err := anEncoder.writer.Write(anEncoder.columns)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+func (anEncoder *csvEncoder) writeRow(message *typedef.SenzingMessage) error {
+	err := anEncoder.writeHeader()
+	if err != nil {
+		return err
+	}
+
+	values := map[string]string{}
+	for _, aColumn := range anEncoder.selection.columns(message) {
+		values[aColumn.name] = formatText(aColumn.value)
+	}
+
+	record := make([]string, len(anEncoder.columns))
+	for index, name := range anEncoder.columns {
+		record[index] = values[name]
+	}
+
+	err = anEncoder.writer.Write(record)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// Write one flat JSON object per message, with arrays only for "errors".
+func (anEncoder *flatJSONEncoder) encode(message *typedef.SenzingMessage) error {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("{")
+
+	for index, aColumn := range anEncoder.selection.columns(message) {
+		if index > 0 {
+			buffer.WriteString(",")
+		}
+
+		name, _ := json.Marshal(aColumn.name)
+		value, _ := json.Marshal(aColumn.value)
+		buffer.Write(name)
+		buffer.WriteString(":")
+		buffer.Write(value)
+	}
+
+	buffer.WriteString("}\n")
+
+	_, err := buffer.WriteTo(anEncoder.writer)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+func (anEncoder *flatJSONEncoder) flush() error {
+	return nil
+}
+
+// Write one logfmt line per message.
+func (anEncoder *logfmtEncoder) encode(message *typedef.SenzingMessage) error {
+	pairs := []string{}
+
+	for _, aColumn := range anEncoder.selection.columns(message) {
+		pairs = append(pairs, logfmtKey(aColumn.name)+"="+logfmtValue(formatText(aColumn.value)))
+	}
+
+	_, err := fmt.Fprintln(anEncoder.writer, strings.Join(pairs, " "))
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+func (anEncoder *logfmtEncoder) flush() error {
+	return nil
+}
+
+// Write one OTLP/JSON ExportLogsServiceRequest per message, as written by the OpenTelemetry file exporter.
+// "time", "level", and "text" become the timestamp, severity, and body; other columns become attributes.
+func (anEncoder *otlpEncoder) encode(message *typedef.SenzingMessage) error {
+	record := map[string]interface{}{}
+	attributes := []map[string]interface{}{}
+
+	for _, aColumn := range anEncoder.selection.columns(message) {
+		switch aColumn.name {
+		case "time":
+			record["timeUnixNano"] = strconv.FormatInt(message.Time.UnixNano(), 10)
+		case "level":
+			record["severityText"] = message.Level
+			if severityNumber, isKnown := otlpSeverityNumbers[message.Level]; isKnown {
+				record["severityNumber"] = severityNumber
+			}
+		case "text":
+			record["body"] = otlpValue(message.Text)
+		default:
+			attributes = append(attributes, map[string]interface{}{"key": aColumn.name, "value": otlpValue(aColumn.value)})
+		}
+	}
+
+	if len(attributes) > 0 {
+		record["attributes"] = attributes
+	}
+
+	request := map[string]interface{}{
+		"resourceLogs": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{},
+			"scopeLogs": []interface{}{map[string]interface{}{
+				"scope":      map[string]interface{}{"name": otlpScopeName},
+				"logRecords": []interface{}{record},
+			}},
+		}},
+	}
+
+	return writeJSONLine(anEncoder.writer, request)
+}
+
+func (anEncoder *otlpEncoder) flush() error {
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Parse the -fields flag.
+func newSelection(fields string) (*selection, error) {
+	result := &selection{fields: map[string]bool{}}
+
+	names := splitList(fields)
+	if len(names) == 0 {
+		names = messageFields
+	}
+
+	for _, name := range names {
+		switch {
+		case strings.HasPrefix(name, detailPrefix) && len(name) > len(detailPrefix):
+			result.details = append(result.details, name)
+		case slices.Contains(messageFields, name):
+			result.fields[name] = true
+			result.allDetails = result.allDetails || name == "details"
+		default:
+			return nil, fmt.Errorf("-fields %q: %w", name, errInvalidValue)
+		}
+	}
+
+	return result, nil
+}
+
+// Create a CSV encoder, which buffers messages only if all details are selected.
+func newCSVEncoder(aSelection *selection, writer io.Writer) *csvEncoder {
+	result := &csvEncoder{
+		selection: aSelection,
+		writer:    csv.NewWriter(writer),
+	}
+
+	if !aSelection.allDetails {
+		result.columns = result.header(nil)
+	}
+
+	return result
+}
+
+// The column of a detail: its key or, if it has none, its position.
+func detailColumnName(detail typedef.Detail) string {
+	if len(detail.Key) > 0 {
+		return detailPrefix + detail.Key
+	}
+
+	return detailPrefix + strconv.Itoa(int(detail.Position))
+}
+
+// A CloudEvents id, the same for the same message so that consumers can discard duplicates.
+func eventID(message *typedef.SenzingMessage) string {
+	text, _ := json.Marshal(message)
+	digest := sha256.Sum256(text)
+
+	return hex.EncodeToString(digest[:16]) //nolint:mnd
+}
+
+// Format a column value as text.  A []string is a JSON array.
+func formatText(value interface{}) string {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue
+	case int64:
+		return strconv.FormatInt(typedValue, 10)
+	default:
+		text, _ := json.Marshal(typedValue)
+
+		return string(text)
+	}
+}
+
+// Replace characters that end a logfmt key.
+func logfmtKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' {
+			return '_'
+		}
+
+		return r
+	}, name)
+}
+
+// Quote a logfmt value if it is empty or has spaces, quotes, equal signs, or control characters.
+func logfmtValue(value string) string {
+	if len(value) == 0 || strings.ContainsAny(value, " =\"\\") || strings.ContainsFunc(value, func(r rune) bool { return r < ' ' }) {
+		return strconv.Quote(value)
+	}
+
+	return value
+}
+
+// An OTLP/JSON AnyValue.  Integers are strings, as in the protobuf JSON mapping of int64.
+func otlpValue(value interface{}) map[string]interface{} {
+	switch typedValue := value.(type) {
+	case int64:
+		return map[string]interface{}{"intValue": strconv.FormatInt(typedValue, 10)}
+	case []string:
+		values := make([]interface{}, 0, len(typedValue))
+		for _, element := range typedValue {
+			values = append(values, otlpValue(element))
+		}
+
+		return map[string]interface{}{"arrayValue": map[string]interface{}{"values": values}}
+	default:
+		return map[string]interface{}{"stringValue": value}
+	}
+}
+
+// A string field of a message by its name in messageFields.
+func stringField(message *typedef.SenzingMessage, field string) string {
+	switch field {
+	case "code":
+		return message.Code
+	case "help":
+		return message.Help
+	case "id":
+		return message.ID
+	case "level":
+		return message.Level
+	case "location":
+		return message.Location
+	case "reason":
+		return message.Reason
+	case "remediation":
+		return message.Remediation
+	case "status":
+		return message.Status
+	case "text":
+		return message.Text
+	default:
+		return ""
+	}
+}
+
+func writeJSONLine(writer io.Writer, value interface{}) error {
+	text, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	_, err = fmt.Fprintf(writer, "%s\n", text)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
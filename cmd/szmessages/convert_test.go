@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCasesForConvert = []struct {
+	name     string
+	args     []string
+	expected string
+}{
+	{
+		name: "convert-0001",
+		args: []string{"-fields", "level,id,errors,details"},
+		expected: `{"level":"INFO","id":"SZSDK99982001"}` + "\n" +
+			`{"level":"WARN","id":"SZSDK99983001"}` + "\n" +
+			`{"level":"ERROR","id":"SZSDK99974001","errors":["0027E bad"],"detail.DATA_SOURCE":"TEST","detail.2":"Jane"}` + "\n" +
+			`{"level":"FATAL","id":"SZSDK99985001"}` + "\n",
+	},
+	{
+		name: "convert-0002",
+		args: []string{"-to", "logfmt", "-fields", "time,id,text,duration,errors"},
+		expected: `time=2000-01-01T00:00:00Z id=SZSDK99982001 text="INFO: Bob works with Jane"` + "\n" +
+			`time=2000-01-01T00:00:01Z id=SZSDK99983001 text="WARN: Bob works with Mary" duration=1500000` + "\n" +
+			`time=2000-01-01T00:00:02Z id=SZSDK99974001 text="ERROR: Bob failed" errors="[\"0027E bad\"]"` + "\n" +
+			`time=2000-01-01T00:00:03Z id=SZSDK99985001 text="FATAL: Jane failed"` + "\n",
+	},
+	{
+		name: "convert-0003",
+		args: []string{"-to", "csv", "-fields", "id,code,details"},
+		expected: "id,code,detail.DATA_SOURCE,detail.2\n" +
+			"SZSDK99982001,,,\n" +
+			"SZSDK99983001,W1,,\n" +
+			"SZSDK99974001,E27,TEST,Jane\n" +
+			"SZSDK99985001,F1,,\n",
+	},
+	{
+		name: "convert-0004",
+		args: []string{"-to", "csv", "-fields", "detail.2,id,caller"},
+		expected: "id,caller.package,caller.function,caller.file,caller.line,detail.2\n" +
+			"SZSDK99982001,,,,,\n" +
+			"SZSDK99983001,,,,,\n" +
+			"SZSDK99974001,,,,,Jane\n" +
+			"SZSDK99985001,,,,,\n",
+	},
+	{
+		name:     "convert-0005",
+		args:     []string{"-to", "otlp", "-fields", "time,level,id,text,duration,errors", "-"},
+		expected: `{"resourceLogs":[{"resource":{},"scopeLogs":[{"logRecords":[{"attributes":[{"key":"id","value":{"stringValue":"SZSDK99983001"}},{"key":"duration","value":{"intValue":"1500000"}}],"body":{"stringValue":"WARN: Bob works with Mary"},"severityNumber":13,"severityText":"WARN","timeUnixNano":"946684801000000000"}],"scope":{"name":"github.com/senzing-garage/go-messaging"}}]}]}` + "\n",
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test private functions
+// ----------------------------------------------------------------------------
+
+func Test_runConvert(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForConvert {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			err := runConvert(testCase.args, strings.NewReader(testLog), &stdout, &stderr)
+			require.NoError(test, err)
+			assert.Contains(test, stdout.String(), testCase.expected)
+		})
+	}
+}
+
+func Test_runConvert_cloudEvents(test *testing.T) {
+	test.Parallel()
+
+	var (
+		stdout, stderr bytes.Buffer
+		event          map[string]interface{}
+	)
+
+	err := runConvert([]string{"-to", "cloudevents", "-source", "/test", "-fields", "level,id,detail.DATA_SOURCE"}, strings.NewReader(testLog), &stdout, &stderr)
+	require.NoError(test, err)
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	require.Len(test, lines, 4)
+	require.NoError(test, json.Unmarshal([]byte(lines[2]), &event))
+
+	assert.Equal(test, "1.0", event["specversion"])
+	assert.Equal(test, "/test", event["source"])
+	assert.Equal(test, "com.senzing.message.error", event["type"])
+	assert.Equal(test, "SZSDK99974001", event["subject"])
+	assert.Equal(test, "2000-01-01T00:00:02Z", event["time"])
+	assert.Len(test, event["id"], 32)
+	assert.Equal(test, map[string]interface{}{
+		"level":   "ERROR",
+		"id":      "SZSDK99974001",
+		"details": []interface{}{map[string]interface{}{"key": "DATA_SOURCE", "position": float64(1), "value": "TEST"}},
+	}, event["data"])
+}
+
+func Test_runConvert_invalid(test *testing.T) {
+	test.Parallel()
+
+	var stdout, stderr bytes.Buffer
+
+	err := runConvert([]string{"-to", "parquet"}, strings.NewReader(testLog), &stdout, &stderr)
+	require.ErrorIs(test, err, errUsage)
+
+	err = runConvert([]string{"-fields", "id,bogus"}, strings.NewReader(testLog), &stdout, &stderr)
+	require.ErrorIs(test, err, errUsage)
+}
+
+func Test_logfmtValue(test *testing.T) {
+	test.Parallel()
+
+	assert.Equal(test, `""`, logfmtValue(""))
+	assert.Equal(test, "plain", logfmtValue("plain"))
+	assert.Equal(test, `"a=b"`, logfmtValue("a=b"))
+	assert.Equal(test, `"line\nbreak"`, logfmtValue("line\nbreak"))
+	assert.Equal(test, "detail.MY_KEY", logfmtKey("detail.MY KEY"))
+}
@@ -0,0 +1,41 @@
+/*
+The szmessages command reads, filters, summarizes, and converts logs of Senzing messages, and checks and previews message catalogs.
+
+A log is newline-delimited JSON, as written by messenger NewJSON(), read from files or standard input.
+Lines that are not messages are passed through or, with -skip-other, dropped by view, and ignored by the other commands.
+
+Usage:
+
+	szmessages <command> [flags] [file ...]
+
+Commands:
+
+	convert  Convert messages to logfmt, CSV, OTLP/JSON, CloudEvents, or flat JSON.
+	lint     Check message catalogs for problems.
+	render   Render messages of a catalog, as NewJSON or NewSlog would.
+	stats    Summarize messages by level, id, code, error rate, and duration.
+	view     Pretty-print and filter messages.
+
+Examples:
+
+	szmessages view -level WARN -since 2025-01-01T00:00:00Z app.log
+	tail -f app.log | szmessages view -id-prefix SZSDK9998 -text 'works with'
+	szmessages view -follow -state app.state -level WARN app.log
+	szmessages view -output json -code '^E' -skip-other app.log > errors.log
+	szmessages view -query 'level >= WARN and detail.DATA_SOURCE == "TEST"' app.log
+	szmessages stats -top 20 -bucket 5m -baseline yesterday.log today.log
+	szmessages convert -to otlp -fields time,level,id,text,code,details app.log
+	szmessages lint -strict registry.json
+	szmessages render -catalog registry.json -output slog SZSDK99984001 Bob int:5 'json:{"a":1}' err:failed
+
+With -follow, view waits for lines appended to one file, continuing across rotation and truncation, until interrupted.
+It starts at the end of the file, at -offset, or at the position saved in the -state file when last interrupted.
+The saved position is not used if the file has since been rotated or truncated.
+
+Detail arguments of render are strings unless they have a type hint:
+int:5, float:1.5, bool:true, nil:, err:message, json:{...}, duration:1.5s, map:KEY=value,KEY2=value2, or str:text.
+Without an id, render reads one id and its details per line of standard input.
+
+Run "szmessages <command> -h" for the flags of a command.
+*/
+package main
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/senzing-garage/go-messaging/registry"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var (
+	errFindings       = errors.New("catalog problems found")
+	errInvalidCatalog = errors.New("not a registry or component")
+)
+
+// ----------------------------------------------------------------------------
+// Command
+// ----------------------------------------------------------------------------
+
+// The "lint" command.
+func runLint(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	var (
+		flagSet = newFlagSet("lint", "[catalog.json ...]", stderr)
+		output  = flagSet.String("output", outputText, "Output format: text or json.")
+		strict  = flagSet.Bool("strict", false, "Fail on warnings as well as errors.")
+	)
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	if *output != outputText && *output != outputJSON {
+		return usageError(fmt.Errorf("-output %q: %w", *output, errInvalidValue))
+	}
+
+	components, err := readCatalogs(flagSet.Args(), stdin)
+	if err != nil {
+		return err
+	}
+
+	findings := registry.Lint(components...)
+
+	err = writeFindings(stdout, *output, findings)
+	if err != nil {
+		return err
+	}
+
+	failures := 0
+
+	for _, finding := range findings {
+		if finding.Severity == registry.SeverityError || *strict {
+			failures++
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d of %d findings: %w", failures, len(findings), errFindings)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Read the components of catalog files, or of stdin if there are no files or the file is "-".
+func readCatalogs(filenames []string, stdin io.Reader) ([]registry.Component, error) {
+	var result []registry.Component
+
+	if len(filenames) == 0 {
+		filenames = []string{"-"}
+	}
+
+	for _, filename := range filenames {
+		var (
+			data []byte
+			err  error
+		)
+
+		if filename == "-" {
+			data, err = io.ReadAll(stdin)
+		} else {
+			data, err = os.ReadFile(filename) //nolint:gosec
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+
+		components, err := parseCatalog(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+
+		result = append(result, components...)
+	}
+
+	return result, nil
+}
+
+// Parse a registry, as written by registry.MarshalJSON, or a single component.
+func parseCatalog(data []byte) ([]registry.Component, error) {
+	var dump struct {
+		Components []registry.Component `json:"components"`
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(&dump)
+	if err == nil {
+		return dump.Components, nil
+	}
+
+	var component registry.Component
+
+	decoder = json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	err = decoder.Decode(&component)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidCatalog, err)
+	}
+
+	return []registry.Component{component}, nil
+}
+
+// Write findings as a JSON array or as one line each.
+func writeFindings(writer io.Writer, output string, findings []registry.Finding) error {
+	if output == outputJSON {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+
+		err := encoder.Encode(findings)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		return nil
+	}
+
+	for _, finding := range findings {
+		subject := finding.Component
+		if len(finding.ID) > 0 {
+			subject += " " + finding.ID
+		}
+
+		_, err := fmt.Fprintf(writer, "%s: %s: %s [%s]\n", subject, finding.Severity, finding.Message, finding.Rule)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/go-messaging/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A registry dump whose second component collides with the first and has a contradicting level prefix.
+const testCatalog = `{"components":[
+{"name":"component-a","idTemplate":"SZSDK9998%04d","idMin":0,"idMax":9999,"idMessages":{"2001":"%s works with %s"}},
+{"name":"component-b","idTemplate":"SZSDK9998%04d","idMin":4000,"idMax":4999,"idMessages":{"4001":"WARN: %s failed"}}
+]}`
+
+var testCasesForLint = []struct {
+	name           string
+	args           []string
+	catalog        string
+	expectedError  error
+	expectedStdout string
+}{
+	{
+		name:           "lint-0001",
+		catalog:        `{"name":"component-a","idTemplate":"SZSDK9998%04d","idMessages":{"2001":"%s works with %s"}}`,
+		expectedStdout: "",
+	},
+	{
+		name:          "lint-0002",
+		catalog:       testCatalog,
+		expectedError: errFindings,
+		expectedStdout: `component-b: error: id "SZSDK99984000" of component "component-b", message 4000, is claimed by component "component-a", message 4000: message id collision [collision]` + "\n" +
+			"component-b SZSDK99984001: warning: text begins with WARN, but message number 4001 is ERROR [level-prefix]\n",
+	},
+	{
+		name:           "lint-0003",
+		catalog:        `{"name":"component-a","idTemplate":"SZSDK9998%04d","idMessages":{"2001":"INFO: %s works with %s"}}`,
+		expectedStdout: "",
+	},
+	{
+		name:           "lint-0004",
+		args:           []string{"-strict"},
+		catalog:        `{"name":"component-a","idTemplate":"SZSDK9998%04d","idMessages":{"2001":"DEBUG: %s works with %s"}}`,
+		expectedError:  errFindings,
+		expectedStdout: "component-a SZSDK99982001: warning: text begins with DEBUG, but message number 2001 is INFO [level-prefix]\n",
+	},
+	{
+		name:          "lint-0005",
+		catalog:       `{"bogus":true}`,
+		expectedError: errInvalidCatalog,
+	},
+	{
+		name:          "lint-0006",
+		args:          []string{"-output", "xml"},
+		expectedError: errUsage,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test private functions
+// ----------------------------------------------------------------------------
+
+func Test_runLint(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForLint {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			err := runLint(testCase.args, strings.NewReader(testCase.catalog), &stdout, &stderr)
+			if testCase.expectedError != nil {
+				require.ErrorIs(test, err, testCase.expectedError)
+			} else {
+				require.NoError(test, err)
+			}
+
+			assert.Equal(test, testCase.expectedStdout, stdout.String())
+		})
+	}
+}
+
+func Test_runLint_json(test *testing.T) {
+	test.Parallel()
+
+	var (
+		stdout, stderr bytes.Buffer
+		findings       []registry.Finding
+	)
+
+	err := runLint([]string{"-output", "json"}, strings.NewReader(testCatalog), &stdout, &stderr)
+	require.ErrorIs(test, err, errFindings)
+	require.NoError(test, json.Unmarshal(stdout.Bytes(), &findings))
+	require.Len(test, findings, 2)
+	assert.Equal(test, registry.RuleCollision, findings[0].Rule)
+	assert.Equal(test, registry.SeverityError, findings[0].Severity)
+	assert.Equal(test, "SZSDK99984001", findings[1].ID)
+	assert.Equal(test, 4001, *findings[1].MessageNumber)
+}
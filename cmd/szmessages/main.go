@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/senzing-garage/go-messaging/go/typedef"
+	"github.com/senzing-garage/go-messaging/parser"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A subcommand of szmessages.
+type command struct {
+	name    string
+	run     func(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error
+	summary string
+}
+
+// A line of input and its message, as from parser.Scanner or parser.Follower.
+type lineSource interface {
+	Line() string
+	Message() *typedef.SenzingMessage
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Exit codes.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var (
+	errInvalidValue = errors.New("invalid value")
+	errUsage        = errors.New("usage error")
+)
+
+var commands = []command{
+	{name: "convert", run: runConvert, summary: "Convert messages to logfmt, CSV, OTLP/JSON, CloudEvents, or flat JSON."},
+	{name: "lint", run: runLint, summary: "Check message catalogs for problems."},
+	{name: "render", run: runRender, summary: "Render messages of a catalog, as NewJSON or NewSlog would."},
+	{name: "stats", run: runStats, summary: "Summarize messages by level, id, code, error rate, and duration."},
+	{name: "view", run: runView, summary: "Pretty-print and filter messages."},
+}
+
+// ----------------------------------------------------------------------------
+// Main
+// ----------------------------------------------------------------------------
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Run a subcommand and return the exit code.
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		writeUsage(stderr)
+
+		return exitUsage
+	}
+
+	for _, aCommand := range commands {
+		if aCommand.name != args[0] {
+			continue
+		}
+
+		err := aCommand.run(args[1:], stdin, stdout, stderr)
+
+		switch {
+		case err == nil:
+			return exitOK
+		case errors.Is(err, flag.ErrHelp):
+			return exitOK
+		case errors.Is(err, errUsage):
+			fmt.Fprintf(stderr, "szmessages %s: %s\n", aCommand.name, err)
+
+			return exitUsage
+		default:
+			fmt.Fprintf(stderr, "szmessages %s: %s\n", aCommand.name, err)
+
+			return exitError
+		}
+	}
+
+	if args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		writeUsage(stdout)
+
+		return exitOK
+	}
+
+	fmt.Fprintf(stderr, "szmessages: unknown command %q\n", args[0])
+	writeUsage(stderr)
+
+	return exitUsage
+}
+
+// Call scan for each line of each file, or of stdin if there are no files or the file is "-".
+func scanFiles(filenames []string, stdin io.Reader, scan func(source lineSource) error) error {
+	if len(filenames) == 0 {
+		filenames = []string{"-"}
+	}
+
+	for _, filename := range filenames {
+		err := scanFile(filename, stdin, scan)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanFile(filename string, stdin io.Reader, scan func(source lineSource) error) error {
+	reader := stdin
+
+	if filename != "-" {
+		file, err := os.Open(filename) //nolint:gosec
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		defer file.Close()
+
+		reader = file
+	}
+
+	scanner := parser.NewScanner(reader)
+	for scanner.Scan() {
+		err := scan(scanner)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return nil
+}
+
+/*
+Call scan for each line appended to a file until ctx is done, following rotation and truncation.
+If stateFilename is given, start at the position it holds, if it exists, and save the position there when done.
+The position is not used if the file has since been rotated or truncated.
+*/
+func followFile(ctx context.Context, filename string, offset int64, stateFilename string, scan func(source lineSource) error) error {
+	var option interface{} = parser.OptionFollowOffset{Value: offset}
+
+	if len(stateFilename) > 0 {
+		position, isSaved, err := readPosition(stateFilename)
+		if err != nil {
+			return err
+		}
+
+		if isSaved {
+			option = parser.OptionFollowPosition{Value: position}
+		}
+	}
+
+	follower, err := parser.NewFollower(ctx, filename, option)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	defer follower.Close()
+
+	for follower.Scan() {
+		err = scan(follower)
+		if err != nil {
+			break
+		}
+	}
+
+	if err == nil && follower.Err() != nil {
+		err = fmt.Errorf("%s: %w", filename, follower.Err())
+	}
+
+	if len(stateFilename) > 0 {
+		err = errors.Join(err, writePosition(stateFilename, follower.Position()))
+	}
+
+	return err
+}
+
+// Create a context which is done on an interrupt or termination signal.
+func followContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// Read the position saved in a state file, or a bare offset saved by earlier versions.  A missing file is not an error.
+func readPosition(stateFilename string) (parser.FollowPosition, bool, error) {
+	var result parser.FollowPosition
+
+	data, err := os.ReadFile(stateFilename) //nolint:gosec
+	if errors.Is(err, os.ErrNotExist) {
+		return result, false, nil
+	}
+
+	if err != nil {
+		return result, false, fmt.Errorf("%w", err)
+	}
+
+	offset, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err == nil {
+		return parser.FollowPosition{Offset: offset}, true, nil
+	}
+
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return result, false, fmt.Errorf("%s: %w", stateFilename, err)
+	}
+
+	return result, true, nil
+}
+
+func writePosition(stateFilename string, position parser.FollowPosition) error {
+	data, err := json.Marshal(position)
+	if err == nil {
+		err = os.WriteFile(stateFilename, append(data, '\n'), 0o600) //nolint:mnd
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// Create the flag set of a subcommand.
+func newFlagSet(name string, usage string, stderr io.Writer) *flag.FlagSet {
+	result := flag.NewFlagSet(name, flag.ContinueOnError)
+	result.SetOutput(stderr)
+	result.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: szmessages %s [flags] %s\n\nFlags:\n", name, usage)
+		result.PrintDefaults()
+	}
+
+	return result
+}
+
+func writeUsage(writer io.Writer) {
+	fmt.Fprintf(writer, "Usage: szmessages <command> [flags] [file ...]\n\nCommands:\n")
+
+	for _, aCommand := range commands {
+		fmt.Fprintf(writer, "  %-8s %s\n", aCommand.name, aCommand.summary)
+	}
+}
+
+// Mark an error as a usage error, unless it is a request for help.
+func usageError(err error) error {
+	if errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", errUsage, err)
+}
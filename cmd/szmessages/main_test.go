@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A log with messages at several levels, a prefixed message, and lines that are not messages.
+const testLog = `starting up
+{"time":"2000-01-01T00:00:00Z","level":"INFO","id":"SZSDK99982001","text":"INFO: Bob works with Jane","status":"OK"}
+{"time":"2000-01-01T00:00:01Z","level":"WARN","id":"SZSDK99983001","text":"WARN: Bob works with Mary","code":"W1","duration":1500000}
+2000/01/01 00:00:02 {"time":"2000-01-01T00:00:02Z","level":"ERROR","id":"SZSDK99974001","text":"ERROR: Bob failed","code":"E27","errors":["0027E bad"],"details":[{"key":"DATA_SOURCE","position":1,"value":"TEST"},{"position":2,"value":"Jane"}]}
+{"time":"2000-01-01T00:00:03Z","level":"FATAL","id":"SZSDK99985001","text":"FATAL: Jane failed","code":"F1"}
+shutting down
+`
+
+var testCasesForRun = []struct {
+	name           string
+	args           []string
+	expectedCode   int
+	expectedStdout string
+	expectedStderr string
+}{
+	{
+		name:           "run-0001",
+		expectedCode:   exitUsage,
+		expectedStderr: "Usage: szmessages <command>",
+	},
+	{
+		name:           "run-0002",
+		args:           []string{"bogus"},
+		expectedCode:   exitUsage,
+		expectedStderr: `unknown command "bogus"`,
+	},
+	{
+		name:           "run-0003",
+		args:           []string{"help"},
+		expectedCode:   exitOK,
+		expectedStdout: "view ",
+	},
+	{
+		name:           "run-0004",
+		args:           []string{"view", "no-such-file.log"},
+		expectedCode:   exitError,
+		expectedStderr: "no-such-file.log",
+	},
+	{
+		name:           "run-0005",
+		args:           []string{"view", "-level", "LOUD"},
+		expectedCode:   exitUsage,
+		expectedStderr: `-level "LOUD": invalid value`,
+	},
+	{
+		name:           "run-0006",
+		args:           []string{"view", "-query", "level >="},
+		expectedCode:   exitUsage,
+		expectedStderr: "-query: query.Compile error: at 9: expected a value",
+	},
+	{
+		name:           "run-0007",
+		args:           []string{"view", "-follow"},
+		expectedCode:   exitUsage,
+		expectedStderr: "-follow requires one file",
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test private functions
+// ----------------------------------------------------------------------------
+
+func Test_run(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForRun {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			actual := run(testCase.args, strings.NewReader(testLog), &stdout, &stderr)
+			assert.Equal(test, testCase.expectedCode, actual)
+			assert.Contains(test, stdout.String(), testCase.expectedStdout)
+			assert.Contains(test, stderr.String(), testCase.expectedStderr)
+		})
+	}
+}
+
+func Test_followFile(test *testing.T) {
+	test.Parallel()
+
+	var (
+		directory     = test.TempDir()
+		filename      = filepath.Join(directory, "test.log")
+		stateFilename = filepath.Join(directory, "test.state")
+		firstLine     = testLog[:strings.Index(testLog, "\n")+1]
+	)
+
+	require.NoError(test, os.WriteFile(filename, []byte(testLog), 0o600))
+	require.NoError(test, os.WriteFile(stateFilename, []byte(strconv.Itoa(len(firstLine))), 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var ids []string
+
+	err := followFile(ctx, filename, 0, stateFilename, func(source lineSource) error {
+		if source.Message() != nil {
+			ids = append(ids, source.Message().ID)
+		}
+
+		if len(ids) == 4 {
+			cancel()
+		}
+
+		return nil
+	})
+	require.NoError(test, err)
+	assert.Equal(test, []string{"SZSDK99982001", "SZSDK99983001", "SZSDK99974001", "SZSDK99985001"}, ids)
+
+	position, isSaved, err := readPosition(stateFilename)
+	require.NoError(test, err)
+	require.True(test, isSaved)
+	assert.Equal(test, int64(len(testLog)-len("shutting down\n")), position.Offset)
+	assert.Equal(test, int64(len(testLog)), position.Size)
+
+	// The saved position is not used once the file has been truncated.
+
+	require.NoError(test, os.WriteFile(filename, []byte(firstLine), 0o600))
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var lines []string
+
+	err = followFile(ctx, filename, 0, stateFilename, func(source lineSource) error {
+		lines = append(lines, source.Line())
+		cancel()
+
+		return nil
+	})
+	require.NoError(test, err)
+	assert.Equal(test, []string{strings.TrimSpace(firstLine)}, lines)
+}
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/senzing-garage/go-messaging/registry"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Renders messages of the components of a catalog.
+type renderer struct {
+	component   string
+	details     []interface{} // Appended to the details of every message, e.g. OptionMessageFields.
+	messengers  map[string]messenger.Messenger
+	output      string
+	registry    *registry.Registry
+	slogHandler slog.Handler
+	writer      io.Writer
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Values of the render -output flag.
+const outputSlog = "slog"
+
+// Prompt written before reading each line in interactive mode.
+const renderPrompt = "> "
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var (
+	errNoComponent  = errors.New("no component; use -component or an id")
+	errUnterminated = errors.New("unterminated quoted argument")
+)
+
+// ----------------------------------------------------------------------------
+// Command
+// ----------------------------------------------------------------------------
+
+// The "render" command.
+func runRender(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	var (
+		flagSet   = newFlagSet("render", "[id|message-number [detail ...]]", stderr)
+		catalog   = flagSet.String("catalog", "", "Catalog file: a registry, as written by registry.MarshalJSON, or a single component.")
+		component = flagSet.String("component", "", "Component of message numbers.  Default: the only component of the catalog.")
+		fields    = flagSet.String("fields", "", "Comma-separated fields to show, as in OptionMessageFields, or \"all\".  Default: SENZING_MESSAGE_FIELDS, else id and text.")
+		output    = flagSet.String("output", outputJSON, "Output format: json, as from NewJSON, or slog, as NewSlog output logged by a slog.JSONHandler.")
+	)
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	if len(*catalog) == 0 {
+		return usageError(fmt.Errorf("-catalog: %w", errInvalidValue))
+	}
+
+	aRenderer, err := newRenderer(*catalog, stdin, *component, *output, *fields, stdout)
+	if err != nil {
+		return err
+	}
+
+	if flagSet.NArg() > 0 {
+		return aRenderer.render(flagSet.Args())
+	}
+
+	return aRenderer.interact(stdin, stderr)
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Render a message for each line of input, reporting errors without stopping.
+func (aRenderer *renderer) interact(reader io.Reader, stderr io.Writer) error {
+	scanner := bufio.NewScanner(reader)
+
+	for fmt.Fprint(stderr, renderPrompt); scanner.Scan(); fmt.Fprint(stderr, renderPrompt) {
+		args, err := splitArgs(scanner.Text())
+		if err == nil && len(args) > 0 {
+			err = aRenderer.render(args)
+		}
+
+		if err != nil {
+			fmt.Fprintf(stderr, "%s\n", err)
+		}
+	}
+
+	fmt.Fprintln(stderr)
+
+	err := scanner.Err()
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// Render one message, given an id or message number and detail arguments.
+func (aRenderer *renderer) render(args []string) error {
+	componentName, messageNumber, err := aRenderer.resolve(args[0])
+	if err != nil {
+		return err
+	}
+
+	details := make([]interface{}, 0, len(args)-1+len(aRenderer.details))
+
+	for _, arg := range args[1:] {
+		detail, err := parseDetail(arg)
+		if err != nil {
+			return err
+		}
+
+		details = append(details, detail)
+	}
+
+	details = append(details, aRenderer.details...)
+	aMessenger := aRenderer.messengers[componentName]
+
+	if aRenderer.output == outputSlog {
+		message, level, keyValuePairs := aMessenger.NewSlogLevel(messageNumber, details...)
+		slog.New(aRenderer.slogHandler).Log(context.Background(), level, message, keyValuePairs...)
+
+		return nil
+	}
+
+	_, err = fmt.Fprintln(aRenderer.writer, aMessenger.NewJSON(messageNumber, details...))
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// Find the component and message number of an id, or of a message number of the default component.
+func (aRenderer *renderer) resolve(idOrNumber string) (string, int, error) {
+	messageNumber, err := strconv.Atoi(idOrNumber)
+	if err != nil {
+		resolution, err := aRenderer.registry.Resolve(idOrNumber)
+		if err != nil {
+			return "", 0, fmt.Errorf("%w", err)
+		}
+
+		return resolution.Component, resolution.MessageNumber, nil
+	}
+
+	if len(aRenderer.component) == 0 {
+		return "", 0, errNoComponent
+	}
+
+	return aRenderer.component, messageNumber, nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Create a renderer with a messenger for each component of a catalog.
+func newRenderer(catalog string, stdin io.Reader, component string, output string, fields string, stdout io.Writer) (*renderer, error) {
+	result := &renderer{
+		component:  component,
+		messengers: map[string]messenger.Messenger{},
+		output:     output,
+		registry:   registry.New(),
+		writer:     stdout,
+	}
+
+	switch output {
+	case outputJSON:
+	case outputSlog:
+		result.slogHandler = slog.NewJSONHandler(stdout, &slog.HandlerOptions{
+			Level:       messenger.LevelTraceSlog,
+			ReplaceAttr: replaceLevelName,
+		})
+	default:
+		return nil, usageError(fmt.Errorf("-output %q: %w", output, errInvalidValue))
+	}
+
+	switch fieldList := strings.ToLower(strings.TrimSpace(fields)); fieldList {
+	case "":
+	case "all":
+		result.details = append(result.details, messenger.OptionMessageFields{Value: messenger.AllMessageFields})
+	default:
+		for _, field := range splitList(fieldList) {
+			if !slices.Contains(messenger.AllMessageFields, field) && !slices.Contains(messenger.NamedMessageFields, field) {
+				return nil, usageError(fmt.Errorf("-fields %q: %w", field, errInvalidValue))
+			}
+		}
+
+		result.details = append(result.details, messenger.OptionMessageFields{Value: splitList(fieldList)})
+	}
+
+	components, err := readCatalogs([]string{catalog}, stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, aComponent := range components {
+		err = result.registry.Register(aComponent)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", catalog, err)
+		}
+
+		result.messengers[aComponent.Name], err = messenger.New(aComponent.Options()...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", aComponent.Name, err)
+		}
+	}
+
+	if len(result.component) == 0 && len(components) == 1 {
+		result.component = components[0].Name
+	}
+
+	if _, isKnown := result.messengers[result.component]; len(result.component) > 0 && !isKnown {
+		return nil, usageError(fmt.Errorf("-component %q: %w", result.component, errInvalidValue))
+	}
+
+	return result, nil
+}
+
+/*
+Convert a detail argument using its type hint:
+
+	int:5  float:1.5  bool:true  nil:  err:message  json:{"a":1}  duration:1.5s  map:KEY=value,KEY2=value2  str:text
+
+An argument without a known hint is a string.
+*/
+func parseDetail(arg string) (interface{}, error) {
+	hint, value, hasHint := strings.Cut(arg, ":")
+	if !hasHint {
+		return arg, nil
+	}
+
+	var (
+		result interface{}
+		err    error
+	)
+
+	switch hint {
+	case "bool":
+		result, err = strconv.ParseBool(value)
+	case "duration":
+		result, err = time.ParseDuration(value)
+	case "err":
+		result = errors.New(value) //nolint:err113
+	case "float":
+		result, err = strconv.ParseFloat(value, 64)
+	case "int":
+		result, err = strconv.Atoi(value)
+	case "json":
+		if !json.Valid([]byte(value)) {
+			err = fmt.Errorf("%q: %w", value, errInvalidValue)
+		}
+
+		result = value
+	case "map":
+		result, err = parseMapDetail(value)
+	case "nil":
+		result = nil
+	case "str":
+		result = value
+	default:
+		result = arg
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", hint, err)
+	}
+
+	return result, nil
+}
+
+// Parse "KEY=value,KEY2=value2" as a map[string]string detail.
+func parseMapDetail(value string) (map[string]string, error) {
+	result := map[string]string{}
+
+	for _, pair := range splitList(value) {
+		key, mapValue, isPair := strings.Cut(pair, "=")
+		if !isPair {
+			return nil, fmt.Errorf("%q: %w", pair, errInvalidValue)
+		}
+
+		result[key] = mapValue
+	}
+
+	return result, nil
+}
+
+// Name levels as messenger does, e.g. "TRACE" rather than "DEBUG-4".
+func replaceLevelName(_ []string, attr slog.Attr) slog.Attr {
+	if attr.Key != slog.LevelKey {
+		return attr
+	}
+
+	level, isLevel := attr.Value.Any().(slog.Level)
+	if name, isKnown := messenger.LevelToTextMap[level]; isLevel && isKnown {
+		attr.Value = slog.StringValue(name)
+	}
+
+	return attr
+}
+
+// Split a line into arguments at spaces.  Double-quoted arguments are Go string literals.
+func splitArgs(line string) ([]string, error) {
+	result := []string{}
+
+	for line = strings.TrimSpace(line); len(line) > 0; line = strings.TrimSpace(line) {
+		if line[0] != '"' {
+			arg, rest, _ := strings.Cut(line, " ")
+			result = append(result, arg)
+			line = rest
+
+			continue
+		}
+
+		quoted, err := strconv.QuotedPrefix(line)
+		if err != nil {
+			return nil, errUnterminated
+		}
+
+		arg, _ := strconv.Unquote(quoted)
+		result = append(result, arg)
+		line = line[len(quoted):]
+	}
+
+	return result, nil
+}
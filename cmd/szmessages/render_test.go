@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A single component catalog.
+const testComponent = `{"name":"example","idTemplate":"SZSDK9999%04d","idMessages":{"2001":"%s works with %s","4001":"Cannot load %d records"},"idStatuses":{"4001":"FAILED"}}`
+
+var testCasesForRender = []struct {
+	name     string
+	args     []string
+	input    string
+	expected string
+}{
+	{
+		name:     "render-0001",
+		args:     []string{"-fields", "id,text", "2001", "Bob", "str:Jane Doe"},
+		expected: `{"id":"SZSDK99992001","text":"Bob works with Jane Doe"}` + "\n",
+	},
+	{
+		name:     "render-0002",
+		args:     []string{"-fields", "id,text,status,errors,details", "SZSDK99994001", "int:5", "err:boom"},
+		expected: `{"id":"SZSDK99994001","text":"Cannot load 5 records","status":"FAILED","errors":["boom"],"details":[{"position":1,"type":"integer","value":"5","valueRaw":5},{"position":2,"type":"error","value":"boom"}]}` + "\n",
+	},
+	{
+		name:     "render-0003",
+		args:     []string{"-fields", "level,id,details", "2001", `json:{"a":1}`, "map:KEY=value"},
+		expected: `{"level":"INFO","id":"SZSDK99992001","details":[{"position":1,"type":"string","value":"{\"a\":1}","valueRaw":{"a":1}},{"key":"KEY","position":2,"type":"map[string]string","value":"value"}]}` + "\n",
+	},
+	{
+		name:     "render-0004",
+		args:     []string{"-fields", "id,text"},
+		input:    "2001 Bob \"Mary Ann\"\n\nSZSDK99994001 int:7\n",
+		expected: `{"id":"SZSDK99992001","text":"Bob works with Mary Ann"}` + "\n" + `{"id":"SZSDK99994001","text":"Cannot load 7 records"}` + "\n",
+	},
+	{
+		name:     "render-0005",
+		args:     []string{"-output", "slog", "4001", "int:3"},
+		expected: `"level":"ERROR","msg":"Cannot load 3 records","id":"SZSDK99994001"}` + "\n",
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test private functions
+// ----------------------------------------------------------------------------
+
+func Test_runRender(test *testing.T) {
+	test.Parallel()
+
+	catalog := filepath.Join(test.TempDir(), "catalog.json")
+	require.NoError(test, os.WriteFile(catalog, []byte(testComponent), 0o600))
+
+	for _, testCase := range testCasesForRender {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			args := append([]string{"-catalog", catalog}, testCase.args...)
+			err := runRender(args, strings.NewReader(testCase.input), &stdout, &stderr)
+			require.NoError(test, err)
+			assert.Contains(test, stdout.String(), testCase.expected)
+		})
+	}
+}
+
+func Test_runRender_errors(test *testing.T) {
+	test.Parallel()
+
+	var stdout, stderr bytes.Buffer
+
+	catalog := filepath.Join(test.TempDir(), "catalog.json")
+	require.NoError(test, os.WriteFile(catalog, []byte(testComponent), 0o600))
+
+	err := runRender([]string{"2001"}, strings.NewReader(""), &stdout, &stderr)
+	require.ErrorIs(test, err, errUsage)
+
+	err = runRender([]string{"-catalog", catalog, "-fields", "id,bogus", "2001"}, strings.NewReader(""), &stdout, &stderr)
+	require.ErrorIs(test, err, errUsage)
+
+	err = runRender([]string{"-catalog", catalog, "2001", "int:x"}, strings.NewReader(""), &stdout, &stderr)
+	require.Error(test, err)
+
+	err = runRender([]string{"-catalog", catalog}, strings.NewReader("SZSDK00000001\n"), &stdout, &stderr)
+	require.NoError(test, err)
+	assert.Contains(test, stderr.String(), "message id not registered")
+}
+
+func Test_parseDetail(test *testing.T) {
+	test.Parallel()
+
+	testCases := []struct {
+		arg      string
+		expected interface{}
+	}{
+		{arg: "plain", expected: "plain"},
+		{arg: "http://example.com", expected: "http://example.com"},
+		{arg: "str:int:5", expected: "int:5"},
+		{arg: "int:5", expected: 5},
+		{arg: "float:1.5", expected: 1.5},
+		{arg: "bool:true", expected: true},
+		{arg: "nil:", expected: nil},
+		{arg: "err:failed", expected: errors.New("failed")}, //nolint:err113
+		{arg: `json:[1,2]`, expected: "[1,2]"},
+		{arg: "duration:1.5s", expected: 1500 * time.Millisecond},
+		{arg: "map:A=1,B=2", expected: map[string]string{"A": "1", "B": "2"}},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := parseDetail(testCase.arg)
+		require.NoError(test, err, testCase.arg)
+		assert.Equal(test, testCase.expected, actual, testCase.arg)
+	}
+
+	for _, arg := range []string{"int:x", "float:x", "bool:x", "json:{", "duration:x", "map:A"} {
+		_, err := parseDetail(arg)
+		require.Error(test, err, arg)
+	}
+}
+
+func Test_splitArgs(test *testing.T) {
+	test.Parallel()
+
+	actual, err := splitArgs(`  2001  Bob "Mary \"Ann\""  int:5 `)
+	require.NoError(test, err)
+	assert.Equal(test, []string{"2001", "Bob", `Mary "Ann"`, "int:5"}, actual)
+
+	_, err = splitArgs(`2001 "Bob`)
+	require.ErrorIs(test, err, errUnterminated)
+}
@@ -0,0 +1,418 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/senzing-garage/go-messaging/go/typedef"
+	"github.com/senzing-garage/go-messaging/messenger"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Counts collected from one or more logs.
+type collector struct {
+	buckets    map[time.Time]*bucket
+	bucketSize time.Duration
+	codes      map[string]int
+	durations  []time.Duration
+	first      time.Time
+	ids        map[string]int
+	last       time.Time
+	levels     map[string]int
+	messages   int
+	otherLines int
+}
+
+// Messages and errors in one time bucket.
+type bucket struct {
+	Errors   int       `json:"errors"`
+	Messages int       `json:"messages"`
+	Rate     float64   `json:"rate"` // Errors divided by messages.
+	Start    time.Time `json:"start"`
+}
+
+// An id whose share of messages grew compared to the baseline.
+type spike struct {
+	Baseline int     `json:"baseline"` // Count in the baseline log.
+	Count    int     `json:"count"`    // Count in the compared log.
+	ID       string  `json:"id"`
+	Ratio    float64 `json:"ratio"` // Share of messages in the compared log divided by share in the baseline.
+}
+
+// The difference between a baseline log and the compared log.
+type comparison struct {
+	NewIDs      []valueCount `json:"newIds"`      // Ids absent from the baseline.
+	SpikingIDs  []spike      `json:"spikingIds"`  // Ids whose share of messages grew by at least the spike factor.
+	SpikeFactor float64      `json:"spikeFactor"` // As in the -spike flag.
+}
+
+// Percentiles of the "duration" field, in nanoseconds as in messages.
+type durationSummary struct {
+	Count int           `json:"count"`
+	Max   time.Duration `json:"max"`
+	Min   time.Duration `json:"min"`
+	P50   time.Duration `json:"p50"`
+	P90   time.Duration `json:"p90"`
+	P99   time.Duration `json:"p99"`
+}
+
+// The result of the "stats" command.
+type summary struct {
+	BucketSize time.Duration    `json:"bucketSize"`
+	Codes      []valueCount     `json:"codes"` // The most frequent codes.
+	Comparison *comparison      `json:"comparison,omitempty"`
+	Durations  *durationSummary `json:"durations,omitempty"`
+	ErrorRate  []bucket         `json:"errorRate"` // Buckets with messages, in time order.
+	First      *time.Time       `json:"first,omitempty"`
+	IDs        []valueCount     `json:"ids"` // The most frequent ids.
+	Last       *time.Time       `json:"last,omitempty"`
+	Levels     []valueCount     `json:"levels"` // In level order.
+	Messages   int              `json:"messages"`
+	OtherLines int              `json:"otherLines"`
+}
+
+type valueCount struct {
+	Count int    `json:"count"`
+	Value string `json:"value"`
+}
+
+// ----------------------------------------------------------------------------
+// Command
+// ----------------------------------------------------------------------------
+
+// The "stats" command.
+func runStats(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	var (
+		flagSet     = newFlagSet("stats", "[file ...]", stderr)
+		baseline    = flagSet.String("baseline", "", "Compare with this log, showing new and spiking ids.")
+		bucketSize  = flagSet.Duration("bucket", time.Minute, "Size of the time buckets of the error rate.")
+		output      = flagSet.String("output", outputText, "Output format: text or json.")
+		spikeFactor = flagSet.Float64("spike", 2, "With -baseline, the growth in an id's share of messages that makes it spiking.") //nolint:mnd
+		top         = flagSet.Int("top", 10, "Number of ids and codes to show.")                                                    //nolint:mnd
+	)
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	switch {
+	case *output != outputText && *output != outputJSON:
+		return usageError(fmt.Errorf("-output %q: %w", *output, errInvalidValue))
+	case *bucketSize <= 0:
+		return usageError(fmt.Errorf("-bucket %s: %w", *bucketSize, errInvalidValue))
+	case *spikeFactor <= 0:
+		return usageError(fmt.Errorf("-spike %g: %w", *spikeFactor, errInvalidValue))
+	case *top < 0:
+		return usageError(fmt.Errorf("-top %d: %w", *top, errInvalidValue))
+	}
+
+	current := newCollector(*bucketSize)
+
+	err = scanFiles(flagSet.Args(), stdin, current.scan)
+	if err != nil {
+		return err
+	}
+
+	result := current.summarize(*top)
+
+	if len(*baseline) > 0 {
+		previous := newCollector(*bucketSize)
+
+		err = scanFile(*baseline, stdin, previous.scan)
+		if err != nil {
+			return err
+		}
+
+		result.Comparison = current.compare(previous, *spikeFactor)
+	}
+
+	if *output == outputJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(result)
+	} else {
+		err = writeSummary(stdout, result)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Count the current line of a log.
+func (aCollector *collector) scan(source lineSource) error {
+	message := source.Message()
+	if message == nil {
+		aCollector.otherLines++
+
+		return nil
+	}
+
+	aCollector.add(message)
+
+	return nil
+}
+
+func (aCollector *collector) add(message *typedef.SenzingMessage) {
+	aCollector.messages++
+
+	if len(message.Level) > 0 {
+		aCollector.levels[message.Level]++
+	}
+
+	if len(message.ID) > 0 {
+		aCollector.ids[message.ID]++
+	}
+
+	if len(message.Code) > 0 {
+		aCollector.codes[message.Code]++
+	}
+
+	if message.Duration != 0 {
+		aCollector.durations = append(aCollector.durations, time.Duration(message.Duration))
+	}
+
+	if message.Time.IsZero() {
+		return
+	}
+
+	if aCollector.first.IsZero() || message.Time.Before(aCollector.first) {
+		aCollector.first = message.Time
+	}
+
+	if message.Time.After(aCollector.last) {
+		aCollector.last = message.Time
+	}
+
+	start := message.Time.UTC().Truncate(aCollector.bucketSize)
+
+	aBucket, isKnown := aCollector.buckets[start]
+	if !isKnown {
+		aBucket = &bucket{Start: start}
+		aCollector.buckets[start] = aBucket
+	}
+
+	aBucket.Messages++
+
+	if isError(message.Level) {
+		aBucket.Errors++
+	}
+}
+
+// Find ids that are new or spiking compared to a baseline.
+func (aCollector *collector) compare(baseline *collector, spikeFactor float64) *comparison {
+	result := &comparison{
+		NewIDs:      []valueCount{},
+		SpikingIDs:  []spike{},
+		SpikeFactor: spikeFactor,
+	}
+
+	for id, count := range aCollector.ids {
+		baselineCount := baseline.ids[id]
+		if baselineCount == 0 {
+			result.NewIDs = append(result.NewIDs, valueCount{Count: count, Value: id})
+
+			continue
+		}
+
+		ratio := (float64(count) / float64(aCollector.messages)) / (float64(baselineCount) / float64(baseline.messages))
+		if ratio >= spikeFactor {
+			result.SpikingIDs = append(result.SpikingIDs, spike{Baseline: baselineCount, Count: count, ID: id, Ratio: ratio})
+		}
+	}
+
+	sortCounts(result.NewIDs)
+	sort.Slice(result.SpikingIDs, func(i, j int) bool {
+		if result.SpikingIDs[i].Ratio != result.SpikingIDs[j].Ratio {
+			return result.SpikingIDs[i].Ratio > result.SpikingIDs[j].Ratio
+		}
+
+		return result.SpikingIDs[i].ID < result.SpikingIDs[j].ID
+	})
+
+	return result
+}
+
+func (aCollector *collector) summarize(top int) *summary {
+	result := &summary{
+		BucketSize: aCollector.bucketSize,
+		Codes:      topCounts(aCollector.codes, top),
+		ErrorRate:  []bucket{},
+		IDs:        topCounts(aCollector.ids, top),
+		Levels:     topCounts(aCollector.levels, len(aCollector.levels)),
+		Messages:   aCollector.messages,
+		OtherLines: aCollector.otherLines,
+	}
+
+	sort.SliceStable(result.Levels, func(i, j int) bool {
+		return levelOrder(result.Levels[i].Value) < levelOrder(result.Levels[j].Value)
+	})
+
+	if !aCollector.first.IsZero() {
+		result.First = &aCollector.first
+		result.Last = &aCollector.last
+	}
+
+	for _, aBucket := range aCollector.buckets {
+		aBucket.Rate = float64(aBucket.Errors) / float64(aBucket.Messages)
+		result.ErrorRate = append(result.ErrorRate, *aBucket)
+	}
+
+	sort.Slice(result.ErrorRate, func(i, j int) bool {
+		return result.ErrorRate[i].Start.Before(result.ErrorRate[j].Start)
+	})
+
+	if len(aCollector.durations) > 0 {
+		durations := append([]time.Duration{}, aCollector.durations...)
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+		result.Durations = &durationSummary{
+			Count: len(durations),
+			Max:   durations[len(durations)-1],
+			Min:   durations[0],
+			P50:   percentile(durations, 50), //nolint:mnd
+			P90:   percentile(durations, 90), //nolint:mnd
+			P99:   percentile(durations, 99), //nolint:mnd
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func newCollector(bucketSize time.Duration) *collector {
+	return &collector{
+		buckets:    map[time.Time]*bucket{},
+		bucketSize: bucketSize,
+		codes:      map[string]int{},
+		ids:        map[string]int{},
+		levels:     map[string]int{},
+	}
+}
+
+// Determine if a level is ERROR or above.
+func isError(level string) bool {
+	slogLevel, isKnown := messenger.TextToLevelMap[level]
+
+	return isKnown && slogLevel >= messenger.LevelErrorSlog
+}
+
+// The position of a level in TRACE to PANIC order, with unknown levels last.
+func levelOrder(level string) int {
+	slogLevel, isKnown := messenger.TextToLevelMap[level]
+	if !isKnown {
+		return math.MaxInt
+	}
+
+	return int(slogLevel)
+}
+
+// The nearest-rank percentile of sorted durations.
+func percentile(sorted []time.Duration, percent int) time.Duration {
+	rank := (percent*len(sorted) + 99) / 100 //nolint:mnd
+
+	return sorted[max(rank-1, 0)]
+}
+
+// Sort by descending count, then by value.
+func sortCounts(counts []valueCount) {
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+
+		return counts[i].Value < counts[j].Value
+	})
+}
+
+// The most frequent values, at most limit of them.
+func topCounts(counts map[string]int, limit int) []valueCount {
+	result := make([]valueCount, 0, len(counts))
+	for value, count := range counts {
+		result = append(result, valueCount{Count: count, Value: value})
+	}
+
+	sortCounts(result)
+
+	return result[:min(limit, len(result))]
+}
+
+// Write a summary as aligned tables.
+func writeSummary(writer io.Writer, aSummary *summary) error {
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0) //nolint:mnd
+	section := func(heading string, rows ...string) {
+		fmt.Fprintf(table, "\n%s\n", heading)
+
+		for _, row := range rows {
+			fmt.Fprintf(table, "%s\n", row)
+		}
+	}
+
+	fmt.Fprintf(table, "Messages\t%d\nOther lines\t%d\n", aSummary.Messages, aSummary.OtherLines)
+
+	if aSummary.First != nil {
+		fmt.Fprintf(table, "First\t%s\nLast\t%s\n", aSummary.First.Format(time.RFC3339Nano), aSummary.Last.Format(time.RFC3339Nano))
+	}
+
+	section("LEVEL\tCOUNT", countRows(aSummary.Levels)...)
+	section("ID\tCOUNT", countRows(aSummary.IDs)...)
+	section("CODE\tCOUNT", countRows(aSummary.Codes)...)
+
+	rows := []string{}
+	for _, aBucket := range aSummary.ErrorRate {
+		rows = append(rows, fmt.Sprintf("%s\t%d\t%d\t%.1f%%",
+			aBucket.Start.Format(time.RFC3339), aBucket.Messages, aBucket.Errors, 100*aBucket.Rate)) //nolint:mnd
+	}
+
+	section(fmt.Sprintf("ERRORS PER %s\tMESSAGES\tERRORS\tRATE", aSummary.BucketSize), rows...)
+
+	if durations := aSummary.Durations; durations != nil {
+		section("DURATIONS\tMIN\tP50\tP90\tP99\tMAX", fmt.Sprintf("%d\t%s\t%s\t%s\t%s\t%s",
+			durations.Count, durations.Min, durations.P50, durations.P90, durations.P99, durations.Max))
+	}
+
+	if aComparison := aSummary.Comparison; aComparison != nil {
+		section("NEW ID\tCOUNT", countRows(aComparison.NewIDs)...)
+
+		rows = []string{}
+		for _, aSpike := range aComparison.SpikingIDs {
+			rows = append(rows, fmt.Sprintf("%s\t%d\t%d\t%.1fx", aSpike.ID, aSpike.Baseline, aSpike.Count, aSpike.Ratio))
+		}
+
+		section("SPIKING ID\tBASELINE\tCOUNT\tRATIO", rows...)
+	}
+
+	err := table.Flush()
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+func countRows(counts []valueCount) []string {
+	result := make([]string, 0, len(counts))
+	for _, count := range counts {
+		result = append(result, fmt.Sprintf("%s\t%d", count.Value, count.Count))
+	}
+
+	return result
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A log in which SZSDK99982001 is an eighth of the messages, half its share in testLog.
+const testBaselineLog = `{"time":"2000-01-01T00:00:00Z","level":"INFO","id":"SZSDK99982001","text":"INFO: Bob works with Jane"}
+{"time":"2000-01-01T00:00:00Z","level":"ERROR","id":"SZSDK99974001","text":"ERROR: Bob failed"}
+{"time":"2000-01-01T00:00:00Z","level":"ERROR","id":"SZSDK99974001","text":"ERROR: Bob failed"}
+{"time":"2000-01-01T00:00:00Z","level":"ERROR","id":"SZSDK99974001","text":"ERROR: Bob failed"}
+{"time":"2000-01-01T00:00:00Z","level":"ERROR","id":"SZSDK99974001","text":"ERROR: Bob failed"}
+{"time":"2000-01-01T00:00:00Z","level":"ERROR","id":"SZSDK99974001","text":"ERROR: Bob failed"}
+{"time":"2000-01-01T00:00:00Z","level":"ERROR","id":"SZSDK99974001","text":"ERROR: Bob failed"}
+{"time":"2000-01-01T00:00:00Z","level":"ERROR","id":"SZSDK99974001","text":"ERROR: Bob failed"}
+`
+
+var testCasesForStats = []struct {
+	name     string
+	args     []string
+	expected string
+}{
+	{
+		name: "stats-0001",
+		args: []string{"-top", "1"},
+		expected: "Messages     4\n" +
+			"Other lines  2\n" +
+			"First        2000-01-01T00:00:00Z\n" +
+			"Last         2000-01-01T00:00:03Z\n" +
+			"\n" +
+			"LEVEL  COUNT\n" +
+			"INFO   1\n" +
+			"WARN   1\n" +
+			"ERROR  1\n" +
+			"FATAL  1\n" +
+			"\n" +
+			"ID             COUNT\n" +
+			"SZSDK99974001  1\n" +
+			"\n" +
+			"CODE  COUNT\n" +
+			"E27   1\n" +
+			"\n" +
+			"ERRORS PER 1m0s       MESSAGES  ERRORS  RATE\n" +
+			"2000-01-01T00:00:00Z  4         2       50.0%\n" +
+			"\n" +
+			"DURATIONS  MIN    P50    P90    P99    MAX\n" +
+			"1          1.5ms  1.5ms  1.5ms  1.5ms  1.5ms\n",
+	},
+	{
+		name:     "stats-0002",
+		args:     []string{"-bucket", "2s", "-top", "0"},
+		expected: "ERRORS PER 2s         MESSAGES  ERRORS  RATE\n2000-01-01T00:00:00Z  2         0       0.0%\n2000-01-01T00:00:02Z  2         2       100.0%\n",
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test private functions
+// ----------------------------------------------------------------------------
+
+func Test_runStats(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForStats {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			err := runStats(testCase.args, strings.NewReader(testLog), &stdout, &stderr)
+			require.NoError(test, err)
+			assert.Contains(test, stdout.String(), testCase.expected)
+		})
+	}
+}
+
+func Test_runStats_baseline(test *testing.T) {
+	test.Parallel()
+
+	var (
+		stdout, stderr bytes.Buffer
+		actual         summary
+	)
+
+	baseline := filepath.Join(test.TempDir(), "baseline.log")
+	require.NoError(test, os.WriteFile(baseline, []byte(testBaselineLog), 0o600))
+
+	err := runStats([]string{"-output", "json", "-baseline", baseline}, strings.NewReader(testLog), &stdout, &stderr)
+	require.NoError(test, err)
+	require.NoError(test, json.Unmarshal(stdout.Bytes(), &actual))
+
+	assert.Equal(test, 4, actual.Messages)
+	assert.Equal(test, time.Minute, actual.BucketSize)
+	assert.Equal(test, []valueCount{{Count: 1, Value: "INFO"}, {Count: 1, Value: "WARN"}, {Count: 1, Value: "ERROR"}, {Count: 1, Value: "FATAL"}}, actual.Levels)
+	require.NotNil(test, actual.Durations)
+	assert.Equal(test, 1500*time.Microsecond, actual.Durations.P99)
+	require.NotNil(test, actual.Comparison)
+	assert.Equal(test, []valueCount{{Count: 1, Value: "SZSDK99983001"}, {Count: 1, Value: "SZSDK99985001"}}, actual.Comparison.NewIDs)
+	assert.Equal(test, []spike{{Baseline: 1, Count: 1, ID: "SZSDK99982001", Ratio: 2}}, actual.Comparison.SpikingIDs)
+}
+
+func Test_runStats_spike(test *testing.T) {
+	test.Parallel()
+
+	var (
+		stdout, stderr bytes.Buffer
+		actual         summary
+	)
+
+	baseline := filepath.Join(test.TempDir(), "baseline.log")
+	require.NoError(test, os.WriteFile(baseline, []byte(testBaselineLog), 0o600))
+
+	err := runStats([]string{"-output", "json", "-baseline", baseline, "-spike", "2.5"}, strings.NewReader(testLog), &stdout, &stderr)
+	require.NoError(test, err)
+	require.NoError(test, json.Unmarshal(stdout.Bytes(), &actual))
+	require.NotNil(test, actual.Comparison)
+	assert.Equal(test, []spike{}, actual.Comparison.SpikingIDs)
+}
+
+func Test_percentile(test *testing.T) {
+	test.Parallel()
+
+	durations := []time.Duration{}
+	for index := 1; index <= 100; index++ {
+		durations = append(durations, time.Duration(index))
+	}
+
+	assert.Equal(test, time.Duration(1), percentile(durations, 0))
+	assert.Equal(test, time.Duration(50), percentile(durations, 50))
+	assert.Equal(test, time.Duration(99), percentile(durations, 99))
+	assert.Equal(test, time.Duration(100), percentile(durations, 100))
+	assert.Equal(test, time.Duration(7), percentile(durations[6:7], 50))
+}
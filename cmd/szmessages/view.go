@@ -0,0 +1,426 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/senzing-garage/go-messaging/go/typedef"
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/senzing-garage/go-messaging/parser"
+	"github.com/senzing-garage/go-messaging/query"
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Criteria a message must meet to be shown.  Zero values match every message.
+type filter struct {
+	codePattern  *regexp.Regexp
+	idPrefixes   []string
+	ids          map[string]bool
+	minimumLevel *slog.Level
+	query        *query.Query
+	since        time.Time
+	textPattern  *regexp.Regexp
+	until        time.Time
+}
+
+// How messages are written.
+type viewer struct {
+	color     bool
+	output    string
+	skipOther bool
+	writer    io.Writer
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Values of the -output flag.
+const (
+	outputJSON = "json"
+	outputText = "text"
+)
+
+// Values of the -color flag.
+const (
+	colorAlways = "always"
+	colorAuto   = "auto"
+	colorNever  = "never"
+)
+
+// ANSI escape sequences.
+const (
+	ansiBold  = "\033[1m"
+	ansiDim   = "\033[2m"
+	ansiReset = "\033[0m"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var levelColors = map[string]string{
+	messenger.LevelTraceName: "\033[90m",
+	messenger.LevelDebugName: "\033[36m",
+	messenger.LevelInfoName:  "\033[32m",
+	messenger.LevelWarnName:  "\033[33m",
+	messenger.LevelErrorName: "\033[31m",
+	messenger.LevelFatalName: "\033[1;31m",
+	messenger.LevelPanicName: "\033[1;35m",
+}
+
+// ----------------------------------------------------------------------------
+// Command
+// ----------------------------------------------------------------------------
+
+// The "view" command.
+func runView(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	var (
+		aFilter   = &filter{}
+		aViewer   = &viewer{writer: stdout}
+		flagSet   = newFlagSet("view", "[file ...]", stderr)
+		code      = flagSet.String("code", "", "Show messages whose code matches this regular expression.")
+		color     = flagSet.String("color", colorAuto, "Colorize text output: auto, always, or never.")
+		follow    = flagSet.Bool("follow", false, "Wait for lines appended to the file, as \"tail -F\" does, until interrupted.")
+		id        = flagSet.String("id", "", "Show messages with one of these comma-separated ids.")
+		idPrefix  = flagSet.String("id-prefix", "", "Show messages whose id starts with one of these comma-separated prefixes.")
+		level     = flagSet.String("level", "", "Show messages at this level or above, e.g. WARN.")
+		offset    = flagSet.Int64("offset", parser.FollowFromEnd, "With -follow, the byte offset at which to start.  Default: the end of the file.")
+		output    = flagSet.String("output", outputText, "Output format: text or json.")
+		queryText = flagSet.String("query", "", "Show messages matching this query, e.g. 'level >= WARN and errors contains \"0027E\"'.")
+		since     = flagSet.String("since", "", "Show messages at or after this RFC3339 time.")
+		state     = flagSet.String("state", "", "With -follow, a file holding the position at which to resume, saved when interrupted.")
+		text      = flagSet.String("text", "", "Show messages whose text matches this regular expression.")
+		until     = flagSet.String("until", "", "Show messages before this RFC3339 time.")
+	)
+
+	flagSet.BoolVar(&aViewer.skipOther, "skip-other", false, "Drop lines that are not messages.")
+
+	err := flagSet.Parse(args)
+	if err != nil {
+		return usageError(err)
+	}
+
+	err = aFilter.set(*code, *id, *idPrefix, *level, *since, *text, *until, *queryText)
+	if err != nil {
+		return usageError(err)
+	}
+
+	err = aViewer.set(*output, *color, stdout)
+	if err != nil {
+		return usageError(err)
+	}
+
+	scan := func(source lineSource) error {
+		message := source.Message()
+		if message == nil {
+			return aViewer.writeOther(source.Line())
+		}
+
+		if !aFilter.matches(message) {
+			return nil
+		}
+
+		return aViewer.writeMessage(source.Line(), message)
+	}
+
+	if !*follow {
+		return scanFiles(flagSet.Args(), stdin, scan)
+	}
+
+	if flagSet.NArg() != 1 || flagSet.Arg(0) == "-" {
+		return usageError(fmt.Errorf("-follow requires one file: %w", errInvalidValue))
+	}
+
+	ctx, cancel := followContext()
+	defer cancel()
+
+	return followFile(ctx, flagSet.Arg(0), *offset, *state, scan)
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Determine if a message meets all criteria.
+func (aFilter *filter) matches(message *typedef.SenzingMessage) bool {
+	if aFilter.minimumLevel != nil {
+		level, isKnown := messenger.TextToLevelMap[message.Level]
+		if !isKnown || level < *aFilter.minimumLevel {
+			return false
+		}
+	}
+
+	if len(aFilter.ids) > 0 && !aFilter.ids[message.ID] {
+		return false
+	}
+
+	if len(aFilter.idPrefixes) > 0 && !hasAnyPrefix(message.ID, aFilter.idPrefixes) {
+		return false
+	}
+
+	if !aFilter.since.IsZero() && (message.Time.IsZero() || message.Time.Before(aFilter.since)) {
+		return false
+	}
+
+	if !aFilter.until.IsZero() && (message.Time.IsZero() || !message.Time.Before(aFilter.until)) {
+		return false
+	}
+
+	if aFilter.codePattern != nil && !aFilter.codePattern.MatchString(message.Code) {
+		return false
+	}
+
+	if aFilter.textPattern != nil && !aFilter.textPattern.MatchString(message.Text) {
+		return false
+	}
+
+	if aFilter.query != nil && !aFilter.query.Match(message) {
+		return false
+	}
+
+	return true
+}
+
+// Set criteria from flag values.
+func (aFilter *filter) set(code, id, idPrefix, level, since, text, until, queryText string) error {
+	var err error
+
+	if len(code) > 0 {
+		aFilter.codePattern, err = regexp.Compile(code)
+		if err != nil {
+			return fmt.Errorf("-code: %w", err)
+		}
+	}
+
+	if len(text) > 0 {
+		aFilter.textPattern, err = regexp.Compile(text)
+		if err != nil {
+			return fmt.Errorf("-text: %w", err)
+		}
+	}
+
+	if len(id) > 0 {
+		aFilter.ids = map[string]bool{}
+		for _, value := range splitList(id) {
+			aFilter.ids[value] = true
+		}
+	}
+
+	aFilter.idPrefixes = splitList(idPrefix)
+
+	if len(level) > 0 {
+		minimumLevel, isKnown := messenger.TextToLevelMap[strings.ToUpper(level)]
+		if !isKnown {
+			return fmt.Errorf("-level %q: %w", level, errInvalidValue)
+		}
+
+		aFilter.minimumLevel = &minimumLevel
+	}
+
+	if len(queryText) > 0 {
+		aFilter.query, err = query.Compile(queryText)
+		if err != nil {
+			return fmt.Errorf("-query: %w", err)
+		}
+	}
+
+	aFilter.since, err = parseTime(since)
+	if err != nil {
+		return fmt.Errorf("-since: %w", err)
+	}
+
+	aFilter.until, err = parseTime(until)
+	if err != nil {
+		return fmt.Errorf("-until: %w", err)
+	}
+
+	return nil
+}
+
+// Set the output format and colorization from flag values.
+func (aViewer *viewer) set(output string, color string, stdout io.Writer) error {
+	switch output {
+	case outputJSON, outputText:
+		aViewer.output = output
+	default:
+		return fmt.Errorf("-output %q: %w", output, errInvalidValue)
+	}
+
+	switch color {
+	case colorAlways:
+		aViewer.color = true
+	case colorAuto:
+		aViewer.color = isTerminal(stdout) && len(os.Getenv("NO_COLOR")) == 0
+	case colorNever:
+		aViewer.color = false
+	default:
+		return fmt.Errorf("-color %q: %w", color, errInvalidValue)
+	}
+
+	return nil
+}
+
+// Write a message as the original JSON or as text.
+func (aViewer *viewer) writeMessage(line string, message *typedef.SenzingMessage) error {
+	var err error
+
+	if aViewer.output == outputJSON {
+		_, err = fmt.Fprintln(aViewer.writer, line[strings.Index(line, "{"):])
+	} else {
+		_, err = io.WriteString(aViewer.writer, aViewer.formatText(message))
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// Write a line that is not a message, unless -skip-other.
+func (aViewer *viewer) writeOther(line string) error {
+	if aViewer.skipOther {
+		return nil
+	}
+
+	if aViewer.color && aViewer.output == outputText {
+		line = ansiDim + line + ansiReset
+	}
+
+	_, err := fmt.Fprintln(aViewer.writer, line)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// Format a message for people: a heading line, then indented fields, errors, and details.
+func (aViewer *viewer) formatText(message *typedef.SenzingMessage) string {
+	var result strings.Builder
+
+	heading := []string{}
+	if !message.Time.IsZero() {
+		heading = append(heading, message.Time.Format(time.RFC3339Nano))
+	}
+
+	if len(message.Level) > 0 {
+		heading = append(heading, aViewer.paint(levelColors[message.Level], fmt.Sprintf("%-5s", message.Level)))
+	}
+
+	if len(message.ID) > 0 {
+		heading = append(heading, aViewer.paint(ansiBold, message.ID))
+	}
+
+	if len(message.Text) > 0 {
+		heading = append(heading, message.Text)
+	}
+
+	result.WriteString(strings.Join(heading, " "))
+	result.WriteString("\n")
+
+	fields := [][2]string{
+		{"code", message.Code},
+		{"reason", message.Reason},
+		{"remediation", message.Remediation},
+		{"help", message.Help},
+		{"status", message.Status},
+		{"location", message.Location},
+	}
+	if message.Duration != 0 {
+		fields = append(fields, [2]string{"duration", time.Duration(message.Duration).String()})
+	}
+
+	for _, field := range fields {
+		if len(field[1]) > 0 {
+			fmt.Fprintf(&result, "    %s: %s\n", aViewer.paint(ansiDim, field[0]), field[1])
+		}
+	}
+
+	for _, anError := range message.Errors {
+		fmt.Fprintf(&result, "    %s %s\n", aViewer.paint(ansiDim, "error:"), anError)
+	}
+
+	for _, detail := range message.Details {
+		name := strconv.Itoa(int(detail.Position))
+		if len(detail.Key) > 0 {
+			name = detail.Key
+		}
+
+		fmt.Fprintf(&result, "    %s %s\n", aViewer.paint(ansiDim, "["+name+"]"), detail.Value)
+	}
+
+	return result.String()
+}
+
+// Surround text with an ANSI escape sequence if colorizing.
+func (aViewer *viewer) paint(escape string, text string) string {
+	if !aViewer.color || len(escape) == 0 {
+		return text
+	}
+
+	return escape + text + ansiReset
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func hasAnyPrefix(text string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(text, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Determine if the writer is a terminal, so that colors are appropriate.
+func isTerminal(writer io.Writer) bool {
+	file, isFile := writer.(*os.File)
+	if !isFile {
+		return false
+	}
+
+	fileInfo, err := file.Stat()
+
+	return err == nil && fileInfo.Mode()&os.ModeCharDevice != 0
+}
+
+func parseTime(value string) (time.Time, error) {
+	if len(value) == 0 {
+		return time.Time{}, nil
+	}
+
+	result, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w", err)
+	}
+
+	return result, nil
+}
+
+// Split a comma-separated flag value, dropping empty entries.
+func splitList(value string) []string {
+	var result []string
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if len(entry) > 0 {
+			result = append(result, entry)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCasesForView = []struct {
+	name     string
+	args     []string
+	expected string
+}{
+	{
+		name: "view-0001",
+		args: []string{"-level", "warn", "-skip-other", "-color", "never"},
+		expected: "2000-01-01T00:00:01Z WARN  SZSDK99983001 WARN: Bob works with Mary\n" +
+			"    code: W1\n" +
+			"    duration: 1.5ms\n" +
+			"2000-01-01T00:00:02Z ERROR SZSDK99974001 ERROR: Bob failed\n" +
+			"    code: E27\n" +
+			"    error: 0027E bad\n" +
+			"    [DATA_SOURCE] TEST\n" +
+			"    [2] Jane\n" +
+			"2000-01-01T00:00:03Z FATAL SZSDK99985001 FATAL: Jane failed\n" +
+			"    code: F1\n",
+	},
+	{
+		name: "view-0002",
+		args: []string{"-output", "json", "-id-prefix", "SZSDK9997,SZSDK99985"},
+		expected: "starting up\n" +
+			`{"time":"2000-01-01T00:00:02Z","level":"ERROR","id":"SZSDK99974001","text":"ERROR: Bob failed","code":"E27","errors":["0027E bad"],"details":[{"key":"DATA_SOURCE","position":1,"value":"TEST"},{"position":2,"value":"Jane"}]}` + "\n" +
+			`{"time":"2000-01-01T00:00:03Z","level":"FATAL","id":"SZSDK99985001","text":"FATAL: Jane failed","code":"F1"}` + "\n" +
+			"shutting down\n",
+	},
+	{
+		name:     "view-0003",
+		args:     []string{"-output", "json", "-skip-other", "-id", "SZSDK99982001"},
+		expected: `{"time":"2000-01-01T00:00:00Z","level":"INFO","id":"SZSDK99982001","text":"INFO: Bob works with Jane","status":"OK"}` + "\n",
+	},
+	{
+		name:     "view-0004",
+		args:     []string{"-skip-other", "-color", "never", "-since", "2000-01-01T00:00:01Z", "-until", "2000-01-01T00:00:03Z", "-code", "^E"},
+		expected: "2000-01-01T00:00:02Z ERROR SZSDK99974001 ERROR: Bob failed\n    code: E27\n    error: 0027E bad\n    [DATA_SOURCE] TEST\n    [2] Jane\n",
+	},
+	{
+		name:     "view-0005",
+		args:     []string{"-skip-other", "-color", "always", "-text", "Jane$"},
+		expected: "2000-01-01T00:00:00Z \033[32mINFO \033[0m \033[1mSZSDK99982001\033[0m INFO: Bob works with Jane\n    \033[2mstatus\033[0m: OK\n",
+	},
+	{
+		name: "view-0006",
+		args: []string{"-output", "json", "-skip-other", "-query", `level >= WARN and (detail.2 == Jane or code == F1)`},
+		expected: `{"time":"2000-01-01T00:00:02Z","level":"ERROR","id":"SZSDK99974001","text":"ERROR: Bob failed","code":"E27","errors":["0027E bad"],"details":[{"key":"DATA_SOURCE","position":1,"value":"TEST"},{"position":2,"value":"Jane"}]}` + "\n" +
+			`{"time":"2000-01-01T00:00:03Z","level":"FATAL","id":"SZSDK99985001","text":"FATAL: Jane failed","code":"F1"}` + "\n",
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test private functions
+// ----------------------------------------------------------------------------
+
+func Test_runView(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForView {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			err := runView(testCase.args, strings.NewReader(testLog), &stdout, &stderr)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, stdout.String())
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Code generated by jtd-codegen for C# + System.Text.Json v0.2.1
+
+using System.Text.Json.Serialization;
+
+namespace Senzing
+{
+    /// <summary>
+    /// A location in the code.
+    /// </summary>
+    public class Frame
+    {
+        /// <summary>
+        /// Source file of the frame.
+        /// </summary>
+        [JsonPropertyName("file")]
+        public string File { get; set; }
+
+        /// <summary>
+        /// Function, without the package path.
+        /// </summary>
+        [JsonPropertyName("function")]
+        public string Function { get; set; }
+
+        /// <summary>
+        /// Line number in the source file.
+        /// </summary>
+        [JsonPropertyName("line")]
+        public int Line { get; set; }
+
+        /// <summary>
+        /// Package, as an import path.
+        /// </summary>
+        [JsonPropertyName("package")]
+        public string Package { get; set; }
+    }
+}
@@ -0,0 +1,34 @@
+// Code generated by jtd-codegen for C# + System.Text.Json v0.2.1
+
+using System;
+using System.Collections.Generic;
+using System.Text.Json;
+using System.Text.Json.Serialization;
+
+namespace Senzing
+{
+    /// <summary>
+    /// A list of frames.  The innermost frame is first.
+    /// </summary>
+    [JsonConverter(typeof(StackJsonConverter))]
+    public class Stack
+    {
+        /// <summary>
+        /// The underlying data being wrapped.
+        /// </summary>
+        public IList<Frame> Value { get; set; }
+    }
+
+    public class StackJsonConverter : JsonConverter<Stack>
+    {
+        public override Stack Read(ref Utf8JsonReader reader, Type typeToConvert, JsonSerializerOptions options)
+        {
+            return new Stack { Value = JsonSerializer.Deserialize<IList<Frame>>(ref reader, options) };
+        }
+
+        public override void Write(Utf8JsonWriter writer, Stack value, JsonSerializerOptions options)
+        {
+            JsonSerializer.Serialize<IList<Frame>>(writer, value.Value, options);
+        }
+    }
+}
@@ -0,0 +1,14 @@
+/*
+The referencegen command generates error reference pages from message catalogs.
+
+Its input is a registry written as JSON, usually by json.Marshal(registry.Default())
+in a program that has registered the catalogs of its components.
+Its output is a Markdown or HTML page with an anchor for each message id,
+so that a support site can link directly to an error.
+
+Usage:
+
+	go run ./go/referencegen -registry registry.json -format markdown -out docs/errors.md
+	go run ./go/referencegen -registry registry.json -format html -title "Senzing errors" -out errors.html
+*/
+package main
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/senzing-garage/go-messaging/registry"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Values of the -format flag.
+const (
+	formatHTML     = "html"
+	formatMarkdown = "markdown"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var errUnsupportedFormat = errors.New("unsupported format")
+
+// ----------------------------------------------------------------------------
+// Main
+// ----------------------------------------------------------------------------
+
+func main() {
+	var (
+		format           = flag.String("format", formatMarkdown, "Output format: markdown or html.")
+		outFilename      = flag.String("out", "", "File to write. Standard output if empty.")
+		registryFilename = flag.String("registry", "registry.json", "Registry written as JSON.")
+		title            = flag.String("title", "Error reference", "Title of the page.")
+	)
+
+	flag.Parse()
+
+	result, err := generateFile(*registryFilename, *format, *title)
+	if err != nil {
+		exitOnError(err)
+	}
+
+	if len(*outFilename) == 0 {
+		_, err = os.Stdout.Write(result)
+	} else {
+		err = os.WriteFile(*outFilename, result, 0o644) //nolint:gosec
+	}
+
+	if err != nil {
+		exitOnError(err)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func exitOnError(err error) {
+	fmt.Fprintf(os.Stderr, "referencegen: %s\n", err)
+	os.Exit(1)
+}
+
+// Read a registry file and generate a reference page from it.
+func generateFile(registryFilename string, format string, title string) ([]byte, error) {
+	registryJSON, err := os.ReadFile(registryFilename) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("referencegen: %w", err)
+	}
+
+	aRegistry := registry.New()
+
+	err = aRegistry.UnmarshalJSON(registryJSON)
+	if err != nil {
+		return nil, fmt.Errorf("referencegen: %w", err)
+	}
+
+	var result bytes.Buffer
+
+	switch format {
+	case formatHTML:
+		err = aRegistry.WriteHTML(&result, title)
+	case formatMarkdown:
+		err = aRegistry.WriteMarkdown(&result, title)
+	default:
+		err = fmt.Errorf("%q: %w", format, errUnsupportedFormat)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("referencegen: %w", err)
+	}
+
+	return result.Bytes(), nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const registryJSON = `{"components":[{"name":"example","idTemplate":"SZSDK9998%04d","idMin":0,"idMax":9999,` +
+	`"idMessages":{"4001":"Cannot open %s"},"remediations":{"4001":"Verify the file exists."}}]}`
+
+var testCasesForGenerateFile = []struct {
+	name             string
+	format           string
+	expectedContains string
+	expectedErr      error
+}{
+	{
+		name:             "referencegen-0001",
+		format:           formatMarkdown,
+		expectedContains: "<a id=\"SZSDK99984001\"></a>\n\n### SZSDK99984001\n",
+	},
+	{
+		name:             "referencegen-0002",
+		format:           formatHTML,
+		expectedContains: `<article id="SZSDK99984001">`,
+	},
+	{
+		name:        "referencegen-0003",
+		format:      "pdf",
+		expectedErr: errUnsupportedFormat,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test private functions
+// ----------------------------------------------------------------------------
+
+func Test_generateFile(test *testing.T) {
+	test.Parallel()
+
+	registryFilename := filepath.Join(test.TempDir(), "registry.json")
+	require.NoError(test, os.WriteFile(registryFilename, []byte(registryJSON), 0o600))
+
+	for _, testCase := range testCasesForGenerateFile {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := generateFile(registryFilename, testCase.format, "Errors")
+			if testCase.expectedErr != nil {
+				require.ErrorIs(test, err, testCase.expectedErr)
+
+				return
+			}
+
+			require.NoError(test, err)
+			assert.Contains(test, string(actual), testCase.expectedContains)
+			assert.Contains(test, string(actual), "Verify the file exists.")
+		})
+	}
+}
+
+func Test_generateFile_badRegistry(test *testing.T) {
+	test.Parallel()
+
+	_, err := generateFile("no-such-file.json", formatMarkdown, "Errors")
+	require.Error(test, err)
+}
@@ -2,3 +2,5 @@
 Package typedef defines the message fields and is generated from message-RFC8927.json.
 */
 package typedef
+
+//go:generate go run ../typedefgen -schema ../../message-RFC8927.json -package typedef -out typedef.go
@@ -1,10 +1,13 @@
-// Code generated by jtd-codegen for Go v0.2.1. DO NOT EDIT.
+// Code generated by typedefgen from message-RFC8927.json. DO NOT EDIT.
 
 package typedef
 
 import "time"
 
 type SenzingMessage struct {
+	// Location in the code, as package, function, file, and line.
+	Caller *Frame `json:"caller,omitempty"`
+
 	// Code for message.
 	Code string `json:"code"`
 
@@ -17,6 +20,9 @@ type SenzingMessage struct {
 	// A list of errors.  Usually a stack of errors.
 	Errors Errors `json:"errors"`
 
+	// URL of documentation for the message.
+	Help string `json:"help,omitempty"`
+
 	// The unique identification of the message.
 	ID string `json:"id"`
 
@@ -30,6 +36,12 @@ type SenzingMessage struct {
 	// Reason for message.
 	Reason string `json:"reason"`
 
+	// What to do about the message.
+	Remediation string `json:"remediation,omitempty"`
+
+	// Stack trace where the message or its error was created.
+	Stack Stack `json:"stack,omitempty"`
+
 	// User-defined status of message.
 	Status string `json:"status"`
 
@@ -66,3 +78,21 @@ type Error = string
 
 // A list of errors.  Usually a stack of errors.
 type Errors = []Error
+
+// A location in the code.
+type Frame struct {
+	// Source file of the frame.
+	File string `json:"file"`
+
+	// Function, without the package path.
+	Function string `json:"function"`
+
+	// Line number in the source file.
+	Line int32 `json:"line"`
+
+	// Package, as an import path.
+	Package string `json:"package"`
+}
+
+// A list of frames.  The innermost frame is first.
+type Stack = []Frame
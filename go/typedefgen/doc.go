@@ -0,0 +1,16 @@
+/*
+The typedefgen command generates Go types from message-RFC8927.json.
+
+It produces two files from the one schema:
+
+  - The consumer types in package typedef, used by the parser to read messages.
+  - The producer types in package messenger, used to write messages.
+
+Usage:
+
+	go run ./go/typedefgen -schema message-RFC8927.json -package typedef -out go/typedef/typedef.go
+	go run ./go/typedefgen -schema message-RFC8927.json -package messenger -producer -root-name MessageFormat -out messenger/message_format.go
+
+Normally it is run by "go generate ./...".
+*/
+package main
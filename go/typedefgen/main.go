@@ -0,0 +1,432 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/senzing-garage/go-messaging/jtd"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+type generator struct {
+	packageName    string
+	producer       bool // If true, generate types for writing messages; otherwise for reading them.
+	rootName       string
+	schema         *jtd.Schema
+	schemaFilename string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Comments are wrapped so that "\t// " plus the text fits in 80 columns.
+const commentWidth = 73
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var errUnsupportedForm = errors.New("unsupported schema form")
+
+// Go initialisms.  See https://go.dev/wiki/CodeReviewComments#initialisms
+var initialisms = map[string]string{
+	"api":  "API",
+	"html": "HTML",
+	"http": "HTTP",
+	"id":   "ID",
+	"json": "JSON",
+	"uri":  "URI",
+	"url":  "URL",
+	"uuid": "UUID",
+}
+
+var jtdTypeToGoType = map[string]string{
+	jtd.TypeBoolean:   "bool",
+	jtd.TypeFloat32:   "float32",
+	jtd.TypeFloat64:   "float64",
+	jtd.TypeInt8:      "int8",
+	jtd.TypeInt16:     "int16",
+	jtd.TypeInt32:     "int32",
+	jtd.TypeString:    "string",
+	jtd.TypeTimestamp: "time.Time",
+	jtd.TypeUint8:     "uint8",
+	jtd.TypeUint16:    "uint16",
+	jtd.TypeUint32:    "uint32",
+}
+
+// ----------------------------------------------------------------------------
+// Main
+// ----------------------------------------------------------------------------
+
+func main() {
+	var (
+		outFilename    = flag.String("out", "", "File to write. Standard output if empty.")
+		packageName    = flag.String("package", "typedef", "Go package of the generated file.")
+		producer       = flag.Bool("producer", false, "Generate producer (messenger) types instead of consumer (typedef) types.")
+		rootName       = flag.String("root-name", "SenzingMessage", "Name of the type generated from the root schema.")
+		schemaFilename = flag.String("schema", "message-RFC8927.json", "RFC 8927 schema to generate from.")
+	)
+
+	flag.Parse()
+
+	result, err := generateFile(*schemaFilename, *packageName, *rootName, *producer)
+	if err != nil {
+		exitOnError(err)
+	}
+
+	if len(*outFilename) == 0 {
+		_, err = os.Stdout.Write(result)
+	} else {
+		err = os.WriteFile(*outFilename, result, 0o644) //nolint:gosec
+	}
+
+	if err != nil {
+		exitOnError(err)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func exitOnError(err error) {
+	fmt.Fprintf(os.Stderr, "typedefgen: %s\n", err)
+	os.Exit(1)
+}
+
+// Read a schema file and generate formatted Go source code from it.
+func generateFile(schemaFilename string, packageName string, rootName string, producer bool) ([]byte, error) {
+	schemaJSON, err := os.ReadFile(schemaFilename) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("typedefgen: %w", err)
+	}
+
+	schema, err := jtd.Parse(schemaJSON)
+	if err != nil {
+		return nil, fmt.Errorf("typedefgen: %w", err)
+	}
+
+	aGenerator := &generator{
+		packageName:    packageName,
+		producer:       producer,
+		rootName:       rootName,
+		schema:         schema,
+		schemaFilename: filepath.Base(schemaFilename),
+	}
+
+	return aGenerator.generate()
+}
+
+// Convert a JSON property or definition name to an exported Go identifier.
+func goName(name string) string {
+	var (
+		result strings.Builder
+		word   strings.Builder
+	)
+
+	flush := func() {
+		if word.Len() == 0 {
+			return
+		}
+
+		lowerWord := strings.ToLower(word.String())
+		if initialism, ok := initialisms[lowerWord]; ok {
+			result.WriteString(initialism)
+		} else {
+			runes := []rune(lowerWord)
+			runes[0] = unicode.ToUpper(runes[0])
+			result.WriteString(string(runes))
+		}
+
+		word.Reset()
+	}
+
+	for _, character := range name {
+		switch {
+		case !unicode.IsLetter(character) && !unicode.IsDigit(character):
+			flush()
+		case unicode.IsUpper(character):
+			flush()
+			word.WriteRune(character)
+		default:
+			word.WriteRune(character)
+		}
+	}
+
+	flush()
+
+	return result.String()
+}
+
+func metadataString(schema *jtd.Schema, key string) string {
+	value, ok := schema.Metadata[key].(string)
+	if !ok {
+		return ""
+	}
+
+	return value
+}
+
+// Property names, in "propertyOrder" metadata order if present, otherwise alphabetically.
+func propertyNames(schema *jtd.Schema, useOrderMetadata bool) []string {
+	result := []string{}
+
+	if useOrderMetadata {
+		if order, ok := schema.Metadata["propertyOrder"].([]interface{}); ok {
+			for _, name := range order {
+				if nameString, isString := name.(string); isString {
+					result = append(result, nameString)
+				}
+			}
+
+			return result
+		}
+	}
+
+	for name := range schema.Properties {
+		result = append(result, name)
+	}
+
+	for name := range schema.OptionalProperties {
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// Word-wrap a description into comment lines.
+func wrapComment(text string, indent string) string {
+	var (
+		result strings.Builder
+		line   string
+	)
+
+	for _, word := range strings.Split(text, " ") {
+		switch {
+		case len(line) == 0:
+			line = word
+		case len(line)+1+len(word) > commentWidth:
+			result.WriteString(indent + "// " + line + "\n")
+			line = word
+		default:
+			line += " " + word
+		}
+	}
+
+	if len(line) > 0 {
+		result.WriteString(indent + "// " + line + "\n")
+	}
+
+	return result.String()
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (aGenerator *generator) generate() ([]byte, error) {
+	var body bytes.Buffer
+
+	err := aGenerator.writeStruct(&body, aGenerator.rootName, aGenerator.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	definitionNames := make([]string, 0, len(aGenerator.schema.Definitions))
+	for name := range aGenerator.schema.Definitions {
+		definitionNames = append(definitionNames, name)
+	}
+
+	sort.Strings(definitionNames)
+
+	for _, name := range definitionNames {
+		err = aGenerator.writeDefinition(&body, name, aGenerator.schema.Definitions[name])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var result bytes.Buffer
+
+	fmt.Fprintf(&result, "// Code generated by typedefgen from %s. DO NOT EDIT.\n\n", aGenerator.schemaFilename)
+	fmt.Fprintf(&result, "package %s\n\n", aGenerator.packageName)
+
+	if bytes.Contains(body.Bytes(), []byte("time.Time")) {
+		result.WriteString("import \"time\"\n\n")
+	}
+
+	result.Write(body.Bytes())
+
+	formatted, err := format.Source(result.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("typedefgen: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// The Go type used for a schema.
+func (aGenerator *generator) goType(schema *jtd.Schema) (string, error) {
+	if goType := metadataString(schema, "goType"); len(goType) > 0 {
+		return goType, nil
+	}
+
+	form, err := schema.Form()
+	if err != nil {
+		return "", fmt.Errorf("typedefgen: %w", err)
+	}
+
+	var result string
+
+	switch form {
+	case jtd.FormEmpty:
+		return "interface{}", nil
+	case jtd.FormRef:
+		definition := aGenerator.schema.Definitions[*schema.Ref]
+		definitionForm, _ := definition.Form()
+
+		if aGenerator.producer && definitionForm != jtd.FormProperties {
+			return aGenerator.goType(definition)
+		}
+
+		result = goName(*schema.Ref)
+	case jtd.FormType:
+		result = jtdTypeToGoType[*schema.Type]
+		if aGenerator.producer && *schema.Type == jtd.TypeTimestamp {
+			result = "string" // Producers format time in RFC3339Nano.
+		}
+	case jtd.FormEnum:
+		result = "string"
+	case jtd.FormElements:
+		elementType, err := aGenerator.goType(schema.Elements)
+		if err != nil {
+			return "", err
+		}
+
+		return "[]" + elementType, nil
+	case jtd.FormValues:
+		valueType, err := aGenerator.goType(schema.Values)
+		if err != nil {
+			return "", err
+		}
+
+		return "map[string]" + valueType, nil
+	default:
+		return "", fmt.Errorf("%w: inline %s", errUnsupportedForm, form)
+	}
+
+	if schema.Nullable {
+		result = "*" + result
+	}
+
+	return result, nil
+}
+
+// Determine if a schema is a reference to a definition generated as a struct.
+func (aGenerator *generator) isStruct(schema *jtd.Schema) bool {
+	if schema.Ref == nil {
+		return false
+	}
+
+	definitionForm, err := aGenerator.schema.Definitions[*schema.Ref].Form()
+
+	return err == nil && definitionForm == jtd.FormProperties
+}
+
+func (aGenerator *generator) writeDefinition(body *bytes.Buffer, name string, definition *jtd.Schema) error {
+	form, err := definition.Form()
+	if err != nil {
+		return fmt.Errorf("typedefgen: %w", err)
+	}
+
+	if form == jtd.FormProperties {
+		body.WriteString("\n")
+		body.WriteString(wrapComment(metadataString(definition, "description"), ""))
+
+		return aGenerator.writeStruct(body, goName(name), definition)
+	}
+
+	if aGenerator.producer {
+		return nil // Producers use the underlying types directly.
+	}
+
+	goType, err := aGenerator.goType(definition)
+	if err != nil {
+		return err
+	}
+
+	body.WriteString("\n")
+	body.WriteString(wrapComment(metadataString(definition, "description"), ""))
+	fmt.Fprintf(body, "type %s = %s\n", goName(name), goType)
+
+	return nil
+}
+
+func (aGenerator *generator) writeStruct(body *bytes.Buffer, name string, schema *jtd.Schema) error {
+	names := propertyNames(schema, aGenerator.producer)
+
+	if aGenerator.producer && schema == aGenerator.schema {
+		body.WriteString("// Fields in the formatted message.\n// Order is important.\n")
+		fmt.Fprintf(body, "// It should be %s.\n", strings.Join(names, ", "))
+	}
+
+	fmt.Fprintf(body, "type %s struct {\n", name)
+
+	for index, propertyName := range names {
+		property, isRequired := schema.Properties[propertyName]
+		if !isRequired {
+			property = schema.OptionalProperties[propertyName]
+		}
+
+		if property == nil {
+			return fmt.Errorf("%w: propertyOrder names unknown property %q", jtd.ErrInvalidSchema, propertyName)
+		}
+
+		goType, err := aGenerator.goType(property)
+		if err != nil {
+			return err
+		}
+
+		if !isRequired && aGenerator.isStruct(property) {
+			goType = "*" + goType // So that an absent optional object is omitted.
+		}
+
+		description := metadataString(property, "description")
+
+		if aGenerator.producer {
+			fmt.Fprintf(body, "\t%s %s `json:\"%s,omitempty\"` // %s\n", goName(propertyName), goType, propertyName, description)
+
+			continue
+		}
+
+		if index > 0 {
+			body.WriteString("\n")
+		}
+
+		tag := propertyName
+		if !isRequired {
+			tag += ",omitempty"
+		}
+
+		body.WriteString(wrapComment(description, "\t"))
+		fmt.Fprintf(body, "\t%s %s `json:\"%s\"`\n", goName(propertyName), goType, tag)
+	}
+
+	body.WriteString("}\n")
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const schemaFilename = "../../message-RFC8927.json"
+
+var testCasesForGenerateFile = []struct {
+	name             string
+	checkedInFile    string
+	packageName      string
+	producer         bool
+	rootName         string
+	expectedContains string
+}{
+	{
+		name:             "typedefgen-0001",
+		checkedInFile:    "../typedef/typedef.go",
+		packageName:      "typedef",
+		rootName:         "SenzingMessage",
+		expectedContains: "Time time.Time `json:\"time\"`",
+	},
+	{
+		name:             "typedefgen-0002",
+		checkedInFile:    "../../messenger/message_format.go",
+		packageName:      "messenger",
+		producer:         true,
+		rootName:         "MessageFormat",
+		expectedContains: "[]string `json:\"errors,omitempty\"`",
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test private functions
+// ----------------------------------------------------------------------------
+
+// If this test fails, run "make generate-go" and commit the result.
+func Test_generateFile_drift(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForGenerateFile {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			expected, err := os.ReadFile(testCase.checkedInFile)
+			require.NoError(test, err)
+
+			actual, err := generateFile(schemaFilename, testCase.packageName, testCase.rootName, testCase.producer)
+			require.NoError(test, err)
+			assert.Contains(test, string(actual), testCase.expectedContains)
+			assert.Equal(test, string(expected), string(actual), "%s is out of date with %s", testCase.checkedInFile, schemaFilename)
+		})
+	}
+}
+
+func Test_generateFile_badSchema(test *testing.T) {
+	test.Parallel()
+
+	_, err := generateFile("no-such-file.json", "typedef", "SenzingMessage", false)
+	require.Error(test, err)
+}
+
+func Test_goName(test *testing.T) {
+	test.Parallel()
+	assert.Equal(test, "ID", goName("id"))
+	assert.Equal(test, "ValueRaw", goName("valueRaw"))
+	assert.Equal(test, "HelpURL", goName("helpUrl"))
+	assert.Equal(test, "SnakeCase", goName("snake_case"))
+}
+
+func Test_wrapComment(test *testing.T) {
+	test.Parallel()
+
+	actual := wrapComment("Log level.  Possible values: TRACE, DEBUG, INFO, WARN, ERROR, FATAL, or PANIC.", "\t")
+	assert.Equal(test, "\t// Log level.  Possible values: TRACE, DEBUG, INFO, WARN, ERROR, FATAL, or\n\t// PANIC.\n", actual)
+}
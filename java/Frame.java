@@ -0,0 +1,91 @@
+// Code generated by jtd-codegen for Java + Jackson v0.2.1
+
+package com.senzing.schema;
+
+import com.fasterxml.jackson.annotation.JsonProperty;
+import com.fasterxml.jackson.databind.annotation.JsonSerialize;
+
+/**
+ * A location in the code.
+ */
+@JsonSerialize
+public class Frame {
+    @JsonProperty("file")
+    private String file;
+
+    @JsonProperty("function")
+    private String function;
+
+    @JsonProperty("line")
+    private Integer line;
+
+    @JsonProperty("package")
+    private String package;
+
+    public Frame() {
+    }
+
+    /**
+     * Getter for file.<p>
+     * Source file of the frame.
+     */
+    public String getFile() {
+        return file;
+    }
+
+    /**
+     * Setter for file.<p>
+     * Source file of the frame.
+     */
+    public void setFile(String file) {
+        this.file = file;
+    }
+
+    /**
+     * Getter for function.<p>
+     * Function, without the package path.
+     */
+    public String getFunction() {
+        return function;
+    }
+
+    /**
+     * Setter for function.<p>
+     * Function, without the package path.
+     */
+    public void setFunction(String function) {
+        this.function = function;
+    }
+
+    /**
+     * Getter for line.<p>
+     * Line number in the source file.
+     */
+    public Integer getLine() {
+        return line;
+    }
+
+    /**
+     * Setter for line.<p>
+     * Line number in the source file.
+     */
+    public void setLine(Integer line) {
+        this.line = line;
+    }
+
+    /**
+     * Getter for package.<p>
+     * Package, as an import path.
+     */
+    public String getPackage() {
+        return package;
+    }
+
+    /**
+     * Setter for package.<p>
+     * Package, as an import path.
+     */
+    public void setPackage(String package) {
+        this.package = package;
+    }
+}
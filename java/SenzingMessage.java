@@ -2,6 +2,7 @@
 
 package com.senzing.schema;
 
+import com.fasterxml.jackson.annotation.JsonInclude;
 import com.fasterxml.jackson.annotation.JsonProperty;
 import com.fasterxml.jackson.databind.annotation.JsonSerialize;
 import java.time.OffsetDateTime;
@@ -41,6 +42,22 @@ public class SenzingMessage {
     @JsonProperty("time")
     private OffsetDateTime time;
 
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    @JsonProperty("caller")
+    private Frame caller;
+
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    @JsonProperty("help")
+    private String help;
+
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    @JsonProperty("remediation")
+    private String remediation;
+
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    @JsonProperty("stack")
+    private Stack stack;
+
     public SenzingMessage() {
     }
 
@@ -221,4 +238,68 @@ public class SenzingMessage {
     public void setTime(OffsetDateTime time) {
         this.time = time;
     }
+
+    /**
+     * Getter for caller.<p>
+     * Location in the code, as package, function, file, and line.
+     */
+    public Frame getCaller() {
+        return caller;
+    }
+
+    /**
+     * Setter for caller.<p>
+     * Location in the code, as package, function, file, and line.
+     */
+    public void setCaller(Frame caller) {
+        this.caller = caller;
+    }
+
+    /**
+     * Getter for help.<p>
+     * URL of documentation for the message.
+     */
+    public String getHelp() {
+        return help;
+    }
+
+    /**
+     * Setter for help.<p>
+     * URL of documentation for the message.
+     */
+    public void setHelp(String help) {
+        this.help = help;
+    }
+
+    /**
+     * Getter for remediation.<p>
+     * What to do about the message.
+     */
+    public String getRemediation() {
+        return remediation;
+    }
+
+    /**
+     * Setter for remediation.<p>
+     * What to do about the message.
+     */
+    public void setRemediation(String remediation) {
+        this.remediation = remediation;
+    }
+
+    /**
+     * Getter for stack.<p>
+     * Stack trace where the message or its error was created.
+     */
+    public Stack getStack() {
+        return stack;
+    }
+
+    /**
+     * Setter for stack.<p>
+     * Stack trace where the message or its error was created.
+     */
+    public void setStack(Stack stack) {
+        this.stack = stack;
+    }
 }
@@ -0,0 +1,31 @@
+// Code generated by jtd-codegen for Java + Jackson v0.2.1
+
+package com.senzing.schema;
+
+import com.fasterxml.jackson.annotation.JsonCreator;
+import com.fasterxml.jackson.annotation.JsonValue;
+import java.util.List;
+
+/**
+ * A list of frames.  The innermost frame is first.
+ */
+public class Stack {
+    @JsonValue
+    private List<Frame> value;
+
+    public Stack() {
+    }
+
+    @JsonCreator
+    public Stack(List<Frame> value) {
+        this.value = value;
+    }
+
+    public List<Frame> getValue() {
+        return value;
+    }
+
+    public void setValue(List<Frame> value) {
+        this.value = value;
+    }
+}
@@ -0,0 +1,9 @@
+/*
+Package jtd validates JSON documents against a JSON Type Definition (JTD) schema as described in RFC 8927.
+
+A schema, such as message-RFC8927.json, is loaded with Parse and checked for correctness.
+Instances are then validated with Validate or ValidateJSON, which report
+RFC 8927 error indicators: pairs of JSON Pointers identifying the offending
+part of the instance ("instancePath") and the schema rule that rejected it ("schemaPath").
+*/
+package jtd
@@ -0,0 +1,31 @@
+package jtd_test
+
+import (
+	"fmt"
+
+	"github.com/senzing-garage/go-messaging/jtd"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleSchema_ValidateJSON() {
+	// For more information, visit https://github.com/senzing-garage/go-messaging/blob/main/jtd/jtd_examples_test.go
+	schema, err := jtd.ParseFile("../message-RFC8927.json")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	validationErrors, err := schema.ValidateJSON([]byte(`{"id": 2001}`), jtd.OptionMaxErrors{Value: 2})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	for _, validationError := range validationErrors {
+		fmt.Printf("%q %q\n", validationError.InstancePath, validationError.SchemaPath)
+	}
+	//Output:
+	// "" "/properties/code"
+	// "" "/properties/details"
+}
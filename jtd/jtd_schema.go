@@ -0,0 +1,229 @@
+package jtd
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var validTypes = []string{
+	TypeBoolean,
+	TypeFloat32,
+	TypeFloat64,
+	TypeInt8,
+	TypeInt16,
+	TypeInt32,
+	TypeString,
+	TypeTimestamp,
+	TypeUint8,
+	TypeUint16,
+	TypeUint32,
+}
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The Form method reports which of the eight RFC 8927 forms the schema takes.
+For a schema that mixes keywords of several forms, an error is returned.
+
+Output
+  - One of the Form* constants.
+*/
+func (schema *Schema) Form() (string, error) {
+	forms := []string{}
+
+	if schema.Ref != nil {
+		forms = append(forms, FormRef)
+	}
+
+	if schema.Type != nil {
+		forms = append(forms, FormType)
+	}
+
+	if schema.Enum != nil {
+		forms = append(forms, FormEnum)
+	}
+
+	if schema.Elements != nil {
+		forms = append(forms, FormElements)
+	}
+
+	if schema.Properties != nil || schema.OptionalProperties != nil {
+		forms = append(forms, FormProperties)
+	} else if schema.AdditionalProperties {
+		return "", fmt.Errorf("%w: additionalProperties without properties or optionalProperties", ErrInvalidSchema)
+	}
+
+	if schema.Values != nil {
+		forms = append(forms, FormValues)
+	}
+
+	if schema.Discriminator != nil {
+		forms = append(forms, FormDiscriminator)
+	} else if schema.Mapping != nil {
+		return "", fmt.Errorf("%w: mapping without discriminator", ErrInvalidSchema)
+	}
+
+	switch len(forms) {
+	case 0:
+		return FormEmpty, nil
+	case 1:
+		return forms[0], nil
+	default:
+		return "", fmt.Errorf("%w: keywords of multiple forms %v", ErrInvalidSchema, forms)
+	}
+}
+
+/*
+The Verify method checks that the schema is a correct RFC 8927 root schema.
+Parse calls Verify, so it is only needed for schemas constructed in code.
+*/
+func (schema *Schema) Verify() error {
+	for name, definition := range schema.Definitions {
+		err := definition.verify(schema, "/definitions/"+escapeToken(name))
+		if err != nil {
+			return err
+		}
+	}
+
+	err := schema.verifyRefCycles()
+	if err != nil {
+		return err
+	}
+
+	return schema.verify(schema, "")
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (schema *Schema) verify(root *Schema, schemaPath string) error {
+	if schema == nil {
+		return fmt.Errorf("%w: %s: schema is null", ErrInvalidSchema, schemaPath)
+	}
+
+	if schema != root && schema.Definitions != nil {
+		return fmt.Errorf("%w: %s: definitions is only allowed in the root schema", ErrInvalidSchema, schemaPath)
+	}
+
+	form, err := schema.Form()
+	if err != nil {
+		return fmt.Errorf("%w (at %q)", err, schemaPath)
+	}
+
+	switch form {
+	case FormRef:
+		if _, ok := root.Definitions[*schema.Ref]; !ok {
+			return fmt.Errorf("%w: %s: ref to undefined definition %q", ErrInvalidSchema, schemaPath, *schema.Ref)
+		}
+	case FormType:
+		if !slices.Contains(validTypes, *schema.Type) {
+			return fmt.Errorf("%w: %s: unknown type %q", ErrInvalidSchema, schemaPath, *schema.Type)
+		}
+	case FormEnum:
+		return schema.verifyEnum(schemaPath)
+	case FormElements:
+		return schema.Elements.verify(root, schemaPath+"/elements")
+	case FormProperties:
+		return schema.verifyProperties(root, schemaPath)
+	case FormValues:
+		return schema.Values.verify(root, schemaPath+"/values")
+	case FormDiscriminator:
+		return schema.verifyDiscriminator(root, schemaPath)
+	}
+
+	return nil
+}
+
+func (schema *Schema) verifyDiscriminator(root *Schema, schemaPath string) error {
+	for tag, mapping := range schema.Mapping {
+		mappingPath := schemaPath + "/mapping/" + escapeToken(tag)
+
+		err := mapping.verify(root, mappingPath)
+		if err != nil {
+			return err
+		}
+
+		form, _ := mapping.Form()
+		if form != FormProperties {
+			return fmt.Errorf("%w: %s: mapping must be of the properties form", ErrInvalidSchema, mappingPath)
+		}
+
+		if mapping.Nullable {
+			return fmt.Errorf("%w: %s: mapping must not be nullable", ErrInvalidSchema, mappingPath)
+		}
+
+		_, inProperties := mapping.Properties[*schema.Discriminator]
+		_, inOptionalProperties := mapping.OptionalProperties[*schema.Discriminator]
+
+		if inProperties || inOptionalProperties {
+			return fmt.Errorf("%w: %s: mapping redefines discriminator %q", ErrInvalidSchema, mappingPath, *schema.Discriminator)
+		}
+	}
+
+	return nil
+}
+
+// Reject definitions which lead back to themselves through refs alone.
+// Validating against them would follow refs forever without consuming any of the instance.
+func (schema *Schema) verifyRefCycles() error {
+	for _, name := range sortedKeys(schema.Definitions) {
+		seen := map[string]bool{}
+
+		for current := name; schema.Definitions[current] != nil && schema.Definitions[current].Ref != nil; {
+			seen[current] = true
+
+			current = *schema.Definitions[current].Ref
+			if seen[current] {
+				return fmt.Errorf("%w: /definitions/%s: ref cycle through %q", ErrInvalidSchema, escapeToken(name), current)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (schema *Schema) verifyEnum(schemaPath string) error {
+	if len(schema.Enum) == 0 {
+		return fmt.Errorf("%w: %s: enum must not be empty", ErrInvalidSchema, schemaPath)
+	}
+
+	seen := map[string]bool{}
+	for _, value := range schema.Enum {
+		if seen[value] {
+			return fmt.Errorf("%w: %s: enum repeats %q", ErrInvalidSchema, schemaPath, value)
+		}
+
+		seen[value] = true
+	}
+
+	return nil
+}
+
+func (schema *Schema) verifyProperties(root *Schema, schemaPath string) error {
+	for name, property := range schema.Properties {
+		if _, ok := schema.OptionalProperties[name]; ok {
+			return fmt.Errorf("%w: %s: %q is both required and optional", ErrInvalidSchema, schemaPath, name)
+		}
+
+		err := property.verify(root, schemaPath+"/properties/"+escapeToken(name))
+		if err != nil {
+			return err
+		}
+	}
+
+	for name, property := range schema.OptionalProperties {
+		err := property.verify(root, schemaPath+"/optionalProperties/"+escapeToken(name))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,380 @@
+package jtd_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/go-messaging/jtd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const messageSchemaFilename = "../message-RFC8927.json"
+
+var testCasesForParse = []struct {
+	name          string
+	schema        string
+	expectedForm  string
+	expectedError bool
+}{
+	{name: "jtd-parse-0001", schema: `{}`, expectedForm: jtd.FormEmpty},
+	{name: "jtd-parse-0002", schema: `{"type": "uint8", "nullable": true}`, expectedForm: jtd.FormType},
+	{name: "jtd-parse-0003", schema: `{"enum": ["A", "B"]}`, expectedForm: jtd.FormEnum},
+	{name: "jtd-parse-0004", schema: `{"elements": {}}`, expectedForm: jtd.FormElements},
+	{name: "jtd-parse-0005", schema: `{"optionalProperties": {}}`, expectedForm: jtd.FormProperties},
+	{name: "jtd-parse-0006", schema: `{"values": {"type": "string"}}`, expectedForm: jtd.FormValues},
+	{name: "jtd-parse-0007", schema: `{"definitions": {"a": {}}, "ref": "a"}`, expectedForm: jtd.FormRef},
+	{name: "jtd-parse-0008", schema: `{"discriminator": "k", "mapping": {"x": {"properties": {}}}}`, expectedForm: jtd.FormDiscriminator},
+	{name: "jtd-parse-0101", schema: `{"type": "int64"}`, expectedError: true},
+	{name: "jtd-parse-0102", schema: `{"enum": []}`, expectedError: true},
+	{name: "jtd-parse-0103", schema: `{"enum": ["A", "A"]}`, expectedError: true},
+	{name: "jtd-parse-0104", schema: `{"ref": "missing"}`, expectedError: true},
+	{name: "jtd-parse-0105", schema: `{"type": "string", "elements": {}}`, expectedError: true},
+	{name: "jtd-parse-0106", schema: `{"elements": {"definitions": {}}}`, expectedError: true},
+	{name: "jtd-parse-0107", schema: `{"properties": {"a": {}}, "optionalProperties": {"a": {}}}`, expectedError: true},
+	{name: "jtd-parse-0108", schema: `{"additionalProperties": true}`, expectedError: true},
+	{name: "jtd-parse-0109", schema: `{"discriminator": "k", "mapping": {"x": {"type": "string"}}}`, expectedError: true},
+	{name: "jtd-parse-0110", schema: `{"discriminator": "k", "mapping": {"x": {"properties": {"k": {}}}}}`, expectedError: true},
+	{name: "jtd-parse-0111", schema: `{"discriminator": "k", "mapping": {"x": {"properties": {}, "nullable": true}}}`, expectedError: true},
+	{name: "jtd-parse-0112", schema: `{"mapping": {}}`, expectedError: true},
+	{name: "jtd-parse-0113", schema: `{"unknownKeyword": 1}`, expectedError: true},
+	{name: "jtd-parse-0114", schema: `Not JSON`, expectedError: true},
+	{name: "jtd-parse-0115", schema: `{"definitions": {"a": {"ref": "a"}}}`, expectedError: true},
+	{name: "jtd-parse-0116", schema: `{"definitions": {"a": {"ref": "b"}, "b": {"ref": "c"}, "c": {"ref": "a"}}, "type": "string"}`, expectedError: true},
+	{name: "jtd-parse-0117", schema: `{"definitions": {"a": {"elements": {"ref": "a"}}}, "ref": "a"}`, expectedForm: jtd.FormRef},
+	{name: "jtd-parse-0118", schema: `{} {}`, expectedError: true},
+}
+
+var testCasesForValidate = []struct {
+	name           string
+	schema         string
+	instance       string
+	expectedErrors []jtd.ValidationError
+}{
+	{
+		name:     "jtd-validate-0001",
+		schema:   `{}`,
+		instance: `[1, "two", null]`,
+	},
+	{
+		name:           "jtd-validate-0002",
+		schema:         `{"type": "string"}`,
+		instance:       `null`,
+		expectedErrors: []jtd.ValidationError{{InstancePath: "", SchemaPath: "/type"}},
+	},
+	{
+		name:     "jtd-validate-0003",
+		schema:   `{"type": "string", "nullable": true}`,
+		instance: `null`,
+	},
+	{
+		name:           "jtd-validate-0004",
+		schema:         `{"type": "uint8"}`,
+		instance:       `256`,
+		expectedErrors: []jtd.ValidationError{{InstancePath: "", SchemaPath: "/type"}},
+	},
+	{
+		name:     "jtd-validate-0005",
+		schema:   `{"type": "int8"}`,
+		instance: `-128.0`,
+	},
+	{
+		name:           "jtd-validate-0006",
+		schema:         `{"type": "int32"}`,
+		instance:       `1.5`,
+		expectedErrors: []jtd.ValidationError{{InstancePath: "", SchemaPath: "/type"}},
+	},
+	{
+		name:     "jtd-validate-0007",
+		schema:   `{"type": "timestamp"}`,
+		instance: `"1990-12-31t23:59:60z"`,
+	},
+	{
+		name:           "jtd-validate-0008",
+		schema:         `{"type": "timestamp"}`,
+		instance:       `"1990-12-31"`,
+		expectedErrors: []jtd.ValidationError{{InstancePath: "", SchemaPath: "/type"}},
+	},
+	{
+		name:           "jtd-validate-0009",
+		schema:         `{"enum": ["PENDING", "DONE"]}`,
+		instance:       `"UNKNOWN"`,
+		expectedErrors: []jtd.ValidationError{{InstancePath: "", SchemaPath: "/enum"}},
+	},
+	{
+		name:     "jtd-validate-0010",
+		schema:   `{"elements": {"type": "boolean"}}`,
+		instance: `[true, 1, false, "x"]`,
+		expectedErrors: []jtd.ValidationError{
+			{InstancePath: "/1", SchemaPath: "/elements/type"},
+			{InstancePath: "/3", SchemaPath: "/elements/type"},
+		},
+	},
+	{
+		name:           "jtd-validate-0011",
+		schema:         `{"elements": {}}`,
+		instance:       `{}`,
+		expectedErrors: []jtd.ValidationError{{InstancePath: "", SchemaPath: "/elements"}},
+	},
+	{
+		name:     "jtd-validate-0012",
+		schema:   `{"properties": {"a": {"type": "string"}, "b": {}}, "optionalProperties": {"c": {"type": "int32"}}}`,
+		instance: `{"a": 1, "c": "x", "d": true, "a/b~": 0}`,
+		expectedErrors: []jtd.ValidationError{
+			{InstancePath: "/a", SchemaPath: "/properties/a/type"},
+			{InstancePath: "", SchemaPath: "/properties/b"},
+			{InstancePath: "/c", SchemaPath: "/optionalProperties/c/type"},
+			{InstancePath: "/a~1b~0", SchemaPath: ""},
+			{InstancePath: "/d", SchemaPath: ""},
+		},
+	},
+	{
+		name:     "jtd-validate-0013",
+		schema:   `{"optionalProperties": {}, "additionalProperties": true}`,
+		instance: `{"anything": "goes"}`,
+	},
+	{
+		name:           "jtd-validate-0014",
+		schema:         `{"optionalProperties": {}}`,
+		instance:       `[]`,
+		expectedErrors: []jtd.ValidationError{{InstancePath: "", SchemaPath: "/optionalProperties"}},
+	},
+	{
+		name:     "jtd-validate-0015",
+		schema:   `{"values": {"type": "float32"}}`,
+		instance: `{"a": 1.5, "b": "x", "c": null}`,
+		expectedErrors: []jtd.ValidationError{
+			{InstancePath: "/b", SchemaPath: "/values/type"},
+			{InstancePath: "/c", SchemaPath: "/values/type"},
+		},
+	},
+	{
+		name:     "jtd-validate-0016",
+		schema:   `{"definitions": {"coordinate": {"type": "int16"}}, "elements": {"ref": "coordinate"}}`,
+		instance: `[1, 2, 70000]`,
+		expectedErrors: []jtd.ValidationError{
+			{InstancePath: "/2", SchemaPath: "/definitions/coordinate/type"},
+		},
+	},
+	{
+		name:     "jtd-validate-0017",
+		schema:   `{"discriminator": "kind", "mapping": {"dog": {"properties": {"bark": {"type": "boolean"}}}}}`,
+		instance: `{"kind": "dog", "bark": true}`,
+	},
+	{
+		name:           "jtd-validate-0018",
+		schema:         `{"discriminator": "kind", "mapping": {"dog": {"properties": {"bark": {"type": "boolean"}}}}}`,
+		instance:       `{"kind": "cat"}`,
+		expectedErrors: []jtd.ValidationError{{InstancePath: "/kind", SchemaPath: "/mapping"}},
+	},
+	{
+		name:           "jtd-validate-0019",
+		schema:         `{"discriminator": "kind", "mapping": {"dog": {"properties": {"bark": {"type": "boolean"}}}}}`,
+		instance:       `{"kind": 7}`,
+		expectedErrors: []jtd.ValidationError{{InstancePath: "/kind", SchemaPath: "/discriminator"}},
+	},
+	{
+		name:           "jtd-validate-0020",
+		schema:         `{"discriminator": "kind", "mapping": {"dog": {"properties": {"bark": {"type": "boolean"}}}}}`,
+		instance:       `{}`,
+		expectedErrors: []jtd.ValidationError{{InstancePath: "", SchemaPath: "/discriminator"}},
+	},
+	{
+		name:           "jtd-validate-0021",
+		schema:         `{"discriminator": "kind", "mapping": {"dog": {"properties": {"bark": {"type": "boolean"}}}}}`,
+		instance:       `{"kind": "dog", "bark": "loud"}`,
+		expectedErrors: []jtd.ValidationError{{InstancePath: "/bark", SchemaPath: "/mapping/dog/properties/bark/type"}},
+	},
+}
+
+var testCasesForMessageSchema = []struct {
+	name           string
+	message        string
+	expectedErrors []jtd.ValidationError
+}{
+	{
+		name:    "jtd-message-0001",
+		message: `{"time":"2000-01-01T00:00:00Z","level":"TRACE","id":"SZSDK99990001","text":"Bob works with Jane","code":"","reason":"","status":"OK","duration":1234,"location":"","errors":["error1","error2"],"details":[{"key":"","position":1,"type":"string","value":"Bob","valueRaw":null}]}`,
+	},
+	{
+		name:    "jtd-message-0002",
+		message: `{"level":"TRACE","id":"SZSDK99990001"}`,
+		expectedErrors: []jtd.ValidationError{
+			{InstancePath: "", SchemaPath: "/properties/code"},
+			{InstancePath: "", SchemaPath: "/properties/details"},
+			{InstancePath: "", SchemaPath: "/properties/duration"},
+			{InstancePath: "", SchemaPath: "/properties/errors"},
+			{InstancePath: "", SchemaPath: "/properties/location"},
+			{InstancePath: "", SchemaPath: "/properties/reason"},
+			{InstancePath: "", SchemaPath: "/properties/status"},
+			{InstancePath: "", SchemaPath: "/properties/text"},
+			{InstancePath: "", SchemaPath: "/properties/time"},
+		},
+	},
+	{
+		name:    "jtd-message-0003",
+		message: `{"time":"yesterday","level":"TRACE","id":"SZSDK99990001","text":"","code":"","reason":"","status":"","duration":0,"location":"","errors":[7],"details":[{"key":"","position":"1","type":"","value":""}],"extra":true}`,
+		expectedErrors: []jtd.ValidationError{
+			{InstancePath: "/details/0/position", SchemaPath: "/definitions/detail/properties/position/type"},
+			{InstancePath: "/details/0", SchemaPath: "/definitions/detail/properties/valueRaw"},
+			{InstancePath: "/errors/0", SchemaPath: "/definitions/error/type"},
+			{InstancePath: "/time", SchemaPath: "/properties/time/type"},
+			{InstancePath: "/extra", SchemaPath: ""},
+		},
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestParse(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForParse {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			schema, err := jtd.Parse([]byte(testCase.schema))
+			if testCase.expectedError {
+				require.ErrorIs(test, err, jtd.ErrInvalidSchema)
+
+				return
+			}
+
+			require.NoError(test, err)
+
+			form, err := schema.Form()
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expectedForm, form)
+		})
+	}
+}
+
+func TestParseFile(test *testing.T) {
+	test.Parallel()
+
+	schema, err := jtd.ParseFile(messageSchemaFilename)
+	require.NoError(test, err)
+	assert.Contains(test, schema.Definitions, "detail")
+
+	_, err = jtd.ParseFile("no-such-file.json")
+	require.Error(test, err)
+}
+
+func TestValidate(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForValidate {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			schema, err := jtd.Parse([]byte(testCase.schema))
+			require.NoError(test, err)
+
+			actual, err := schema.ValidateJSON([]byte(testCase.instance))
+			require.NoError(test, err)
+
+			if testCase.expectedErrors == nil {
+				assert.Empty(test, actual)
+			} else {
+				assert.Equal(test, testCase.expectedErrors, actual)
+			}
+		})
+	}
+}
+
+func TestValidate_messageSchema(test *testing.T) {
+	test.Parallel()
+
+	schema, err := jtd.ParseFile(messageSchemaFilename)
+	require.NoError(test, err)
+
+	for _, testCase := range testCasesForMessageSchema {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actual, err := schema.ValidateJSON([]byte(testCase.message))
+			require.NoError(test, err)
+
+			if testCase.expectedErrors == nil {
+				assert.Empty(test, actual)
+			} else {
+				assert.Equal(test, testCase.expectedErrors, actual)
+			}
+		})
+	}
+}
+
+func TestValidate_goValue(test *testing.T) {
+	test.Parallel()
+
+	schema, err := jtd.Parse([]byte(`{"properties": {"count": {"type": "uint16"}}}`))
+	require.NoError(test, err)
+
+	actual, err := schema.Validate(struct {
+		Count int `json:"count"`
+	}{Count: 42})
+	require.NoError(test, err)
+	assert.Empty(test, actual)
+
+	_, err = schema.Validate(make(chan int))
+	require.Error(test, err)
+
+	// Elements of generic maps and slices are converted too.
+
+	schema, err = jtd.Parse([]byte(`{"properties": {"a": {"type": "int32"}, "b": {"elements": {"type": "uint8"}}}}`))
+	require.NoError(test, err)
+
+	actual, err = schema.Validate(map[string]interface{}{"a": 5, "b": []interface{}{uint(1), int64(2)}})
+	require.NoError(test, err)
+	assert.Empty(test, actual)
+}
+
+func TestValidate_maxDepth(test *testing.T) {
+	test.Parallel()
+
+	schema, err := jtd.Parse([]byte(`{"definitions": {"nested": {"elements": {"ref": "nested"}}}, "ref": "nested"}`))
+	require.NoError(test, err)
+
+	_, err = schema.ValidateJSON([]byte(strings.Repeat("[", 64)+strings.Repeat("]", 64)), jtd.OptionMaxDepth{Value: 32})
+	require.ErrorIs(test, err, jtd.ErrMaxDepthExceeded)
+}
+
+func TestValidate_maxErrors(test *testing.T) {
+	test.Parallel()
+
+	schema, err := jtd.Parse([]byte(`{"elements": {"type": "string"}}`))
+	require.NoError(test, err)
+
+	actual, err := schema.ValidateJSON([]byte(`[1, 2, 3, 4]`), jtd.OptionMaxErrors{Value: 2})
+	require.NoError(test, err)
+	assert.Len(test, actual, 2)
+}
+
+func TestValidateJSON_badJSON(test *testing.T) {
+	test.Parallel()
+
+	schema, err := jtd.Parse([]byte(`{}`))
+	require.NoError(test, err)
+
+	_, err = schema.ValidateJSON([]byte(`{Not really JSON}`))
+	require.Error(test, err)
+
+	_, err = schema.ValidateJSON([]byte(`{"a": 5} garbage`))
+	require.Error(test, err)
+
+	_, err = schema.ValidateJSON([]byte(`{"a": 5} {}`))
+	require.Error(test, err)
+
+	actual, err := schema.ValidateJSON([]byte(" {\"a\": 5}\n"))
+	require.NoError(test, err)
+	assert.Empty(test, actual)
+}
+
+func TestValidationError_Error(test *testing.T) {
+	test.Parallel()
+
+	validationError := jtd.ValidationError{InstancePath: "/a", SchemaPath: "/properties/a/type"}
+	assert.Equal(test, `instancePath "/a" rejected by schemaPath "/properties/a/type"`, validationError.Error())
+}
@@ -0,0 +1,546 @@
+package jtd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The state of a single call to Validate.
+type validation struct {
+	errors         []ValidationError
+	instanceTokens []string
+	maxDepth       int
+	maxErrors      int
+	root           *Schema
+	schemaTokens   [][]string // A stack; one entry per followed "ref".
+}
+
+// Integer ranges for the integer values of "type".
+type integerRange struct {
+	minimum float64
+	maximum float64
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var integerRanges = map[string]integerRange{
+	TypeInt8:   {math.MinInt8, math.MaxInt8},
+	TypeUint8:  {0, math.MaxUint8},
+	TypeInt16:  {math.MinInt16, math.MaxInt16},
+	TypeUint16: {0, math.MaxUint16},
+	TypeInt32:  {math.MinInt32, math.MaxInt32},
+	TypeUint32: {0, math.MaxUint32},
+}
+
+// Stops validation once OptionMaxErrors has been reached.
+var errMaxErrorsReached = errors.New("maximum errors reached")
+
+// JSON text holding more than one value.
+var errTrailingData = errors.New("invalid data after top-level value")
+
+// ----------------------------------------------------------------------------
+// Public methods
+// ----------------------------------------------------------------------------
+
+/*
+The Validate method validates an instance against the root schema.
+
+Input
+  - instance: A JSON value as produced by encoding/json (nil, bool, float64, json.Number,
+    string, []interface{}, map[string]interface{}).  Other Go values are first
+    converted by marshaling them to JSON.
+  - options: Zero or more of OptionMaxDepth and OptionMaxErrors.
+
+Output
+  - The error indicators, in a deterministic order.  Empty when the instance is valid.
+  - An error if the instance cannot be represented as JSON or OptionMaxDepth is exceeded.
+*/
+func (schema *Schema) Validate(instance interface{}, options ...interface{}) ([]ValidationError, error) {
+	aValidation := &validation{
+		errors:         []ValidationError{},
+		instanceTokens: []string{},
+		root:           schema,
+		schemaTokens:   [][]string{{}},
+	}
+
+	for _, value := range options {
+		switch typedValue := value.(type) {
+		case OptionMaxDepth:
+			aValidation.maxDepth = typedValue.Value
+		case OptionMaxErrors:
+			aValidation.maxErrors = typedValue.Value
+		}
+	}
+
+	instance, err := asJSONValue(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	err = aValidation.validate(schema, instance, nil)
+	if err != nil && !errors.Is(err, errMaxErrorsReached) {
+		return nil, err
+	}
+
+	return aValidation.errors, nil
+}
+
+/*
+The ValidateJSON method decodes JSON text and validates it against the root schema.
+
+Input
+  - instanceJSON: The JSON text to validate.
+  - options: Zero or more of OptionMaxDepth and OptionMaxErrors.
+
+Output
+  - The error indicators.  Empty when the instance is valid.
+  - An error if instanceJSON is not JSON or OptionMaxDepth is exceeded.
+*/
+func (schema *Schema) ValidateJSON(instanceJSON []byte, options ...interface{}) ([]ValidationError, error) {
+	var instance interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(instanceJSON))
+	decoder.UseNumber()
+
+	err := decodeOnly(decoder, &instance)
+	if err != nil {
+		return nil, fmt.Errorf("jtd.ValidateJSON error: %w", err)
+	}
+
+	return schema.Validate(instance, options...)
+}
+
+// Error implements the error interface so a ValidationError can be wrapped or returned directly.
+func (validationError ValidationError) Error() string {
+	return fmt.Sprintf("instancePath %q rejected by schemaPath %q", validationError.InstancePath, validationError.SchemaPath)
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (aValidation *validation) popInstance() {
+	aValidation.instanceTokens = aValidation.instanceTokens[:len(aValidation.instanceTokens)-1]
+}
+
+func (aValidation *validation) popSchema() {
+	last := len(aValidation.schemaTokens) - 1
+	aValidation.schemaTokens[last] = aValidation.schemaTokens[last][:len(aValidation.schemaTokens[last])-1]
+}
+
+func (aValidation *validation) pushInstance(token string) {
+	aValidation.instanceTokens = append(aValidation.instanceTokens, token)
+}
+
+func (aValidation *validation) pushSchema(token string) {
+	last := len(aValidation.schemaTokens) - 1
+	aValidation.schemaTokens[last] = append(aValidation.schemaTokens[last], token)
+}
+
+func (aValidation *validation) pushError() error {
+	aValidation.errors = append(aValidation.errors, ValidationError{
+		InstancePath: asJSONPointer(aValidation.instanceTokens),
+		SchemaPath:   asJSONPointer(aValidation.schemaTokens[len(aValidation.schemaTokens)-1]),
+	})
+
+	if aValidation.maxErrors > 0 && len(aValidation.errors) >= aValidation.maxErrors {
+		return errMaxErrorsReached
+	}
+
+	return nil
+}
+
+// Push a schema keyword, record an error, and pop the keyword.
+func (aValidation *validation) pushErrorAt(token string) error {
+	aValidation.pushSchema(token)
+	err := aValidation.pushError()
+	aValidation.popSchema()
+
+	return err
+}
+
+// Push an instance token and a schema keyword, record an error, and pop both.
+func (aValidation *validation) pushErrorAtInstance(instanceToken string, token string) error {
+	aValidation.pushInstance(instanceToken)
+	err := aValidation.pushErrorAt(token)
+	aValidation.popInstance()
+
+	return err
+}
+
+// Implements RFC 8927 section 3.3.  The parentTag, if not nil, is the
+// discriminator property that a "mapping" schema must tolerate.
+func (aValidation *validation) validate(schema *Schema, instance interface{}, parentTag *string) error {
+	if schema.Nullable && instance == nil {
+		return nil
+	}
+
+	form, err := schema.Form()
+	if err != nil {
+		return err
+	}
+
+	switch form {
+	case FormRef:
+		return aValidation.validateRef(schema, instance)
+	case FormType:
+		return aValidation.validateType(schema, instance)
+	case FormEnum:
+		value, isString := instance.(string)
+		if !isString || !slices.Contains(schema.Enum, value) {
+			return aValidation.pushErrorAt("enum")
+		}
+	case FormElements:
+		return aValidation.validateElements(schema, instance)
+	case FormProperties:
+		return aValidation.validateProperties(schema, instance, parentTag)
+	case FormValues:
+		return aValidation.validateValues(schema, instance)
+	case FormDiscriminator:
+		return aValidation.validateDiscriminator(schema, instance)
+	}
+
+	return nil
+}
+
+func (aValidation *validation) validateDiscriminator(schema *Schema, instance interface{}) error {
+	object, isObject := instance.(map[string]interface{})
+	if !isObject {
+		return aValidation.pushErrorAt("discriminator")
+	}
+
+	tagValue, hasTag := object[*schema.Discriminator]
+	if !hasTag {
+		return aValidation.pushErrorAt("discriminator")
+	}
+
+	tag, isString := tagValue.(string)
+	if !isString {
+		return aValidation.pushErrorAtInstance(*schema.Discriminator, "discriminator")
+	}
+
+	mapping, hasMapping := schema.Mapping[tag]
+	if !hasMapping {
+		return aValidation.pushErrorAtInstance(*schema.Discriminator, "mapping")
+	}
+
+	aValidation.pushSchema("mapping")
+	aValidation.pushSchema(tag)
+	err := aValidation.validate(mapping, instance, schema.Discriminator)
+	aValidation.popSchema()
+	aValidation.popSchema()
+
+	return err
+}
+
+func (aValidation *validation) validateElements(schema *Schema, instance interface{}) error {
+	array, isArray := instance.([]interface{})
+	if !isArray {
+		return aValidation.pushErrorAt("elements")
+	}
+
+	aValidation.pushSchema("elements")
+	defer aValidation.popSchema()
+
+	for index, element := range array {
+		aValidation.pushInstance(strconv.Itoa(index))
+		err := aValidation.validate(schema.Elements, element, nil)
+		aValidation.popInstance()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (aValidation *validation) validateProperties(schema *Schema, instance interface{}, parentTag *string) error {
+	object, isObject := instance.(map[string]interface{})
+	if !isObject {
+		if schema.Properties != nil {
+			return aValidation.pushErrorAt("properties")
+		}
+
+		return aValidation.pushErrorAt("optionalProperties")
+	}
+
+	err := aValidation.validatePropertyList(schema.Properties, "properties", object, true)
+	if err != nil {
+		return err
+	}
+
+	err = aValidation.validatePropertyList(schema.OptionalProperties, "optionalProperties", object, false)
+	if err != nil {
+		return err
+	}
+
+	if schema.AdditionalProperties {
+		return nil
+	}
+
+	for _, key := range sortedKeys(object) {
+		_, isRequired := schema.Properties[key]
+		_, isOptional := schema.OptionalProperties[key]
+		isParentTag := parentTag != nil && *parentTag == key
+
+		if !isRequired && !isOptional && !isParentTag {
+			aValidation.pushInstance(key)
+			err = aValidation.pushError()
+			aValidation.popInstance()
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (aValidation *validation) validatePropertyList(
+	properties map[string]*Schema,
+	keyword string,
+	object map[string]interface{},
+	isRequired bool,
+) error {
+	var err error
+
+	aValidation.pushSchema(keyword)
+	defer aValidation.popSchema()
+
+	for _, key := range sortedKeys(properties) {
+		aValidation.pushSchema(key)
+
+		value, isPresent := object[key]
+
+		switch {
+		case isPresent:
+			aValidation.pushInstance(key)
+			err = aValidation.validate(properties[key], value, nil)
+			aValidation.popInstance()
+		case isRequired:
+			err = aValidation.pushError()
+		}
+
+		aValidation.popSchema()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (aValidation *validation) validateRef(schema *Schema, instance interface{}) error {
+	if aValidation.maxDepth > 0 && len(aValidation.schemaTokens) > aValidation.maxDepth {
+		return ErrMaxDepthExceeded
+	}
+
+	definition, ok := aValidation.root.Definitions[*schema.Ref]
+	if !ok {
+		return fmt.Errorf("%w: ref to undefined definition %q", ErrInvalidSchema, *schema.Ref)
+	}
+
+	aValidation.schemaTokens = append(aValidation.schemaTokens, []string{"definitions", *schema.Ref})
+	err := aValidation.validate(definition, instance, nil)
+	aValidation.schemaTokens = aValidation.schemaTokens[:len(aValidation.schemaTokens)-1]
+
+	return err
+}
+
+func (aValidation *validation) validateType(schema *Schema, instance interface{}) error {
+	var isValid bool
+
+	switch *schema.Type {
+	case TypeBoolean:
+		_, isValid = instance.(bool)
+	case TypeString:
+		_, isValid = instance.(string)
+	case TypeTimestamp:
+		value, isString := instance.(string)
+		isValid = isString && isTimestamp(value)
+	case TypeFloat32, TypeFloat64:
+		_, isValid = asFloat(instance)
+	default:
+		value, isNumber := asFloat(instance)
+		limits := integerRanges[*schema.Type]
+		isValid = isNumber && value == math.Trunc(value) && value >= limits.minimum && value <= limits.maximum
+	}
+
+	if !isValid {
+		return aValidation.pushErrorAt("type")
+	}
+
+	return nil
+}
+
+func (aValidation *validation) validateValues(schema *Schema, instance interface{}) error {
+	object, isObject := instance.(map[string]interface{})
+	if !isObject {
+		return aValidation.pushErrorAt("values")
+	}
+
+	aValidation.pushSchema("values")
+	defer aValidation.popSchema()
+
+	for _, key := range sortedKeys(object) {
+		aValidation.pushInstance(key)
+		err := aValidation.validate(schema.Values, object[key], nil)
+		aValidation.popInstance()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Convert a number to float64.
+func asFloat(instance interface{}) (float64, bool) {
+	switch value := instance.(type) {
+	case float64:
+		return value, true
+	case json.Number:
+		result, err := value.Float64()
+
+		return result, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// Join escaped tokens into a JSON Pointer.
+func asJSONPointer(tokens []string) string {
+	var result strings.Builder
+
+	for _, token := range tokens {
+		result.WriteString("/")
+		result.WriteString(escapeToken(token))
+	}
+
+	return result.String()
+}
+
+// Convert an arbitrary Go value into the generic values produced by encoding/json.
+// The elements of []interface{} and map[string]interface{} are converted too.
+func asJSONValue(instance interface{}) (interface{}, error) {
+	switch typedInstance := instance.(type) {
+	case nil, bool, float64, json.Number, string:
+		return instance, nil
+	case []interface{}:
+		result := make([]interface{}, len(typedInstance))
+
+		for index, element := range typedInstance {
+			value, err := asJSONValue(element)
+			if err != nil {
+				return nil, err
+			}
+
+			result[index] = value
+		}
+
+		return result, nil
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(typedInstance))
+
+		for key, element := range typedInstance {
+			value, err := asJSONValue(element)
+			if err != nil {
+				return nil, err
+			}
+
+			result[key] = value
+		}
+
+		return result, nil
+	}
+
+	instanceJSON, err := json.Marshal(instance)
+	if err != nil {
+		return nil, fmt.Errorf("jtd.Validate error: %w", err)
+	}
+
+	var result interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(instanceJSON))
+	decoder.UseNumber()
+
+	err = decoder.Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("jtd.Validate error: %w", err)
+	}
+
+	return result, nil
+}
+
+// Decode a value that must be the only one in the decoder's input.
+func decodeOnly(decoder *json.Decoder, value interface{}) error {
+	err := decoder.Decode(value)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	var trailing json.RawMessage
+
+	err = decoder.Decode(&trailing)
+	switch {
+	case errors.Is(err, io.EOF):
+		return nil
+	case err != nil:
+		return err //nolint:wrapcheck
+	default:
+		return errTrailingData
+	}
+}
+
+// Escape a JSON Pointer reference token.  See RFC 6901 section 3.
+func escapeToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// Determine if a string is an RFC 3339 timestamp.  Leap seconds are allowed.
+func isTimestamp(value string) bool {
+	candidate := strings.ToUpper(value)
+
+	if len(candidate) > 19 && candidate[17:19] == "60" {
+		candidate = candidate[:17] + "59" + candidate[19:]
+	}
+
+	_, err := time.Parse(time.RFC3339Nano, candidate)
+
+	return err == nil
+}
+
+// Keys of a map in sorted order, making error indicators deterministic.
+func sortedKeys[V any](aMap map[string]V) []string {
+	result := make([]string, 0, len(aMap))
+	for key := range aMap {
+		result = append(result, key)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,141 @@
+package jtd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ----------------------------------------------------------------------------
+// Types - struct
+// ----------------------------------------------------------------------------
+
+// A Schema is a JSON Type Definition as described in RFC 8927.
+// Only the root schema may contain Definitions.
+type Schema struct {
+	Definitions          map[string]*Schema     `json:"definitions,omitempty"`
+	Metadata             map[string]interface{} `json:"metadata,omitempty"`
+	Nullable             bool                   `json:"nullable,omitempty"`
+	Ref                  *string                `json:"ref,omitempty"`
+	Type                 *string                `json:"type,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Elements             *Schema                `json:"elements,omitempty"`
+	Properties           map[string]*Schema     `json:"properties,omitempty"`
+	OptionalProperties   map[string]*Schema     `json:"optionalProperties,omitempty"`
+	AdditionalProperties bool                   `json:"additionalProperties,omitempty"`
+	Values               *Schema                `json:"values,omitempty"`
+	Discriminator        *string                `json:"discriminator,omitempty"`
+	Mapping              map[string]*Schema     `json:"mapping,omitempty"`
+}
+
+// A ValidationError is an RFC 8927 error indicator.
+// Both paths are JSON Pointers (RFC 6901).
+type ValidationError struct {
+	InstancePath string `json:"instancePath"` // Location in the instance that was rejected.
+	SchemaPath   string `json:"schemaPath"`   // Location in the schema of the rule that rejected it.
+}
+
+// --- Options for Validate() --------------------------------------------------
+
+// Maximum number of "ref" indirections to follow before giving up.
+type OptionMaxDepth struct {
+	Value int // Zero means unlimited.
+}
+
+// Maximum number of errors to report.
+type OptionMaxErrors struct {
+	Value int // Zero means unlimited.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Forms a schema may take.  See RFC 8927 section 2.2.
+const (
+	FormEmpty         = "empty"
+	FormRef           = "ref"
+	FormType          = "type"
+	FormEnum          = "enum"
+	FormElements      = "elements"
+	FormProperties    = "properties"
+	FormValues        = "values"
+	FormDiscriminator = "discriminator"
+)
+
+// Values of the "type" keyword.  See RFC 8927 section 2.2.3.
+const (
+	TypeBoolean   = "boolean"
+	TypeString    = "string"
+	TypeTimestamp = "timestamp"
+	TypeFloat32   = "float32"
+	TypeFloat64   = "float64"
+	TypeInt8      = "int8"
+	TypeUint8     = "uint8"
+	TypeInt16     = "int16"
+	TypeUint16    = "uint16"
+	TypeInt32     = "int32"
+	TypeUint32    = "uint32"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var (
+	ErrInvalidSchema    = errors.New("invalid JTD schema")
+	ErrMaxDepthExceeded = errors.New("maximum JTD ref depth exceeded")
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Parse function creates a Schema from its JSON representation and verifies
+that it is a correct RFC 8927 root schema.
+
+Input
+  - schemaJSON: The JSON text of a JTD schema.
+
+Output
+  - The verified schema.
+*/
+func Parse(schemaJSON []byte) (*Schema, error) {
+	result := &Schema{}
+
+	decoder := json.NewDecoder(bytes.NewReader(schemaJSON))
+	decoder.DisallowUnknownFields()
+
+	err := decodeOnly(decoder, result)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+
+	err = result.Verify()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+/*
+The ParseFile function reads a file and calls Parse on its contents.
+
+Input
+  - filename: Path to a file holding a JTD schema.
+
+Output
+  - The verified schema.
+*/
+func ParseFile(filename string) (*Schema, error) {
+	schemaJSON, err := os.ReadFile(filename) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("jtd.ParseFile error: %w", err)
+	}
+
+	return Parse(schemaJSON)
+}
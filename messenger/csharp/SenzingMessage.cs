@@ -73,5 +73,33 @@ namespace Senzing
         /// </summary>
         [JsonPropertyName("time")]
         public DateTimeOffset Time { get; set; }
+
+        /// <summary>
+        /// Location in the code, as package, function, file, and line.
+        /// </summary>
+        [JsonPropertyName("caller")]
+        [JsonIgnore(Condition = JsonIgnoreCondition.WhenWritingDefault)]
+        public Frame Caller { get; set; }
+
+        /// <summary>
+        /// URL of documentation for the message.
+        /// </summary>
+        [JsonPropertyName("help")]
+        [JsonIgnore(Condition = JsonIgnoreCondition.WhenWritingDefault)]
+        public string Help { get; set; }
+
+        /// <summary>
+        /// What to do about the message.
+        /// </summary>
+        [JsonPropertyName("remediation")]
+        [JsonIgnore(Condition = JsonIgnoreCondition.WhenWritingDefault)]
+        public string Remediation { get; set; }
+
+        /// <summary>
+        /// Stack trace where the message or its error was created.
+        /// </summary>
+        [JsonPropertyName("stack")]
+        [JsonIgnore(Condition = JsonIgnoreCondition.WhenWritingDefault)]
+        public Stack Stack { get; set; }
     }
 }
@@ -1,5 +1,15 @@
 /*
 Package messenger creates representations of a message.
 These representations can be used in message passing, observing, logging, etc.
+
+The SENZING_MESSAGE_FIELDS environment variable chooses the fields of messages: a comma-separated
+list of fields, or "all", optionally followed by ";"-separated rules of the form "selector: fields".
+A selector is a message id, a message number range such as "4000-4999", or a level such as "level>=ERROR".
+The fields of a rule may include "level=NAME" to change the level of matching messages.
+Rules work as OptionMessageRules, and are tried before them.
+
+	SENZING_MESSAGE_FIELDS="id,text; level>=ERROR: time,level,id,text,location,errors; SZSDK99993001: id,text,level=ERROR"
 */
 package messenger
+
+//go:generate go run ../go/typedefgen -schema ../message-RFC8927.json -package messenger -producer -root-name MessageFormat -out message_format.go
@@ -2,6 +2,9 @@ package messenger
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"slices"
 	"time"
 
 	"golang.org/x/exp/slog"
@@ -13,6 +16,16 @@ import (
 
 // The Messenger interface has methods for creating different
 // representations of a message.
+//
+// A suppressed message is empty, not absent: NewJSON returns "" when the message is below its minimum level
+// or rate limited, and NewSlog and NewSlogLevel return an empty message and nil key-value pairs when it is below
+// its minimum level.  Printing or logging the result anyway writes an empty line or record,
+// so skip an empty JSON string, and check LevelEnabler.Enabled before calling NewSlog or NewSlogLevel:
+//
+//	if levelEnabler, isLevelEnabler := aMessenger.(messenger.LevelEnabler); !isLevelEnabler || levelEnabler.Enabled(2001) {
+//		message, keyValuePairs := aMessenger.NewSlog(2001, "Bob", "Jane")
+//		logger.Info(message, keyValuePairs...)
+//	}
 type Messenger interface {
 	NewError(messageNumber int, details ...interface{}) error
 	NewJSON(messageNumber int, details ...interface{}) string
@@ -20,33 +33,114 @@ type Messenger interface {
 	NewSlogLevel(messageNumber int, details ...interface{}) (string, slog.Level, []interface{})
 }
 
+// The LevelEnabler interface reports whether messages would be suppressed by their level,
+// so that expensive details need not be computed.  BasicMessenger implements it.
+type LevelEnabler interface {
+	Enabled(messageNumber int) bool
+}
+
 // ----------------------------------------------------------------------------
 // Types - struct
 // ----------------------------------------------------------------------------
 
-// Fields in the formatted message.
-// Order is important.
-// It should be time, level, id, text, code, reason, status, duration, location, errors, details.
-type MessageFormat struct {
-	Time     string      `json:"time,omitempty"`     // Time of message in UTC.
-	Level    string      `json:"level,omitempty"`    // Level:  TRACE, DEBUG, INFO, WARN, ERROR, FATAL, PANIC.
-	ID       string      `json:"id,omitempty"`       // Message identifier.
-	Text     string      `json:"text,omitempty"`     // Message text.
-	Code     string      `json:"code,omitempty"`     // Underlying reason code.
-	Reason   string      `json:"reason,omitempty"`   // Underlying reason.
-	Status   string      `json:"status,omitempty"`   // Status information.
-	Duration int64       `json:"duration,omitempty"` // Duration in nanoseconds
-	Location string      `json:"location,omitempty"` // Location in the code issuing message.
-	Errors   interface{} `json:"errors,omitempty"`   // List of errors.
-	Details  []Detail    `json:"details,omitempty"`  // All instances passed into the message.
-}
-
-type Detail struct {
-	Key      string      `json:"key,omitempty"`
-	Position int32       `json:"position,omitempty"`
-	Type     string      `json:"type,omitempty"`
-	Value    string      `json:"value,omitempty"`
-	ValueRaw interface{} `json:"valueRaw,omitempty"`
+// An AggregatedMessage is a message that occurred Count times, written by an Aggregator.
+// Fields other than the aggregate metadata come from the first occurrence.
+type AggregatedMessage struct {
+	MessageFormat
+	Count     int    `json:"count"`     // Number of occurrences.
+	FirstSeen string `json:"firstSeen"` // Time of the first occurrence in RFC3339 format.
+	LastSeen  string `json:"lastSeen"`  // Time of the last occurrence in RFC3339 format.
+}
+
+// A MessageRule chooses the fields, and optionally the level, of the messages it matches.
+// All non-zero criteria must match.
+// Of the matching rules, the first having Fields chooses the fields and the first having Level chooses the level.
+type MessageRule struct {
+	ID           string   // Message id, such as "SZSDK99994001".
+	IDMin        int      // With IDMax, the first message number of a range.
+	IDMax        int      // The last message number of a range.  Zero matches any message number.
+	MinimumLevel string   // Level name, such as LevelErrorName.  Matches the level of the message number and above.
+	Fields       []string // Fields of matching messages, as in OptionMessageFields, or ["all"].
+	Level        string   // Level name which replaces the level of matching messages.
+}
+
+// A RateLimit configures a RateLimiter.
+// In each Interval, the first Burst messages having the same key are allowed,
+// then only every SampleEvery-th message is allowed.
+type RateLimit struct {
+	Burst       int                    // Messages allowed per Interval before sampling begins.
+	ByDetails   bool                   // If true, the key is the message id plus a hash of the details.
+	Clock       func() time.Time       // Source of the current time.  Default: time.Now.
+	Interval    time.Duration          // Length of a window.  Zero means a single window that never ends.
+	OnSummary   func(RateLimitSummary) // Called when a window that suppressed messages ends, or on Flush().  May be called by a timer goroutine.
+	SampleEvery int                    // After Burst, allow 1 in SampleEvery messages.  Zero suppresses all.
+}
+
+// A RateLimitSummary reports the messages suppressed for one key during one window.
+type RateLimitSummary struct {
+	Allowed    int       // Messages allowed in the window.
+	End        time.Time // End of the window.
+	ID         string    // The message id.
+	Key        string    // The message id, or message id and details hash when ByDetails is set.
+	Start      time.Time // Start of the window.
+	Suppressed int       // Messages suppressed in the window.
+}
+
+// A RedactionRule identifies sensitive values and how to redact them.
+// All non-zero criteria must match.
+// Without Pattern, the whole value is redacted.
+// With Pattern, only the text matching Pattern is redacted.
+// A rule having only a Pattern applies to every string in the message:
+// text, reason, errors, and details, including strings inside JSON values.
+// Position and Type are those of the detail, so with Key they select the keys of, or JSON object keys within,
+// matching details only.
+// A rule with a Key is applied to details before "text" is formatted from them,
+// so a detail used in "text" shows its keys redacted, with a JSON value shown as its redacted JSON.
+type RedactionRule struct {
+	Key      string            // Detail key or JSON object key, compared case-insensitively.
+	Position int32             // Detail position, starting at 1.
+	Type     string            // Go type of the detail, as reported by reflect.TypeOf(), or "error".
+	Pattern  *regexp.Regexp    // Text to redact.
+	Strategy RedactionStrategy // How to redact.
+}
+
+// A StackTrace configures the "stack" field, which is included when OptionStackTrace is given and "stack" is
+// one of the message fields.
+// The stack comes from the innermost error detail carrying a trace, such as those created by
+// github.com/pkg/errors, otherwise from where the message was created.
+type StackTrace struct {
+	Depth        int      // Maximum number of frames.  Default: 32.
+	Level        string   // Minimum level, such as LevelWarnName.  Default: LevelErrorName.
+	SkipPackages []string // Import paths of packages whose frames are omitted, in addition to "runtime" and this package.
+}
+
+// A RedactionStrategy determines how a value is redacted.
+type RedactionStrategy int
+
+// A Configuration holds the settings of a Configurator, which may change while messengers are in use.
+// Empty values defer to the messenger's own settings.
+type Configuration struct {
+	IDOverrides   map[string]IDConfiguration `json:"idOverrides,omitempty"`   // Settings for message ids, such as "SZSDK99992001".
+	MessageFields []string                   `json:"messageFields,omitempty"` // Fields, as in OptionMessageFields, or ["all"].  Precedes SENZING_MESSAGE_FIELDS.
+	MinimumLevel  string                     `json:"minimumLevel,omitempty"`  // Level name, such as LevelWarnName.  Precedes OptionMinimumLevel.
+}
+
+// An IDConfiguration holds the settings of a Configuration for one message id.
+// Empty values defer to the Configuration.
+type IDConfiguration struct {
+	MessageFields []string `json:"messageFields,omitempty"` // Fields, as in OptionMessageFields, or ["all"].
+	MinimumLevel  string   `json:"minimumLevel,omitempty"`  // Level name, such as LevelTraceName.
+}
+
+// MessageLimits bound the size of a message.  A zero value means no limit.
+// Truncated strings end with "...[truncated N bytes]".
+// Omitted details are replaced by a final detail with key "truncated" and value "N details omitted".
+// Omitted errors are replaced by a final entry "[truncated: N errors omitted]".
+type MessageLimits struct {
+	MaxDetailCount  int // Maximum number of "details" entries.
+	MaxMessageBytes int // Maximum length of NewJSON() and NewError() output.
+	MaxTextLength   int // Maximum length, in bytes, of "text".
+	MaxValueBytes   int // Maximum length, in bytes, of each detail "value" and each "errors" entry.
 }
 
 // --- Override values when creating messages ---------------------------------
@@ -66,6 +160,11 @@ type MessageDuration struct {
 	Value int64 // Duration in nanoseconds
 }
 
+// Value of the "help" field.
+type MessageHelp struct {
+	Value string // URL of documentation for the message.
+}
+
 // Value of the "id" field.
 type MessageID struct {
 	Value string // Message identifier.
@@ -86,6 +185,11 @@ type MessageReason struct {
 	Value string // Underlying message reason.
 }
 
+// Value of the "remediation" field.
+type MessageRemediation struct {
+	Value string // What to do about the message.
+}
+
 // Value of the "status" field.
 type MessageStatus struct {
 	Value string // Status information.
@@ -105,7 +209,33 @@ type MessageTime struct {
 
 // Number of callers to skip when determining location.
 type OptionCallerSkip struct {
-	Value int // Number of callers to skip in the stack trace when determining the location.
+	Value int // Number of callers to skip in the stack trace when determining the location, or CallerSkipAuto.
+}
+
+// Packages or functions that wrap the messenger, skipped when using CallerSkipAuto.
+type OptionCallerWrappers struct {
+	Value []string // Import paths, such as "example.com/logging", or functions, such as "example.com/logging.(*Logger).Log".
+}
+
+// Runtime configuration of fields and minimum levels, shared with other messengers and an admin endpoint.
+type OptionConfigurator struct {
+	Value *Configurator // Created by NewConfigurator().
+}
+
+// Formatters of "details" values, such as those of custom types.
+type OptionDetailFormatters struct {
+	Value *DetailFormatters // Created by NewDetailFormatters().
+}
+
+// Render Secret() and PII() values as a salted hash instead of a placeholder.
+type OptionHashSensitive struct {
+	Value bool // If true, use RedactHash with OptionRedactionSalt.
+}
+
+// Template of the "help" field.  The message id replaces the verb, as in
+// "https://example.com/errors.html#%s".
+type OptionHelpURLTemplate struct {
+	Value string // Format string.
 }
 
 // Map of message number to message templates.
@@ -113,6 +243,11 @@ type OptionIDMessages struct {
 	Value map[int]string // Message number to message template map.
 }
 
+// Map of message number to remediation text.
+type OptionIDRemediations struct {
+	Value map[int]string // Message number to the "remediation" field.
+}
+
 // Map of message number to status values.
 type OptionIDStatuses struct {
 	Value map[int]string // Message number to status map
@@ -120,12 +255,24 @@ type OptionIDStatuses struct {
 
 // List of fields included in final message.
 type OptionMessageField struct {
-	Value string // One of AllMessageFields values.
+	Value string // One of AllMessageFields or NamedMessageFields values.
 }
 
 // List of fields included in final message.
 type OptionMessageFields struct {
-	Value []string // One or more of AllMessageFields values.
+	Value []string // One or more of AllMessageFields or NamedMessageFields values.
+}
+
+// Minimum level of messages created by NewJSON() and NewSlogLevel().
+// A *slog.LevelVar allows the level to be changed while the messenger is in use.
+type OptionMinimumLevel struct {
+	Value slog.Leveler // Such as LevelWarnSlog or a *slog.LevelVar.
+}
+
+// Rules choosing the fields and levels of messages by id, message number range, or level.
+// Rules from SENZING_MESSAGE_FIELDS are tried first.
+type OptionMessageRules struct {
+	Value []MessageRule // Tried in order.
 }
 
 // Format of the unique id.
@@ -133,6 +280,38 @@ type OptionMessageIDTemplate struct {
 	Value string // Format string.
 }
 
+// Limits on the size of messages.
+type OptionMessageLimits struct {
+	Value MessageLimits // Zero values mean no limit.
+}
+
+// Rate limiter applied to NewJSON().
+// Share the same RateLimiter with RateLimiter.Handler() to limit the slog path; summaries of messages suppressed
+// from NewJSON() are then logged through that handler, as well as passed to RateLimit.OnSummary.
+type OptionRateLimiter struct {
+	Value *RateLimiter // Created by NewRateLimiter().
+}
+
+// Rules for redacting sensitive values.
+type OptionRedactionRules struct {
+	Value []RedactionRule // Applied in order.
+}
+
+// Salt prepended to values before hashing by RedactHash.
+type OptionRedactionSalt struct {
+	Value string // Salt.
+}
+
+// Reveal Secret() and PII() values.  Ignored unless built with "-tags messenger_debug".
+type OptionRevealSensitive struct {
+	Value bool // If true, and a debug build, emit the original values.
+}
+
+// Configuration of the "stack" field.  Without it, stacks are not captured, even with "stack" in the message fields.
+type OptionStackTrace struct {
+	Value StackTrace // Depth, level threshold, and frame filtering.
+}
+
 // ----------------------------------------------------------------------------
 // Constants
 // ----------------------------------------------------------------------------
@@ -171,6 +350,20 @@ const (
 	LevelWarnSlog  = slog.LevelWarn
 )
 
+// Redaction strategies.
+const (
+	RedactMask RedactionStrategy = iota // Replace with RedactionMask.
+	RedactHash                          // Replace with a salted SHA-256 digest, e.g. "sha256:1a2b3c4d5e6f7a8b".
+	RedactDrop                          // Remove the detail or JSON member. In "text", RedactionMask is used.
+)
+
+// Replacement text used by RedactMask.
+const RedactionMask = "[REDACTED]"
+
+// Value for OptionCallerSkip which finds the first caller outside of "runtime",
+// this package, and OptionCallerWrappers.
+const CallerSkipAuto = -1
+
 // ----------------------------------------------------------------------------
 // Variables
 // ----------------------------------------------------------------------------
@@ -210,8 +403,9 @@ var TextToLevelMap = map[string]slog.Level{
 }
 
 var (
-	ErrEmptyMessages = errors.New("messages must be a map[int]string")
-	ErrEmptyStatuses = errors.New("statuses must be a map[int]string")
+	ErrEmptyMessages        = errors.New("messages must be a map[int]string")
+	ErrEmptyStatuses        = errors.New("statuses must be a map[int]string")
+	ErrInvalidConfiguration = errors.New("invalid configuration")
 )
 
 // Order is important in AllMessageFields. Should match order in MessageFormat.
@@ -222,13 +416,23 @@ var AllMessageFields = []string{
 	"text",
 	"code",
 	"reason",
+	"remediation",
+	"help",
 	"status",
 	"duration",
 	"location",
 	"errors",
 	"details",
+	"stack",
 }
 
+// Fields which are included only when named, as they are not part of AllMessageFields or "all".
+// "caller" is a structured form of "location".
+var NamedMessageFields = []string{"caller"}
+
+// Fields of messages when neither SENZING_MESSAGE_FIELDS nor OptionMessageFields are given.
+var defaultMessageFields = []string{"id", "text"}
+
 // ----------------------------------------------------------------------------
 // Public functions
 // ----------------------------------------------------------------------------
@@ -246,10 +450,24 @@ func New(options ...interface{}) (Messenger, error) {
 
 	var (
 		callerSkip        int
+		callerWrappers    []string
+		configurator      *Configurator
+		detailFormatters  *DetailFormatters
+		hashSensitive     bool
+		helpURLTemplate   string
 		idMessages        = map[int]string{}
+		idRemediations    = map[int]string{}
 		idStatuses        = map[int]string{}
 		messageIDTemplate = "%04d"
 		messageFields     []string
+		messageLimits     MessageLimits
+		messageRules      []MessageRule
+		minimumLevel      slog.Leveler
+		rateLimiter       *RateLimiter
+		redactionRules    []RedactionRule
+		redactionSalt     string
+		revealSensitive   bool
+		stackTrace        *StackTrace
 	)
 
 	// Process options.
@@ -258,14 +476,42 @@ func New(options ...interface{}) (Messenger, error) {
 		switch typedValue := value.(type) {
 		case OptionCallerSkip:
 			callerSkip = typedValue.Value
+		case OptionCallerWrappers:
+			callerWrappers = typedValue.Value
+		case OptionConfigurator:
+			configurator = typedValue.Value
+		case OptionDetailFormatters:
+			detailFormatters = typedValue.Value
+		case OptionHashSensitive:
+			hashSensitive = typedValue.Value
+		case OptionHelpURLTemplate:
+			helpURLTemplate = typedValue.Value
 		case OptionIDMessages:
 			idMessages = typedValue.Value
+		case OptionIDRemediations:
+			idRemediations = typedValue.Value
 		case OptionIDStatuses:
 			idStatuses = typedValue.Value
 		case OptionMessageFields:
 			messageFields = typedValue.Value
 		case OptionMessageIDTemplate:
 			messageIDTemplate = typedValue.Value
+		case OptionMessageLimits:
+			messageLimits = typedValue.Value
+		case OptionMessageRules:
+			messageRules = typedValue.Value
+		case OptionMinimumLevel:
+			minimumLevel = typedValue.Value
+		case OptionRateLimiter:
+			rateLimiter = typedValue.Value
+		case OptionRedactionRules:
+			redactionRules = typedValue.Value
+		case OptionRedactionSalt:
+			redactionSalt = typedValue.Value
+		case OptionRevealSensitive:
+			revealSensitive = typedValue.Value
+		case OptionStackTrace:
+			stackTrace = &typedValue.Value
 		}
 	}
 
@@ -279,14 +525,36 @@ func New(options ...interface{}) (Messenger, error) {
 		return result, ErrEmptyStatuses
 	}
 
+	messageRules = slices.Clone(messageRules)
+	for index, rule := range messageRules {
+		messageRules[index], err = normalizeMessageRule(rule)
+		if err != nil {
+			return result, fmt.Errorf("messenger.New error: message rule %d: %w", index, err)
+		}
+	}
+
 	// Create MessengerInterface.
 
 	result = &BasicMessenger{
 		callerSkip:        callerSkip,
+		callerWrappers:    callerWrappers,
+		configurator:      configurator,
+		detailFormatters:  detailFormatters,
+		hashSensitive:     hashSensitive,
+		helpURLTemplate:   helpURLTemplate,
 		idMessages:        idMessages,
+		idRemediations:    idRemediations,
 		idStatuses:        idStatuses,
 		messageFields:     messageFields,
 		messageIDTemplate: messageIDTemplate,
+		messageLimits:     messageLimits,
+		messageRules:      messageRules,
+		minimumLevel:      minimumLevel,
+		rateLimiter:       rateLimiter,
+		redactionRules:    redactionRules,
+		redactionSalt:     redactionSalt,
+		revealSensitive:   revealSensitive,
+		stackTrace:        stackTrace,
 	}
 
 	return result, err
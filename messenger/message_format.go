@@ -0,0 +1,41 @@
+// Code generated by typedefgen from message-RFC8927.json. DO NOT EDIT.
+
+package messenger
+
+// Fields in the formatted message.
+// Order is important.
+// It should be time, level, id, text, code, reason, remediation, help, status, duration, location, caller, errors, details, stack.
+type MessageFormat struct {
+	Time        string   `json:"time,omitempty"`        // Time message was generated in RFC3339 format.
+	Level       string   `json:"level,omitempty"`       // Log level.  Possible values: TRACE, DEBUG, INFO, WARN, ERROR, FATAL, or PANIC.
+	ID          string   `json:"id,omitempty"`          // The unique identification of the message.
+	Text        string   `json:"text,omitempty"`        // Text representation of the message.
+	Code        string   `json:"code,omitempty"`        // Code for message.
+	Reason      string   `json:"reason,omitempty"`      // Reason for message.
+	Remediation string   `json:"remediation,omitempty"` // What to do about the message.
+	Help        string   `json:"help,omitempty"`        // URL of documentation for the message.
+	Status      string   `json:"status,omitempty"`      // User-defined status of message.
+	Duration    int64    `json:"duration,omitempty"`    // Time duration reported by the message.
+	Location    string   `json:"location,omitempty"`    // Location in the code identifying where the message was generated.
+	Caller      *Frame   `json:"caller,omitempty"`      // Location in the code, as package, function, file, and line.
+	Errors      []string `json:"errors,omitempty"`      // A list of errors.  Usually a stack of errors.
+	Details     []Detail `json:"details,omitempty"`     // A list of objects sent to the message generator.
+	Stack       []Frame  `json:"stack,omitempty"`       // Stack trace where the message or its error was created.
+}
+
+// A detail published by the message generator.
+type Detail struct {
+	Key      string      `json:"key,omitempty"`      // The unique identifier of the detail.
+	Position int32       `json:"position,omitempty"` // The order in which the detail was given to the message generator.
+	Type     string      `json:"type,omitempty"`     // Datatype of the value.
+	Value    string      `json:"value,omitempty"`    // The value of the detail in string form.
+	ValueRaw interface{} `json:"valueRaw,omitempty"` // The value of the detail if it differs from string form.
+}
+
+// A location in the code.
+type Frame struct {
+	File     string `json:"file,omitempty"`     // Source file of the frame.
+	Function string `json:"function,omitempty"` // Function, without the package path.
+	Line     int32  `json:"line,omitempty"`     // Line number in the source file.
+	Package  string `json:"package,omitempty"`  // Package, as an import path.
+}
@@ -0,0 +1,273 @@
+package messenger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// An Aggregator coalesces identical messages, those having the same "id", "text", and "code",
+// into one AggregatedMessage per window.
+// It is an io.Writer that accepts the output of NewJSON(), so it can replace the writer messages are sent to.
+// It is safe for concurrent use.
+type Aggregator struct {
+	closed     bool
+	err        error // First error from a timed flush, returned by the next Flush() or Close().
+	generation int   // Incremented by each flush, so a stale timer does not end a later window.
+	groups     map[aggregationKey]*AggregatedMessage
+	mutex      sync.Mutex
+	order      []aggregationKey
+	timer      *time.Timer
+	window     time.Duration
+	writer     io.Writer
+}
+
+// The fields that make messages identical.
+type aggregationKey struct {
+	code string
+	id   string
+	text string
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var ErrAggregatorClosed = errors.New("aggregator is closed")
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewAggregator function creates an Aggregator.
+
+Input
+  - writer: Where AggregatedMessage JSON lines and non-message lines are written.
+  - window: How long messages are collected before being written.  Zero means only on Flush() or Close().
+
+Output
+  - An Aggregator.  Call Close() on shutdown.
+*/
+func NewAggregator(writer io.Writer, window time.Duration) *Aggregator {
+	return &Aggregator{
+		groups: map[aggregationKey]*AggregatedMessage{},
+		window: window,
+		writer: writer,
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Add method counts a message.
+
+Input
+  - messageFormat: The message.  Its "time", if present, is used for "firstSeen" and "lastSeen".
+
+Output
+  - ErrAggregatorClosed after Close().
+*/
+func (aggregator *Aggregator) Add(messageFormat *MessageFormat) error {
+	aggregator.mutex.Lock()
+	defer aggregator.mutex.Unlock()
+
+	if aggregator.closed {
+		return ErrAggregatorClosed
+	}
+
+	seen := messageFormat.Time
+	if len(seen) == 0 {
+		seen = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+
+	key := aggregationKey{code: messageFormat.Code, id: messageFormat.ID, text: messageFormat.Text}
+
+	group, isOK := aggregator.groups[key]
+	if !isOK {
+		group = &AggregatedMessage{MessageFormat: *messageFormat, FirstSeen: seen}
+		aggregator.groups[key] = group
+		aggregator.order = append(aggregator.order, key)
+	}
+
+	group.Count++
+	group.LastSeen = seen
+
+	if aggregator.window > 0 && aggregator.timer == nil {
+		generation := aggregator.generation
+		aggregator.timer = time.AfterFunc(aggregator.window, func() { aggregator.timedFlush(generation) })
+	}
+
+	return nil
+}
+
+/*
+The Close method stops the window timer and writes all pending messages.
+Later calls to Add() and Write() fail with ErrAggregatorClosed.
+
+Output
+  - The first error from writing, including errors from earlier timed flushes.
+*/
+func (aggregator *Aggregator) Close() error {
+	aggregator.mutex.Lock()
+	aggregator.closed = true
+	aggregator.mutex.Unlock()
+
+	return aggregator.Flush()
+}
+
+/*
+The Flush method writes all pending messages, in order of first occurrence, and starts a new window.
+
+Output
+  - The first error from writing, including errors from earlier timed flushes.
+*/
+func (aggregator *Aggregator) Flush() error {
+	aggregator.mutex.Lock()
+	defer aggregator.mutex.Unlock()
+
+	err := aggregator.flush()
+	if aggregator.err != nil {
+		err = aggregator.err
+		aggregator.err = nil
+	}
+
+	return err
+}
+
+/*
+The Write method accepts one or more messages, such as the output of NewJSON(), one per line.
+Lines that are not messages are written through immediately.
+Each call must contain whole lines; a final line without a newline is treated as complete.
+
+Input
+  - line: JSON messages separated by newlines.
+
+Output
+  - The number of bytes accepted.
+  - ErrAggregatorClosed after Close(), or an error from writing a non-message line.
+*/
+func (aggregator *Aggregator) Write(line []byte) (int, error) {
+	for _, part := range bytes.Split(bytes.TrimRight(line, "\n"), []byte("\n")) {
+		var err error
+
+		messageFormat := unmarshalMessageFormat(part)
+		if messageFormat == nil {
+			err = aggregator.writeThrough(part)
+		} else {
+			err = aggregator.Add(messageFormat)
+		}
+
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(line), nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Write and clear pending messages.  Must be called while holding the mutex.
+func (aggregator *Aggregator) flush() error {
+	var result error
+
+	aggregator.generation++
+
+	if aggregator.timer != nil {
+		aggregator.timer.Stop()
+		aggregator.timer = nil
+	}
+
+	for _, key := range aggregator.order {
+		var line bytes.Buffer
+
+		encoder := json.NewEncoder(&line)
+		encoder.SetEscapeHTML(false)
+
+		err := encoder.Encode(aggregator.groups[key])
+		if err == nil {
+			_, err = aggregator.writer.Write(line.Bytes())
+		}
+
+		if err != nil && result == nil {
+			result = fmt.Errorf("messenger.Aggregator.Flush error: %w", err)
+		}
+	}
+
+	aggregator.groups = map[aggregationKey]*AggregatedMessage{}
+	aggregator.order = nil
+
+	return result
+}
+
+// Called by the window timer.
+func (aggregator *Aggregator) timedFlush(generation int) {
+	aggregator.mutex.Lock()
+	defer aggregator.mutex.Unlock()
+
+	if generation != aggregator.generation {
+		return
+	}
+
+	err := aggregator.flush()
+	if err != nil && aggregator.err == nil {
+		aggregator.err = err
+	}
+}
+
+func (aggregator *Aggregator) writeThrough(line []byte) error {
+	aggregator.mutex.Lock()
+	defer aggregator.mutex.Unlock()
+
+	if aggregator.closed {
+		return ErrAggregatorClosed
+	}
+
+	_, err := fmt.Fprintln(aggregator.writer, string(line))
+	if err != nil {
+		return fmt.Errorf("messenger.Aggregator.Write error: %w", err)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Parse a message, keeping each "valueRaw" as the original JSON text.  Returns nil if the line is not a message.
+func unmarshalMessageFormat(line []byte) *MessageFormat {
+	var rawDetails struct {
+		Details []struct {
+			ValueRaw json.RawMessage `json:"valueRaw"`
+		} `json:"details"`
+	}
+
+	result := &MessageFormat{}
+
+	err := json.Unmarshal(line, result)
+	if err != nil || len(result.ID) == 0 || json.Unmarshal(line, &rawDetails) != nil {
+		return nil
+	}
+
+	for index, detail := range rawDetails.Details {
+		if len(detail.ValueRaw) > 0 {
+			result.Details[index].ValueRaw = detail.ValueRaw
+		}
+	}
+
+	return result
+}
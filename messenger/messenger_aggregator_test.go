@@ -0,0 +1,97 @@
+package messenger_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A bytes.Buffer that is safe for the Aggregator's window timer.
+type syncBuffer struct {
+	buffer bytes.Buffer
+	mutex  sync.Mutex
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestAggregator_Write(test *testing.T) {
+	test.Parallel()
+
+	var buffer bytes.Buffer
+
+	aggregator := messenger.NewAggregator(&buffer, 0)
+	testObject, err := messenger.New(getOptionMessageIDTemplate(9999), getOptionMessageFieldsAll(), getOptionIDMessages())
+	require.NoError(test, err)
+
+	for index := range 3 {
+		_, err = fmt.Fprintln(aggregator, testObject.NewJSON(3001, "Bob", "Jane", `{"b": 1, "a": 2}`,
+			messenger.MessageTime{Value: time.Date(2000, time.January, 1, 0, 0, index, 0, time.UTC)}))
+		require.NoError(test, err)
+	}
+
+	_, err = aggregator.Write([]byte("not a message\n"))
+	require.NoError(test, err)
+
+	_, err = fmt.Fprint(aggregator, testObject.NewJSON(3001, "Bob", "Mary",
+		messenger.MessageTime{Value: time.Date(2000, time.January, 1, 0, 0, 5, 0, time.UTC)}))
+	require.NoError(test, err)
+	assert.Equal(test, "not a message\n", buffer.String())
+
+	require.NoError(test, aggregator.Close())
+
+	expected := `not a message
+{"time":"2000-01-01T00:00:00Z","level":"WARN","id":"SZSDK99993001","text":"WARN: Bob works with Jane","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"},{"position":3,"type":"string","value":"{\"b\": 1, \"a\": 2}","valueRaw":{"b":1,"a":2}}],"count":3,"firstSeen":"2000-01-01T00:00:00Z","lastSeen":"2000-01-01T00:00:02Z"}
+{"time":"2000-01-01T00:00:05Z","level":"WARN","id":"SZSDK99993001","text":"WARN: Bob works with Mary","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Mary"}],"count":1,"firstSeen":"2000-01-01T00:00:05Z","lastSeen":"2000-01-01T00:00:05Z"}
+`
+	assert.Equal(test, expected, buffer.String())
+
+	_, err = aggregator.Write([]byte(testObject.NewJSON(3001, "Bob", "Jane")))
+	require.ErrorIs(test, err, messenger.ErrAggregatorClosed)
+}
+
+func TestAggregator_window(test *testing.T) {
+	test.Parallel()
+
+	buffer := &syncBuffer{}
+	aggregator := messenger.NewAggregator(buffer, 10*time.Millisecond)
+
+	for range 5 {
+		require.NoError(test, aggregator.Add(&messenger.MessageFormat{ID: "SZSDK99993001", Text: "Repeated"}))
+	}
+
+	assert.Eventually(test, func() bool {
+		return strings.Contains(buffer.String(), `"count":5`)
+	}, time.Second, time.Millisecond)
+
+	require.NoError(test, aggregator.Add(&messenger.MessageFormat{ID: "SZSDK99993001", Text: "Repeated"}))
+	require.NoError(test, aggregator.Close())
+	assert.Len(test, strings.Split(strings.TrimSpace(buffer.String()), "\n"), 2)
+	assert.Contains(test, buffer.String(), `"count":1`)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions - names begin with lowercase letter
+// ----------------------------------------------------------------------------
+
+func (buffer *syncBuffer) String() string {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	return buffer.buffer.String()
+}
+
+func (buffer *syncBuffer) Write(data []byte) (int, error) {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	return buffer.buffer.Write(data) //nolint:wrapcheck
+}
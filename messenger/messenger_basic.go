@@ -5,15 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
-	"reflect"
-	"regexp"
-	"runtime"
 	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/exp/slog"
@@ -25,33 +21,67 @@ import (
 
 // BasicMessenger is an type-struct for an implementation of the MessengerInterface.
 type BasicMessenger struct {
-	callerSkip          int            // Levels of code nexting to skip when calculation location
-	idMessages          map[int]string // Map message numbers to text format strings
-	idStatuses          map[int]string
-	messageFields       []string
-	messageIDTemplate   string // A string template for fmt.Sprinf()
-	sortedIDLevelRanges []int  // The keys of IdLevelRanges in sorted order.
+	callerSkip              int               // Levels of code nexting to skip when calculation location
+	callerWrappers          []string          // Packages and functions skipped by CallerSkipAuto.
+	configurator            *Configurator     // If not nil, runtime changes to fields and minimum level.
+	detailFormatters        *DetailFormatters // If nil, the built-in formatters.
+	hashSensitive           bool              // Render Sensitive values as a salted hash.
+	helpURLTemplate         string            // A string template for fmt.Sprintf() of the message id.
+	idMessages              map[int]string    // Map message numbers to text format strings
+	idRemediations          map[int]string    // Map message numbers to remediation text.
+	idStatuses              map[int]string
+	messageFields           []string
+	messageIDTemplate       string          // A string template for fmt.Sprinf()
+	messageLimits           MessageLimits   // Size limits.
+	messageRules            []MessageRule   // Fields and levels by id, range, or level.
+	minimumLevel            slog.Leveler    // If not nil, NewJSON() and NewSlogLevel() suppress lower levels.
+	rateLimiter             *RateLimiter    // If not nil, limits NewJSON().
+	redactionRules          []RedactionRule // Rules for removing sensitive values.
+	redactionSalt           string          // Salt for RedactHash.
+	revealSensitive         bool            // Emit Sensitive values; honored only in debug builds.
+	sortedIDLevelRanges     []int           // The keys of IdLevelRanges in sorted order.
+	sortedIDLevelRangesOnce sync.Once       // Sorts sortedIDLevelRanges on first use.
+	stackTrace              *StackTrace     // Configuration of the "stack" field.  Nil when stacks are not captured.
 }
 
 type theFields struct {
+	caller          *Frame
 	code            string
 	duration        int64
+	help            string
 	id              string
 	level           string
 	location        string
 	reason          string
+	remediation     string
 	status          string
 	text            string
 	callerSkip      int
-	errorList       []interface{}
+	errorList       []string
 	timeNow         string
 	filteredDetails []interface{}
+	stack           []Frame
 }
 
 // ----------------------------------------------------------------------------
 // Interface methods
 // ----------------------------------------------------------------------------
 
+/*
+The Enabled method reports whether messages of the message number are at or above the minimum level
+of OptionConfigurator or OptionMinimumLevel.
+Use it to avoid computing expensive details of a message which would be suppressed.
+
+Input
+  - messageNumber: A message identifier which indexes into "idMessages".
+
+Output
+  - False if NewJSON() and NewSlogLevel() would suppress the message.
+*/
+func (messenger *BasicMessenger) Enabled(messageNumber int) bool {
+	return messenger.isEnabled(messageNumber, nil)
+}
+
 /*
 The NewError method returns an error with a JSON string message.
 
@@ -63,7 +93,9 @@ Output
   - An error with a JSON string representing the details formatted by the template identified by the messageNumber.
 */
 func (messenger *BasicMessenger) NewError(messageNumber int, details ...interface{}) error {
-	return errors.New(messenger.NewJSON(messageNumber, details...)) //nolint
+	// Errors are returned to the caller, not logged, so they are never rate limited or suppressed by level.
+
+	return errors.New(messenger.newErrorJSON(messageNumber, details...)) //nolint
 }
 
 /*
@@ -75,37 +107,32 @@ Input
 
 Output
   - A JSON string representing the details formatted by the template identified by the messageNumber.
+    An empty string if the message is suppressed by its minimum level or OptionRateLimiter; do not print it.
 */
 func (messenger *BasicMessenger) NewJSON(messageNumber int, details ...interface{}) string {
-	messageFormat := messenger.populateStructure(messageNumber, details...)
-
-	// Construct return value.
-
-	// Would love to do it this way, but HTML escaping happens.
-	// Reported in https://github.com/golang/go/issues/56630
-	// result, _ := json.Marshal(messageBuilder)
-	// return string(result), err
-
-	// Work-around.
+	if !messenger.isEnabled(messageNumber, details) {
+		return ""
+	}
 
-	var resultBytes bytes.Buffer
+	if messenger.rateLimiter != nil &&
+		!messenger.rateLimiter.Allow(fmt.Sprintf(messenger.messageIDTemplate, messageNumber), details...) {
+		return ""
+	}
 
-	enc := json.NewEncoder(&resultBytes)
-	enc.SetEscapeHTML(false)
+	messageFormat, truncation := messenger.populateStructure(messageNumber, details...)
 
-	err := enc.Encode(messageFormat)
+	result, err := messenger.marshalLimited(messageFormat, truncation)
 	if err != nil {
 		return err.Error()
 	}
 
-	result := strings.TrimSpace(resultBytes.String())
-
 	return result
 }
 
 /*
 The NewSlog method returns a message and list of Key-Value pairs string with the elements of the message.
 A convenience method for NewSlogLevel(), but without slog.Level returned.
+A suppressed message is empty but would still be logged, so check Enabled() first.
 
 Input
   - messageNumber: A message identifier which indexes into "idMessages".
@@ -114,6 +141,7 @@ Input
 Output
   - A text message
   - A slice of oscillating key-value pairs.
+    If the message is suppressed by its minimum level, an empty message and no key-value pairs.
 */
 func (messenger *BasicMessenger) NewSlog(messageNumber int, details ...interface{}) (string, []interface{}) {
 	message, _, keyValuePairs := messenger.NewSlogLevel(messageNumber, details...)
@@ -123,6 +151,7 @@ func (messenger *BasicMessenger) NewSlog(messageNumber int, details ...interface
 
 /*
 The NewSlogLevel method returns a message. an slog level, and a list of Key-Value pairs string with the elements of the message.
+A suppressed message is empty but, at its own level, would still be logged, so check Enabled() first.
 
 Input
   - messageNumber: A message identifier which indexes into "idMessages".
@@ -132,15 +161,20 @@ Output
   - A text message
   - A message level
   - A slice of oscillating key-value pairs.
+    If the message is suppressed by its minimum level, an empty message and no key-value pairs.
 */
 func (messenger *BasicMessenger) NewSlogLevel(
 	messageNumber int,
 	details ...interface{},
 ) (string, slog.Level, []interface{}) {
+	if !messenger.isEnabled(messageNumber, details) {
+		return "", messenger.messageLevel(messageNumber, details), nil
+	}
+
 	populateDetails := make([]interface{}, 0, len(details)+1)
 	populateDetails = append(populateDetails, details...)
 	populateDetails = append(populateDetails, OptionMessageField{Value: "level"})
-	messageFormat := messenger.populateStructure(messageNumber, populateDetails...)
+	messageFormat, _ := messenger.populateStructure(messageNumber, populateDetails...)
 
 	// Create a text message.
 
@@ -155,7 +189,7 @@ func (messenger *BasicMessenger) NewSlogLevel(
 
 	// Create a slice of oscillating key-value pairs.
 
-	messageFields := messenger.findMessageFields(details...)
+	messageFields := messenger.findMessageFields(messageNumber, fmt.Sprintf(messenger.messageIDTemplate, messageNumber), details...)
 	keyValuePairs := messenger.getKeyValuePairs(messageFormat, messageFields)
 
 	return message, slogLevel, keyValuePairs
@@ -165,33 +199,41 @@ func (messenger *BasicMessenger) NewSlogLevel(
 // Private methods
 // ----------------------------------------------------------------------------
 
-func (messenger *BasicMessenger) findMessageFields(details ...interface{}) []string {
+// The JSON of NewError().  As when NewError() called NewJSON(), the message is populated one frame deeper
+// than by NewJSON(), so an OptionCallerSkip used with NewError() keeps reporting the same location.
+func (messenger *BasicMessenger) newErrorJSON(messageNumber int, details ...interface{}) string {
+	result, err := messenger.marshalLimited(messenger.populateStructure(messageNumber, details...))
+	if err != nil {
+		return err.Error()
+	}
+
+	return result
+}
+
+// Determine the fields of a message.  In order of precedence, the fields are from OptionMessageFields details,
+// OptionConfigurator, rules of SENZING_MESSAGE_FIELDS and OptionMessageRules, SENZING_MESSAGE_FIELDS,
+// and OptionMessageFields of New().
+func (messenger *BasicMessenger) findMessageFields(messageNumber int, id string, details ...interface{}) []string {
 	var (
 		result   []string
 		appendix = []string{}
 	)
 
-	senzingMessageFields := strings.TrimSpace(strings.ToLower(os.Getenv("SENZING_MESSAGE_FIELDS")))
-
-	if messenger.messageFields == nil {
-		messenger.populateMessageFields(senzingMessageFields)
-	}
+	configuredFields := messenger.configurator.messageFields(id)
+	ruleFields, _ := messenger.matchRules(messageNumber, id)
+	environmentFields := environmentMessageFields().fields
 
 	switch {
-	case len(senzingMessageFields) == 0:
+	case configuredFields != nil:
+		result = configuredFields
+	case ruleFields != nil:
+		result = ruleFields
+	case environmentFields != nil:
+		result = environmentFields
+	case messenger.messageFields != nil:
 		result = messenger.messageFields
-	case senzingMessageFields == "all":
-		result = AllMessageFields
 	default:
-		result = []string{}
-
-		messageSplits := strings.Split(senzingMessageFields, ",")
-		for _, value := range messageSplits {
-			valueTrimmed := strings.TrimSpace(value)
-			if slices.Contains(AllMessageFields, valueTrimmed) {
-				result = append(result, valueTrimmed)
-			}
-		}
+		result = defaultMessageFields
 	}
 
 	for _, value := range details {
@@ -204,7 +246,9 @@ func (messenger *BasicMessenger) findMessageFields(details ...interface{}) []str
 		}
 	}
 
-	result = append(result, appendix...)
+	// Clip, so that appending never changes a slice shared with other messages.
+
+	result = append(slices.Clip(result), appendix...)
 
 	return result
 }
@@ -215,16 +259,20 @@ func (messenger *BasicMessenger) getKeyValuePairs(appMessageFormat *MessageForma
 	var result []interface{}
 
 	keyValueMap := map[string]interface{}{
-		"code":     appMessageFormat.Code,
-		"details":  appMessageFormat.Details,
-		"duration": appMessageFormat.Duration,
-		"errors":   appMessageFormat.Errors,
-		"id":       appMessageFormat.ID,
-		"level":    appMessageFormat.Level,
-		"location": appMessageFormat.Location,
-		"reason":   appMessageFormat.Reason,
-		"status":   appMessageFormat.Status,
-		"time":     appMessageFormat.Time,
+		"caller":      appMessageFormat.Caller,
+		"code":        appMessageFormat.Code,
+		"details":     appMessageFormat.Details,
+		"duration":    appMessageFormat.Duration,
+		"errors":      appMessageFormat.Errors,
+		"help":        appMessageFormat.Help,
+		"id":          appMessageFormat.ID,
+		"level":       appMessageFormat.Level,
+		"location":    appMessageFormat.Location,
+		"reason":      appMessageFormat.Reason,
+		"remediation": appMessageFormat.Remediation,
+		"stack":       appMessageFormat.Stack,
+		"status":      appMessageFormat.Status,
+		"time":        appMessageFormat.Time,
 	}
 
 	// In key order, append values to result.
@@ -244,6 +292,18 @@ func (messenger *BasicMessenger) getKeyValuePairs(appMessageFormat *MessageForma
 			if typedValue != 0 {
 				result = append(result, key, value)
 			}
+		case []string:
+			if len(typedValue) > 0 {
+				result = append(result, key, value)
+			}
+		case []Frame:
+			if len(typedValue) > 0 {
+				result = append(result, key, value)
+			}
+		case *Frame:
+			if typedValue != nil {
+				result = append(result, key, frameAsGroup(typedValue))
+			}
 		default:
 			if typedValue != nil {
 				result = append(result, key, value)
@@ -256,52 +316,33 @@ func (messenger *BasicMessenger) getKeyValuePairs(appMessageFormat *MessageForma
 
 // Given a message number, figure out the Level (TRACE, DEBUG, ..., FATAL, PANIC).
 func (messenger *BasicMessenger) getLevel(messageNumber int) string {
-	sortedMessageLevelKeys := messenger.getSortedIDLevelRanges(IDLevelRangesAsString)
-	for _, messageLevelKey := range sortedMessageLevelKeys {
-		if messageNumber >= messageLevelKey {
-			return IDLevelRangesAsString[messageLevelKey]
-		}
-	}
-
-	return "UNKNOWN"
+	return levelOfMessageNumber(messageNumber, messenger.getSortedIDLevelRanges(IDLevelRangesAsString))
 }
 
 // Since a map[int]any is not guaranteed to be in order, return an ordered slice of int.
 func (messenger *BasicMessenger) getSortedIDLevelRanges(idLevelRanges map[int]string) []int {
-	if messenger.sortedIDLevelRanges == nil {
+	messenger.sortedIDLevelRangesOnce.Do(func() {
 		messenger.sortedIDLevelRanges = make([]int, 0, len(idLevelRanges))
 		for key := range idLevelRanges {
 			messenger.sortedIDLevelRanges = append(messenger.sortedIDLevelRanges, key)
 		}
 
 		sort.Sort(sort.Reverse(sort.IntSlice(messenger.sortedIDLevelRanges)))
-	}
+	})
 
 	return messenger.sortedIDLevelRanges
 }
 
-func (messenger *BasicMessenger) populateMessageFields(senzingMessageFields string) {
-	switch {
-	case len(senzingMessageFields) == 0:
-		messenger.messageFields = []string{"id", "text"}
-	case senzingMessageFields == "all":
-		messenger.messageFields = AllMessageFields
-	default:
-		messenger.messageFields = []string{}
+// Create a populated MessageFormat, with what MessageLimits truncated from it.
+func (messenger *BasicMessenger) populateStructure(
+	messageNumber int,
+	details ...interface{},
+) (*MessageFormat, *messageTruncation) {
+	actualFields := &theFields{}
 
-		messageSplits := strings.Split(senzingMessageFields, ",")
-		for _, value := range messageSplits {
-			valueTrimmed := strings.TrimSpace(value)
-			if slices.Contains(AllMessageFields, valueTrimmed) {
-				messenger.messageFields = append(messenger.messageFields, valueTrimmed)
-			}
-		}
-	}
-}
+	// Redact whole details before they are used in "text", "errors", and "details".
 
-// Create a populated MessageFormat.
-func (messenger *BasicMessenger) populateStructure(messageNumber int, details ...interface{}) *MessageFormat {
-	actualFields := &theFields{}
+	details = messenger.redactDetails(details)
 
 	// Calculate fields.
 
@@ -309,12 +350,20 @@ func (messenger *BasicMessenger) populateStructure(messageNumber int, details ..
 	actualFields.callerSkip = messenger.callerSkip
 	actualFields.level = messenger.getLevel(messageNumber)
 	actualFields.id = fmt.Sprintf(messenger.messageIDTemplate, messageNumber)
+	templateID := actualFields.id
+
+	if _, ruleLevel := messenger.matchRules(messageNumber, templateID); len(ruleLevel) > 0 {
+		actualFields.level = ruleLevel
+	}
+
 	statusCandidate, isOK := messenger.idStatuses[messageNumber]
 
 	if isOK {
 		actualFields.status = statusCandidate
 	}
 
+	actualFields.remediation = messenger.idRemediations[messageNumber]
+
 	// Construct "text".
 
 	textTemplate, isOK := messenger.idMessages[messageNumber]
@@ -332,44 +381,47 @@ func (messenger *BasicMessenger) populateStructure(messageNumber int, details ..
 
 	parseDetails(actualFields, details)
 
-	// Calculate field - location.
+	// Calculate field - help.
+
+	if len(actualFields.help) == 0 && len(messenger.helpURLTemplate) > 0 {
+		actualFields.help = fmt.Sprintf(messenger.helpURLTemplate, actualFields.id)
+	}
+
+	// Calculate fields - location and caller.
 	// See https://pkg.go.dev/runtime#Caller
 
-	if actualFields.callerSkip > 0 {
-		pc, file, line, ok := runtime.Caller(actualFields.callerSkip)
-		if ok {
-			callingFunction := runtime.FuncForPC(pc)
-			runtimeFunc := regexp.MustCompile(`^.*\.(.*)$`)
-			functionName := runtimeFunc.ReplaceAllString(callingFunction.Name(), "$1")
-			filename := filepath.Base(file)
-			actualFields.location = fmt.Sprintf("In %s() at %s:%d", functionName, filename, line)
+	if actualFields.callerSkip > 0 || actualFields.callerSkip == CallerSkipAuto {
+		actualFields.caller = messenger.captureCaller(actualFields.callerSkip)
+		if actualFields.caller != nil {
+			actualFields.location = formatLocation(actualFields.caller)
 		}
 	}
 
 	// Determine fields to print.
 
-	messageFields := messenger.findMessageFields(details...)
+	messageFields := messenger.findMessageFields(messageNumber, templateID, details...)
+
+	// Calculate field - stack.
+
+	if messenger.stackTrace != nil && slices.Contains(messageFields, "stack") {
+		actualFields.stack = messenger.captureStack(actualFields.level, actualFields.filteredDetails)
+	}
 
-	return populateMessageFormat(actualFields, messageFields)
+	result := populateMessageFormat(actualFields, messageFields, messenger.detailFormatters)
+	messenger.redactMessageFormat(result, actualFields.filteredDetails)
+	truncation := messenger.limitMessageFormat(result)
+
+	return result, truncation
 }
 
 // ----------------------------------------------------------------------------
 // Private functions
 // ----------------------------------------------------------------------------
 
-func createBooleanDetail(position int32, value bool) Detail {
-	return Detail{
-		Position: position,
-		Type:     "boolean",
-		Value:    interfaceAsString(value),
-		ValueRaw: value,
-	}
-}
-
 func createErrorDetail(position int32, value error) Detail {
 	result := Detail{
 		Position: position,
-		Type:     "error",
+		Type:     DetailTypeError,
 		Value:    cleanErrorString(value),
 	}
 	if isJSON(result.Value) {
@@ -379,35 +431,10 @@ func createErrorDetail(position int32, value error) Detail {
 	return result
 }
 
-func createFloat64Detail(position int32, value float64) Detail {
-	return Detail{
-		Position: position,
-		Type:     "float",
-		Value:    interfaceAsString(value),
-		ValueRaw: value,
-	}
-}
-
-func createIntDetail(position int32, value int) Detail {
-	return Detail{
-		Position: position,
-		Type:     "integer",
-		Value:    interfaceAsString(value),
-		ValueRaw: value,
-	}
-}
-
-func createNilDetail(position int32) Detail {
-	return Detail{
-		Position: position,
-		Type:     "nil",
-	}
-}
-
 func createStringDetail(position int32, value string) Detail {
 	result := Detail{
 		Position: position,
-		Type:     "string",
+		Type:     DetailTypeString,
 		Value:    value,
 	}
 	if isJSON(value) {
@@ -424,6 +451,8 @@ func parseDetails(actualFields *theFields, details []interface{}) {
 			actualFields.code = typedValue.Value
 		case MessageDuration:
 			actualFields.duration = typedValue.Value
+		case MessageHelp:
+			actualFields.help = typedValue.Value
 		case MessageID:
 			actualFields.id = typedValue.Value
 		case MessageLevel:
@@ -432,6 +461,8 @@ func parseDetails(actualFields *theFields, details []interface{}) {
 			actualFields.location = typedValue.Value
 		case MessageReason:
 			actualFields.reason = typedValue.Value
+		case MessageRemediation:
+			actualFields.remediation = typedValue.Value
 		case MessageStatus:
 			actualFields.status = typedValue.Value
 		case MessageText:
@@ -451,15 +482,19 @@ func parseDetails(actualFields *theFields, details []interface{}) {
 	}
 }
 
-func populateMessageFormat(actualFields *theFields, messageFields []string) *MessageFormat {
+func populateMessageFormat(actualFields *theFields, messageFields []string, detailFormatters *DetailFormatters) *MessageFormat {
 	result := &MessageFormat{}
+	if slices.Contains(messageFields, "caller") {
+		result.Caller = actualFields.caller
+	}
+
 	if slices.Contains(messageFields, "code") {
 		result.Code = actualFields.code
 	}
 
 	if slices.Contains(messageFields, "details") {
 		if len(actualFields.filteredDetails) > 0 {
-			result.Details = messageDetails(actualFields.filteredDetails...)
+			result.Details = messageDetails(detailFormatters, actualFields.filteredDetails...)
 		}
 	}
 
@@ -473,6 +508,10 @@ func populateMessageFormat(actualFields *theFields, messageFields []string) *Mes
 		}
 	}
 
+	if slices.Contains(messageFields, "help") {
+		result.Help = actualFields.help
+	}
+
 	if slices.Contains(messageFields, "id") {
 		result.ID = actualFields.id
 	}
@@ -489,6 +528,14 @@ func populateMessageFormat(actualFields *theFields, messageFields []string) *Mes
 		result.Reason = actualFields.reason
 	}
 
+	if slices.Contains(messageFields, "remediation") {
+		result.Remediation = actualFields.remediation
+	}
+
+	if slices.Contains(messageFields, "stack") {
+		result.Stack = actualFields.stack
+	}
+
 	if slices.Contains(messageFields, "status") {
 		result.Status = actualFields.status
 	}
@@ -504,6 +551,38 @@ func populateMessageFormat(actualFields *theFields, messageFields []string) *Mes
 	return result
 }
 
+// Construct the JSON representation of a message.
+func marshalMessageFormat(messageFormat *MessageFormat) (string, error) {
+	// Would love to do it this way, but HTML escaping happens.
+	// Reported in https://github.com/golang/go/issues/56630
+	// result, _ := json.Marshal(messageBuilder)
+	// return string(result), err
+
+	// Work-around.
+
+	var resultBytes bytes.Buffer
+
+	enc := json.NewEncoder(&resultBytes)
+	enc.SetEscapeHTML(false)
+
+	err := enc.Encode(messageFormat)
+	if err != nil {
+		return "", fmt.Errorf("messenger.marshalMessageFormat error: %w", err)
+	}
+
+	return strings.TrimSpace(resultBytes.String()), nil
+}
+
+// Represent a frame as a slog group, which handlers render as separate fields.
+func frameAsGroup(frame *Frame) slog.Value {
+	return slog.GroupValue(
+		slog.String("package", frame.Package),
+		slog.String("function", frame.Function),
+		slog.String("file", frame.File),
+		slog.Int("line", int(frame.Line)),
+	)
+}
+
 // Strip \t and \n from string.
 func cleanTabsAndNewlines(unknownString string) string {
 	result := unknownString
@@ -526,6 +605,11 @@ func isJSON(unknownString string) bool {
 	return json.Unmarshal([]byte(unknownStringUnescaped), &jsonRawMessage) == nil
 }
 
+// Determine if a field is one of AllMessageFields or NamedMessageFields.
+func isMessageField(field string) bool {
+	return slices.Contains(AllMessageFields, field) || slices.Contains(NamedMessageFields, field)
+}
+
 // Cast JSON string into an interface{}.
 func jsonAsInterface(unknownString string) interface{} {
 	unknownStringUnescaped := cleanTabsAndNewlines(unknownString)
@@ -570,27 +654,19 @@ func interfaceAsString(unknown interface{}) string {
 }
 
 // Walk through the details to improve their future JSON representation.
-func messageDetails(details ...interface{}) []Detail {
+func messageDetails(detailFormatters *DetailFormatters, details ...interface{}) []Detail {
 	result := []Detail{}
 
+	if detailFormatters == nil {
+		detailFormatters = defaultDetailFormatters
+	}
+
 	// Process different types of details.
 
 	for index, value := range details {
 		detailPosition := int32(index + 1)
 
 		switch typedValue := value.(type) {
-		case nil:
-			result = append(result, createNilDetail(detailPosition))
-		case int:
-			result = append(result, createIntDetail(detailPosition, typedValue))
-		case float64:
-			result = append(result, createFloat64Detail(detailPosition, typedValue))
-		case string:
-			result = append(result, createStringDetail(detailPosition, typedValue))
-		case bool:
-			result = append(result, createBooleanDetail(detailPosition, typedValue))
-		case error:
-			result = append(result, createErrorDetail(detailPosition, typedValue))
 		case map[string]string:
 			for mapIndex, mapValue := range typedValue {
 				detail := Detail{
@@ -603,6 +679,19 @@ func messageDetails(details ...interface{}) []Detail {
 					detail.ValueRaw = jsonAsInterface(detail.Value)
 				}
 
+				result = append(result, detail)
+			}
+		case redactedValue:
+			if !typedValue.dropped {
+				detail := Detail{
+					Position: detailPosition,
+					Type:     typedValue.detailType,
+					Value:    typedValue.text,
+				}
+				if typedValue.raw != nil {
+					detail.ValueRaw = typedValue.raw
+				}
+
 				result = append(result, detail)
 			}
 		case OptionMessageField:
@@ -610,12 +699,8 @@ func messageDetails(details ...interface{}) []Detail {
 		case OptionMessageFields:
 			// Do nothing.
 		default:
-			detail := Detail{
-				Position: detailPosition,
-				Type:     fmt.Sprintf("%+v", reflect.TypeOf(value)),
-				Value:    interfaceAsString(typedValue),
-				ValueRaw: typedValue,
-			}
+			detail := detailFormatters.Format(typedValue)
+			detail.Position = detailPosition
 			result = append(result, detail)
 		}
 	}
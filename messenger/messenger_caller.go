@@ -0,0 +1,95 @@
+package messenger
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Maximum number of frames searched for the caller when using CallerSkipAuto.
+const maxCallerDepth = 64
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+/*
+The captureCaller method finds where a message was requested.
+
+Input
+  - callerSkip: A positive number of frames to skip above populateStructure(), as with runtime.Caller(),
+    or CallerSkipAuto to skip frames of "runtime", this package, and OptionCallerWrappers.
+
+Output
+  - The location, or nil if it cannot be determined.
+*/
+func (messenger *BasicMessenger) captureCaller(callerSkip int) *Frame {
+	var programCounters []uintptr
+
+	if callerSkip > 0 {
+		// One more frame, for this method.
+		programCounters = make([]uintptr, 1)
+		programCounters = programCounters[:runtime.Callers(callerSkip+2, programCounters)]
+	} else {
+		programCounters = make([]uintptr, maxCallerDepth)
+		programCounters = programCounters[:runtime.Callers(2, programCounters)]
+	}
+
+	frames := runtime.CallersFrames(programCounters)
+
+	for {
+		frame, more := frames.Next()
+		if len(frame.Function) == 0 {
+			return nil
+		}
+
+		packageName, functionName := splitFunctionName(frame.Function)
+
+		if callerSkip > 0 || !messenger.isCallerWrapper(packageName, frame.Function) {
+			return &Frame{
+				File:     frame.File,
+				Function: functionName,
+				Line:     int32(frame.Line), //nolint:gosec
+				Package:  packageName,
+			}
+		}
+
+		if !more {
+			return nil
+		}
+	}
+}
+
+// Determine if a frame belongs to "runtime", this package, or one of the OptionCallerWrappers.
+func (messenger *BasicMessenger) isCallerWrapper(packageName string, fullFunctionName string) bool {
+	if len(packageName) == 0 || packageName == "runtime" || packageName == messengerPackage {
+		return true
+	}
+
+	for _, wrapper := range messenger.callerWrappers {
+		switch {
+		case packageName == wrapper, strings.HasPrefix(packageName, wrapper+"/"):
+			return true
+		case fullFunctionName == wrapper, strings.HasPrefix(fullFunctionName, wrapper+"."):
+			return true
+		}
+	}
+
+	return false
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Format a frame as the legacy "location" string, e.g. "In Method() at file.go:123".
+func formatLocation(frame *Frame) string {
+	functionName := frame.Function[strings.LastIndex(frame.Function, ".")+1:]
+
+	return "In " + functionName + "() at " + filepath.Base(frame.File) + ":" + strconv.Itoa(int(frame.Line))
+}
@@ -0,0 +1,180 @@
+package messenger_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+var testCasesForCaller = []struct {
+	name             string
+	options          []interface{}
+	details          []interface{}
+	wrapped          bool
+	expectedFunction string
+	expectedLocation string
+}{
+	{
+		name:             "caller-0001",
+		options:          []interface{}{messenger.OptionCallerSkip{Value: messenger.CallerSkipAuto}},
+		expectedFunction: "TestBasicMessenger_NewJSON_caller.func1",
+		expectedLocation: "In func1() at messenger_caller_test.go:",
+	},
+	{
+		name: "caller-0002",
+		options: []interface{}{
+			messenger.OptionCallerSkip{Value: messenger.CallerSkipAuto},
+			messenger.OptionCallerWrappers{Value: []string{testPackage + ".logWrapper"}},
+		},
+		wrapped:          true,
+		expectedFunction: "TestBasicMessenger_NewJSON_caller.func1",
+		expectedLocation: "In func1() at messenger_caller_test.go:",
+	},
+	{
+		name:             "caller-0003",
+		options:          []interface{}{messenger.OptionCallerSkip{Value: messenger.CallerSkipAuto}},
+		wrapped:          true,
+		expectedFunction: "logWrapper",
+		expectedLocation: "In logWrapper() at messenger_caller_test.go:",
+	},
+	{
+		name:             "caller-0004",
+		options:          []interface{}{getOptionCallerSkip()},
+		expectedFunction: "TestBasicMessenger_NewJSON_caller.func1",
+		expectedLocation: "In func1() at messenger_caller_test.go:",
+	},
+	{
+		name:             "caller-0005",
+		details:          []interface{}{messenger.OptionCallerSkip{Value: messenger.CallerSkipAuto}},
+		expectedFunction: "TestBasicMessenger_NewJSON_caller.func1",
+		expectedLocation: "In func1() at messenger_caller_test.go:",
+	},
+	{
+		name: "caller-0006",
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicMessenger_NewJSON_caller(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForCaller {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			options := append([]interface{}{
+				messenger.OptionMessageFields{Value: []string{"id", "location", "caller"}},
+			}, testCase.options...)
+			testObject, err := messenger.New(options...)
+			require.NoError(test, err)
+
+			var message string
+			if testCase.wrapped {
+				message = logWrapper(testObject, testCase.details...)
+			} else {
+				message = testObject.NewJSON(2001, testCase.details...)
+			}
+
+			messageFormat := &messenger.MessageFormat{}
+			require.NoError(test, json.Unmarshal([]byte(message), messageFormat))
+
+			if len(testCase.expectedFunction) == 0 {
+				assert.Nil(test, messageFormat.Caller)
+				assert.Empty(test, messageFormat.Location)
+
+				return
+			}
+
+			require.NotNil(test, messageFormat.Caller)
+			assert.Equal(test, testCase.expectedFunction, messageFormat.Caller.Function)
+			assert.Equal(test, testPackage, messageFormat.Caller.Package)
+			assert.Contains(test, messageFormat.Caller.File, "messenger_caller_test.go")
+			assert.Positive(test, messageFormat.Caller.Line)
+			assert.Equal(test, testCase.expectedLocation, messageFormat.Location[:len(testCase.expectedLocation)])
+		})
+	}
+}
+
+func TestBasicMessenger_NewSlog_caller(test *testing.T) {
+	test.Parallel()
+
+	testObject, err := messenger.New(
+		messenger.OptionMessageFields{Value: []string{"id", "caller"}},
+		messenger.OptionCallerSkip{Value: messenger.CallerSkipAuto},
+	)
+	require.NoError(test, err)
+
+	_, keyValuePairs := testObject.NewSlog(2001)
+	require.Len(test, keyValuePairs, 4)
+	assert.Equal(test, "caller", keyValuePairs[2])
+
+	group, isValue := keyValuePairs[3].(slog.Value)
+	require.True(test, isValue)
+	require.Equal(test, slog.KindGroup, group.Kind())
+
+	attributes := map[string]slog.Value{}
+	for _, attribute := range group.Group() {
+		attributes[attribute.Key] = attribute.Value
+	}
+
+	assert.Equal(test, testPackage, attributes["package"].String())
+	assert.Equal(test, "TestBasicMessenger_NewSlog_caller", attributes["function"].String())
+	assert.Contains(test, attributes["file"].String(), "messenger_caller_test.go")
+	assert.Positive(test, attributes["line"].Int64())
+}
+
+func TestBasicMessenger_NewError_caller(test *testing.T) {
+	test.Parallel()
+
+	for _, callerSkip := range []int{messenger.CallerSkipAuto, 2} {
+		jsonMessenger, err := messenger.New(
+			messenger.OptionMessageFields{Value: []string{"location", "caller"}},
+			messenger.OptionCallerSkip{Value: callerSkip},
+		)
+		require.NoError(test, err)
+
+		// NewError() populates the message one frame deeper than NewJSON(), as it did by calling NewJSON().
+
+		errorSkip := callerSkip
+		if callerSkip > 0 {
+			errorSkip++
+		}
+
+		errorMessenger, err := messenger.New(
+			messenger.OptionMessageFields{Value: []string{"location", "caller"}},
+			messenger.OptionCallerSkip{Value: errorSkip},
+		)
+		require.NoError(test, err)
+
+		// Both on one line, so that they have the same location.
+
+		jsonMessage, errorMessage := jsonMessenger.NewJSON(2001), errorMessenger.NewError(2001).Error()
+
+		jsonFormat := &messenger.MessageFormat{}
+		require.NoError(test, json.Unmarshal([]byte(jsonMessage), jsonFormat))
+
+		errorFormat := &messenger.MessageFormat{}
+		require.NoError(test, json.Unmarshal([]byte(errorMessage), errorFormat))
+
+		require.NotNil(test, jsonFormat.Caller)
+		assert.Equal(test, "TestBasicMessenger_NewError_caller", jsonFormat.Caller.Function)
+		assert.Equal(test, jsonFormat.Caller, errorFormat.Caller)
+		assert.Equal(test, jsonFormat.Location, errorFormat.Location)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions - names begin with lowercase letter
+// ----------------------------------------------------------------------------
+
+// A logging function that wraps the messenger.
+func logWrapper(testObject messenger.Messenger, details ...interface{}) string {
+	return testObject.NewJSON(2001, details...)
+}
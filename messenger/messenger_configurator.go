@@ -0,0 +1,446 @@
+package messenger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A Configurator changes the fields and minimum levels of messengers while they are in use.
+// It is safe for concurrent use, so one Configurator may be shared by several messengers,
+// signal handlers such as ConfigureOnSignal(), and its Handler().
+type Configurator struct {
+	configuration Configuration
+	mutex         sync.RWMutex
+}
+
+// An http.Handler for viewing and changing a Configurator.
+type configuratorHandler struct {
+	configurator *Configurator
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Value of MessageFields for all fields.
+const allFieldsName = "all"
+
+// Largest request body accepted by Configurator.Handler().
+const maxConfigurationBytes = 1 << 20
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewConfigurator function creates a Configurator.
+
+Input
+  - configuration: The initial configuration.  A zero Configuration leaves messengers unchanged.
+
+Output
+  - A Configurator to be used with OptionConfigurator.
+  - An error if a field or level name is unknown.
+*/
+func NewConfigurator(configuration Configuration) (*Configurator, error) {
+	result := &Configurator{}
+
+	err := result.Configure(configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Configuration method returns a copy of the current configuration.
+*/
+func (configurator *Configurator) Configuration() Configuration {
+	configurator.mutex.RLock()
+	defer configurator.mutex.RUnlock()
+
+	return copyConfiguration(configurator.configuration)
+}
+
+/*
+The Configure method replaces the whole configuration.
+
+Input
+  - configuration: The new configuration.
+
+Output
+  - An error if a field or level name is unknown, in which case the configuration is unchanged.
+*/
+func (configurator *Configurator) Configure(configuration Configuration) error {
+	normalized, err := normalizeConfiguration(configuration)
+	if err != nil {
+		return fmt.Errorf("messenger.Configurator error: %w", err)
+	}
+
+	configurator.mutex.Lock()
+	defer configurator.mutex.Unlock()
+
+	configurator.configuration = normalized
+
+	return nil
+}
+
+/*
+The ConfigureOnSignal method replaces the whole configuration with a newly loaded one each time a signal is received,
+until ctx is done.  For example, load may read a file so that editing it and sending SIGHUP reconfigures a service.
+
+Input
+  - ctx: When done, signals are no longer handled.
+  - load: Returns the new configuration.  On an error, the configuration is unchanged.
+  - signals: The signals to handle.  If none, syscall.SIGHUP.
+
+Output
+  - A channel receiving the error, or nil, of each reload.  It is closed when ctx is done.
+    Results are dropped while an earlier one has not been received, so the channel may be ignored.
+*/
+func (configurator *Configurator) ConfigureOnSignal(
+	ctx context.Context,
+	load func() (Configuration, error),
+	signals ...os.Signal,
+) <-chan error {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+
+	received := make(chan os.Signal, 1)
+	results := make(chan error, 1)
+
+	signal.Notify(received, signals...)
+
+	go func() {
+		defer close(results)
+		defer signal.Stop(received)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-received:
+				configuration, err := load()
+				if err != nil {
+					err = fmt.Errorf("messenger.Configurator error: %w", err)
+				} else {
+					err = configurator.Configure(configuration)
+				}
+
+				select {
+				case results <- err:
+				default:
+				}
+			}
+		}
+	}()
+
+	return results
+}
+
+/*
+The DeleteIDOverride method removes the settings of a message id.
+
+Input
+  - id: A message id, such as "SZSDK99992001".
+*/
+func (configurator *Configurator) DeleteIDOverride(id string) {
+	configurator.mutex.Lock()
+	defer configurator.mutex.Unlock()
+
+	delete(configurator.configuration.IDOverrides, id)
+}
+
+/*
+The Handler method returns an http.Handler for a local admin endpoint.
+GET returns the configuration as JSON.
+PUT replaces the configuration with the JSON request body.
+PATCH changes only the members of the JSON request body; an "idOverrides" member of null removes that id's settings.
+PUT and PATCH return the resulting configuration.
+
+Output
+  - An http.Handler.  It has no authentication, so serve it only on a trusted interface.
+*/
+func (configurator *Configurator) Handler() http.Handler {
+	return &configuratorHandler{configurator: configurator}
+}
+
+/*
+The SetIDOverride method sets the fields and minimum level of one message id.
+
+Input
+  - id: A message id, such as "SZSDK99992001".
+  - idConfiguration: The settings of the message id.  A zero value removes the settings.
+
+Output
+  - An error if a field or level name is unknown.
+*/
+func (configurator *Configurator) SetIDOverride(id string, idConfiguration IDConfiguration) error {
+	return configurator.update(func(configuration *Configuration) error {
+		if configuration.IDOverrides == nil {
+			configuration.IDOverrides = map[string]IDConfiguration{}
+		}
+
+		configuration.IDOverrides[id] = idConfiguration
+
+		return nil
+	})
+}
+
+/*
+The SetMessageFields method sets the fields of all messages.
+
+Input
+  - messageFields: Fields, as in OptionMessageFields, or "all".  None restores the messengers' own fields.
+
+Output
+  - An error if a field name is unknown.
+*/
+func (configurator *Configurator) SetMessageFields(messageFields ...string) error {
+	return configurator.update(func(configuration *Configuration) error {
+		configuration.MessageFields = messageFields
+
+		return nil
+	})
+}
+
+/*
+The SetMinimumLevel method sets the minimum level of all messages.
+
+Input
+  - level: A level name, such as LevelWarnName.  An empty string restores the messengers' own minimum level.
+
+Output
+  - An error if the level name is unknown.
+*/
+func (configurator *Configurator) SetMinimumLevel(level string) error {
+	return configurator.update(func(configuration *Configuration) error {
+		configuration.MinimumLevel = level
+
+		return nil
+	})
+}
+
+// ServeHTTP implements http.Handler.
+func (handler *configuratorHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPatch, http.MethodPut:
+		// Read the body before locking, then decode it over the current configuration under one lock,
+		// so that concurrent PATCH requests do not lose each other's changes.
+
+		body, err := io.ReadAll(http.MaxBytesReader(writer, request.Body, maxConfigurationBytes))
+		if err == nil {
+			err = handler.configurator.update(func(configuration *Configuration) error {
+				if request.Method == http.MethodPut {
+					*configuration = Configuration{}
+				}
+
+				decoder := json.NewDecoder(bytes.NewReader(body))
+				decoder.DisallowUnknownFields()
+
+				return decoder.Decode(configuration)
+			})
+		}
+
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+	default:
+		writer.Header().Set("Allow", "GET, HEAD, PATCH, PUT")
+		http.Error(writer, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	response, err := json.Marshal(handler.configurator.Configuration())
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	_, _ = writer.Write(append(response, '\n'))
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// The fields of messages having the id, or nil if not configured.  A nil Configurator configures nothing.
+func (configurator *Configurator) messageFields(id string) []string {
+	if configurator == nil {
+		return nil
+	}
+
+	configurator.mutex.RLock()
+	defer configurator.mutex.RUnlock()
+
+	if idConfiguration, isOverridden := configurator.configuration.IDOverrides[id]; isOverridden && idConfiguration.MessageFields != nil {
+		return idConfiguration.MessageFields
+	}
+
+	return configurator.configuration.MessageFields
+}
+
+// The minimum level of messages having the id, and whether it is configured.
+func (configurator *Configurator) minimumLevel(id string) (slog.Level, bool) {
+	configurator.mutex.RLock()
+	defer configurator.mutex.RUnlock()
+
+	levelName := configurator.configuration.MinimumLevel
+	if idConfiguration, isOverridden := configurator.configuration.IDOverrides[id]; isOverridden && len(idConfiguration.MinimumLevel) > 0 {
+		levelName = idConfiguration.MinimumLevel
+	}
+
+	if len(levelName) == 0 {
+		return 0, false
+	}
+
+	return TextToLevelMap[levelName], true
+}
+
+// Change a copy of the configuration and, if the change succeeds and the result is valid, use it.
+func (configurator *Configurator) update(change func(configuration *Configuration) error) error {
+	configurator.mutex.Lock()
+	defer configurator.mutex.Unlock()
+
+	configuration := copyConfiguration(configurator.configuration)
+
+	err := change(&configuration)
+	if err != nil {
+		return err
+	}
+
+	normalized, err := normalizeConfiguration(configuration)
+	if err != nil {
+		return fmt.Errorf("messenger.Configurator error: %w", err)
+	}
+
+	configurator.configuration = normalized
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func copyConfiguration(configuration Configuration) Configuration {
+	result := Configuration{
+		MessageFields: slices.Clone(configuration.MessageFields),
+		MinimumLevel:  configuration.MinimumLevel,
+	}
+
+	if configuration.IDOverrides != nil {
+		result.IDOverrides = make(map[string]IDConfiguration, len(configuration.IDOverrides))
+		for id, idConfiguration := range configuration.IDOverrides {
+			idConfiguration.MessageFields = slices.Clone(idConfiguration.MessageFields)
+			result.IDOverrides[id] = idConfiguration
+		}
+	}
+
+	return result
+}
+
+// Validate a configuration, expanding "all", upper-casing levels, and dropping empty id settings.
+func normalizeConfiguration(configuration Configuration) (Configuration, error) {
+	var err error
+
+	result := Configuration{}
+
+	result.MessageFields, err = normalizeMessageFields(configuration.MessageFields)
+	if err != nil {
+		return result, err
+	}
+
+	result.MinimumLevel, err = normalizeLevel(configuration.MinimumLevel)
+	if err != nil {
+		return result, err
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(configuration.IDOverrides)) {
+		idConfiguration := IDConfiguration{}
+
+		idConfiguration.MessageFields, err = normalizeMessageFields(configuration.IDOverrides[id].MessageFields)
+		if err != nil {
+			return result, fmt.Errorf("%s: %w", id, err)
+		}
+
+		idConfiguration.MinimumLevel, err = normalizeLevel(configuration.IDOverrides[id].MinimumLevel)
+		if err != nil {
+			return result, fmt.Errorf("%s: %w", id, err)
+		}
+
+		if idConfiguration.MessageFields == nil && len(idConfiguration.MinimumLevel) == 0 {
+			continue
+		}
+
+		if result.IDOverrides == nil {
+			result.IDOverrides = map[string]IDConfiguration{}
+		}
+
+		result.IDOverrides[id] = idConfiguration
+	}
+
+	return result, nil
+}
+
+func normalizeLevel(level string) (string, error) {
+	result := strings.ToUpper(strings.TrimSpace(level))
+	if _, isKnown := TextToLevelMap[result]; len(result) > 0 && !isKnown {
+		return "", fmt.Errorf("level %q: %w", level, ErrInvalidConfiguration)
+	}
+
+	return result, nil
+}
+
+// Validate fields, returning nil for none and AllMessageFields for "all".
+func normalizeMessageFields(messageFields []string) ([]string, error) {
+	if len(messageFields) == 0 {
+		return nil, nil
+	}
+
+	result := make([]string, 0, len(messageFields))
+
+	for _, field := range messageFields {
+		field = strings.ToLower(strings.TrimSpace(field))
+
+		switch {
+		case field == allFieldsName:
+			return slices.Clone(AllMessageFields), nil
+		case isMessageField(field):
+			result = append(result, field)
+		default:
+			return nil, fmt.Errorf("field %q: %w", field, ErrInvalidConfiguration)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,354 @@
+package messenger_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCasesForConfigurator = []struct {
+	name          string
+	configuration messenger.Configuration
+	messageNumber int
+	details       []interface{}
+	expectedJSON  string
+}{
+	{
+		name:          "configurator-0001",
+		messageNumber: 2001,
+		expectedJSON:  `{"level":"INFO","id":"SZSDK99992001","text":"INFO: Bob works with Jane","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"}]}`,
+	},
+	{
+		name:          "configurator-0002",
+		configuration: messenger.Configuration{MessageFields: []string{"ID", " text"}},
+		messageNumber: 2001,
+		expectedJSON:  `{"id":"SZSDK99992001","text":"INFO: Bob works with Jane"}`,
+	},
+	{
+		name: "configurator-0003",
+		configuration: messenger.Configuration{
+			MessageFields: []string{"id"},
+			IDOverrides:   map[string]messenger.IDConfiguration{"SZSDK99992001": {MessageFields: []string{"id", "status"}}},
+		},
+		messageNumber: 2001,
+		details:       []interface{}{getMessageStatus()},
+		expectedJSON:  `{"id":"SZSDK99992001","status":"TestStatus"}`,
+	},
+	{
+		name:          "configurator-0004",
+		configuration: messenger.Configuration{MessageFields: []string{"id"}},
+		messageNumber: 2001,
+		details:       []interface{}{messenger.OptionMessageFields{Value: []string{"text"}}},
+		expectedJSON:  `{"text":"INFO: Bob works with Jane"}`,
+	},
+	{
+		name:          "configurator-0005",
+		configuration: messenger.Configuration{MinimumLevel: "warn"},
+		messageNumber: 2001,
+		expectedJSON:  "",
+	},
+	{
+		name: "configurator-0006",
+		configuration: messenger.Configuration{
+			MinimumLevel: messenger.LevelWarnName,
+			IDOverrides:  map[string]messenger.IDConfiguration{"SZSDK99991001": {MinimumLevel: messenger.LevelTraceName}},
+		},
+		messageNumber: 1001,
+		expectedJSON:  `{"level":"DEBUG","id":"SZSDK99991001","text":"DEBUG: Bob works with Jane","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"}]}`,
+	},
+	{
+		name:          "configurator-0007",
+		configuration: messenger.Configuration{MinimumLevel: messenger.LevelDebugName},
+		messageNumber: 1001,
+		expectedJSON:  `{"level":"DEBUG","id":"SZSDK99991001","text":"DEBUG: Bob works with Jane","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"}]}`,
+	},
+}
+
+var testCasesForConfiguratorHandler = []struct {
+	name           string
+	method         string
+	body           string
+	expectedBody   string
+	expectedStatus int
+}{
+	{
+		name:           "handler-0001",
+		method:         http.MethodGet,
+		expectedBody:   `{"idOverrides":{"SZSDK99992001":{"minimumLevel":"TRACE"}},"messageFields":["id","text"],"minimumLevel":"WARN"}`,
+		expectedStatus: http.StatusOK,
+	},
+	{
+		name:           "handler-0002",
+		method:         http.MethodPatch,
+		body:           `{"minimumLevel":"debug","idOverrides":{"SZSDK99992001":null,"SZSDK99993001":{"messageFields":["all"]}}}`,
+		expectedBody:   `{"idOverrides":{"SZSDK99993001":{"messageFields":["time","level","id","text","code","reason","remediation","help","status","duration","location","errors","details","stack"]}},"messageFields":["id","text"],"minimumLevel":"DEBUG"}`,
+		expectedStatus: http.StatusOK,
+	},
+	{
+		name:           "handler-0003",
+		method:         http.MethodPut,
+		body:           `{"minimumLevel":"ERROR"}`,
+		expectedBody:   `{"minimumLevel":"ERROR"}`,
+		expectedStatus: http.StatusOK,
+	},
+	{
+		name:           "handler-0004",
+		method:         http.MethodPatch,
+		body:           `{"messageFields":["id","bogus"]}`,
+		expectedBody:   "messenger.Configurator error: field \"bogus\": invalid configuration",
+		expectedStatus: http.StatusBadRequest,
+	},
+	{
+		name:           "handler-0005",
+		method:         http.MethodPut,
+		body:           `{"level":"WARN"}`,
+		expectedBody:   `json: unknown field "level"`,
+		expectedStatus: http.StatusBadRequest,
+	},
+	{
+		name:           "handler-0006",
+		method:         http.MethodDelete,
+		expectedBody:   http.StatusText(http.StatusMethodNotAllowed),
+		expectedStatus: http.StatusMethodNotAllowed,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicMessenger_NewJSON_configurator(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForConfigurator {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			configurator, err := messenger.NewConfigurator(testCase.configuration)
+			require.NoError(test, err)
+
+			testObject, err := messenger.New(
+				getOptionMessageIDTemplate(9999),
+				getOptionMessageFields(),
+				getOptionIDMessages(),
+				messenger.OptionConfigurator{Value: configurator},
+			)
+			require.NoError(test, err)
+
+			details := append([]interface{}{"Bob", "Jane"}, testCase.details...)
+			assert.Equal(test, testCase.expectedJSON, testObject.NewJSON(testCase.messageNumber, details...))
+			assert.Equal(test, len(testCase.expectedJSON) > 0, levelEnabled(test, testObject, testCase.messageNumber))
+		})
+	}
+}
+
+func TestConfigurator_Set(test *testing.T) {
+	test.Parallel()
+
+	configurator, err := messenger.NewConfigurator(messenger.Configuration{})
+	require.NoError(test, err)
+
+	testObject, err := messenger.New(getOptionMessageIDTemplate(9999), getOptionIDMessages(), configuratorOption(configurator))
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"id":"SZSDK99992001","text":"INFO: Bob works with Jane"}`, testObject.NewJSON(2001, "Bob", "Jane"))
+
+	require.NoError(test, configurator.SetMessageFields("level", "id"))
+	require.NoError(test, configurator.SetMinimumLevel("info"))
+	assert.Equal(test, `{"level":"INFO","id":"SZSDK99992001"}`, testObject.NewJSON(2001, "Bob", "Jane"))
+	assert.Empty(test, testObject.NewJSON(1001, "Bob", "Jane"))
+
+	require.NoError(test, configurator.SetIDOverride("SZSDK99991001", messenger.IDConfiguration{MinimumLevel: "debug"}))
+	assert.Equal(test, `{"level":"DEBUG","id":"SZSDK99991001"}`, testObject.NewJSON(1001, "Bob", "Jane"))
+
+	idConfiguration := messenger.IDConfiguration{MessageFields: []string{"id", "text"}, MinimumLevel: "debug"}
+	require.NoError(test, configurator.SetIDOverride("SZSDK99991001", idConfiguration))
+
+	message, _, keyValuePairs := testObject.NewSlogLevel(1001, "Bob", "Jane")
+	assert.Equal(test, "DEBUG: Bob works with Jane", message)
+	assert.Equal(test, []interface{}{"id", "SZSDK99991001"}, keyValuePairs)
+
+	configurator.DeleteIDOverride("SZSDK99991001")
+	assert.False(test, levelEnabled(test, testObject, 1001))
+
+	require.NoError(test, configurator.SetMessageFields())
+	require.NoError(test, configurator.SetMinimumLevel(""))
+	assert.Equal(test, messenger.Configuration{}, configurator.Configuration())
+	assert.True(test, levelEnabled(test, testObject, 1001))
+}
+
+func TestConfigurator_Set_invalid(test *testing.T) {
+	test.Parallel()
+
+	_, err := messenger.NewConfigurator(messenger.Configuration{MinimumLevel: "LOUD"})
+	require.ErrorIs(test, err, messenger.ErrInvalidConfiguration)
+
+	configurator, err := messenger.NewConfigurator(messenger.Configuration{MinimumLevel: messenger.LevelWarnName})
+	require.NoError(test, err)
+
+	err = configurator.SetIDOverride("SZSDK99992001", messenger.IDConfiguration{MessageFields: []string{"bogus"}})
+	require.ErrorIs(test, err, messenger.ErrInvalidConfiguration)
+	require.ErrorContains(test, err, "SZSDK99992001")
+	require.ErrorIs(test, configurator.SetMessageFields("id", "bogus"), messenger.ErrInvalidConfiguration)
+	assert.Equal(test, messenger.Configuration{MinimumLevel: messenger.LevelWarnName}, configurator.Configuration())
+}
+
+func TestConfigurator_Configuration_copy(test *testing.T) {
+	test.Parallel()
+
+	configurator, err := messenger.NewConfigurator(messenger.Configuration{MessageFields: []string{"id"}})
+	require.NoError(test, err)
+
+	configuration := configurator.Configuration()
+	configuration.MessageFields[0] = "text"
+
+	assert.Equal(test, []string{"id"}, configurator.Configuration().MessageFields)
+}
+
+func TestConfigurator_Handler(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForConfiguratorHandler {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			configurator, err := messenger.NewConfigurator(messenger.Configuration{
+				IDOverrides:   map[string]messenger.IDConfiguration{"SZSDK99992001": {MinimumLevel: messenger.LevelTraceName}},
+				MessageFields: []string{"id", "text"},
+				MinimumLevel:  messenger.LevelWarnName,
+			})
+			require.NoError(test, err)
+
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(testCase.method, "/messenger", strings.NewReader(testCase.body))
+			configurator.Handler().ServeHTTP(recorder, request)
+
+			assert.Equal(test, testCase.expectedStatus, recorder.Code)
+			assert.Equal(test, testCase.expectedBody, strings.TrimSpace(recorder.Body.String()))
+		})
+	}
+}
+
+func TestConfigurator_ConfigureOnSignal(test *testing.T) {
+	test.Parallel()
+
+	configurator, err := messenger.NewConfigurator(messenger.Configuration{})
+	require.NoError(test, err)
+
+	ctx, cancel := context.WithCancel(test.Context())
+	minimumLevels := make(chan string, 1)
+	results := configurator.ConfigureOnSignal(ctx, func() (messenger.Configuration, error) {
+		return messenger.Configuration{MinimumLevel: <-minimumLevels}, nil
+	})
+
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(test, err)
+
+	if process.Signal(syscall.SIGHUP) != nil {
+		cancel()
+		test.Skip("signals cannot be sent on this platform")
+	}
+
+	minimumLevels <- messenger.LevelErrorName
+
+	require.NoError(test, receiveResult(test, results))
+	assert.Equal(test, messenger.Configuration{MinimumLevel: messenger.LevelErrorName}, configurator.Configuration())
+
+	// An invalid configuration leaves the configuration unchanged.
+
+	require.NoError(test, process.Signal(syscall.SIGHUP))
+	minimumLevels <- "LOUD"
+
+	require.ErrorIs(test, receiveResult(test, results), messenger.ErrInvalidConfiguration)
+	assert.Equal(test, messenger.Configuration{MinimumLevel: messenger.LevelErrorName}, configurator.Configuration())
+
+	cancel()
+
+	for range results {
+	}
+}
+
+func TestConfigurator_concurrent(test *testing.T) {
+	test.Parallel()
+
+	configurator, err := messenger.NewConfigurator(messenger.Configuration{})
+	require.NoError(test, err)
+
+	testObject, err := messenger.New(getOptionIDMessages(), configuratorOption(configurator))
+	require.NoError(test, err)
+
+	var waitGroup sync.WaitGroup
+
+	for index := range 8 {
+		waitGroup.Go(func() {
+			for range 100 {
+				if index%2 == 0 {
+					assert.NoError(test, configurator.SetMessageFields("id", "level"))
+					assert.NoError(test, configurator.SetMinimumLevel(messenger.LevelWarnName))
+				} else {
+					testObject.NewJSON(3001, "Bob", "Jane", messenger.OptionMessageField{Value: "text"})
+					testObject.NewSlogLevel(2001, "Bob", "Jane")
+				}
+			}
+		})
+	}
+
+	waitGroup.Wait()
+}
+
+func TestConfigurator_Handler_concurrentPatch(test *testing.T) {
+	test.Parallel()
+
+	configurator, err := messenger.NewConfigurator(messenger.Configuration{})
+	require.NoError(test, err)
+
+	handler := configurator.Handler()
+
+	var waitGroup sync.WaitGroup
+
+	for index := range 8 {
+		waitGroup.Go(func() {
+			for messageNumber := index * 100; messageNumber < (index+1)*100; messageNumber++ {
+				body := fmt.Sprintf(`{"idOverrides":{"SZSDK9999%04d":{"minimumLevel":"WARN"}}}`, messageNumber)
+				recorder := httptest.NewRecorder()
+				handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPatch, "/messenger", strings.NewReader(body)))
+				assert.Equal(test, http.StatusOK, recorder.Code)
+			}
+		})
+	}
+
+	waitGroup.Wait()
+	assert.Len(test, configurator.Configuration().IDOverrides, 800)
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+func configuratorOption(configurator *messenger.Configurator) messenger.OptionConfigurator {
+	return messenger.OptionConfigurator{Value: configurator}
+}
+
+// The next result of ConfigureOnSignal, failing rather than hanging if there is none.
+func receiveResult(test *testing.T, results <-chan error) error {
+	test.Helper()
+
+	select {
+	case err := <-results:
+		return err
+	case <-time.After(5 * time.Second):
+		require.FailNow(test, "no result of ConfigureOnSignal")
+
+		return nil
+	}
+}
@@ -0,0 +1,587 @@
+package messenger
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A DetailFormatter converts a detail value to the Type, Value, and ValueRaw of a Detail.
+// Value is the string form.  ValueRaw, if not nil, must be encodable by encoding/json.
+// The Position of the result is set by the messenger.
+type DetailFormatter func(value interface{}) Detail
+
+// DetailFormatters is a registry of DetailFormatter by type.
+// It is safe for concurrent use, so one DetailFormatters may be shared by several messengers.
+type DetailFormatters struct {
+	byInterface []interfaceFormatter // Tried in order.
+	byType      map[reflect.Type]DetailFormatter
+	maxDepth    int // Maps, slices, arrays, and structs nested deeper are replaced by DetailMaxDepthMarker.
+	mutex       sync.RWMutex
+}
+
+// Nesting depth of maps, slices, arrays, and structs in a detail.  See DefaultMaxDetailDepth.
+type OptionMaxDetailDepth struct {
+	Value int
+}
+
+// The state of formatting one detail, whose maps, slices, arrays, and structs contain other values.
+type formatState struct {
+	depth    int
+	visiting map[visit]bool // The maps, slices, and pointers being formatted, for detecting cycles.
+}
+
+// A map, slice, or pointer being formatted.
+type visit struct {
+	length    int
+	pointer   uintptr
+	valueType reflect.Type
+}
+
+// A DetailFormatter for values implementing an interface.
+// The built-in slog.LogValuer formatter is nil, as it is given the formatState of its container.
+type interfaceFormatter struct {
+	formatter     DetailFormatter
+	interfaceType reflect.Type
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Values of Detail.Type for built-in formatters.
+const (
+	DetailTypeBoolean  = "boolean"
+	DetailTypeBytes    = "bytes"
+	DetailTypeDuration = "duration"
+	DetailTypeError    = "error"
+	DetailTypeFloat    = "float"
+	DetailTypeInteger  = "integer"
+	DetailTypeNil      = "nil"
+	DetailTypeString   = "string"
+	DetailTypeTime     = "time"
+)
+
+// Default of OptionMaxDetailDepth.
+const DefaultMaxDetailDepth = 8
+
+// Values replacing what cannot be formatted inside a map, slice, array, or struct.
+const (
+	DetailCycleMarker    = "[cycle]"
+	DetailMaxDepthMarker = "[max depth]"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Formatters used by messengers without OptionDetailFormatters.
+var defaultDetailFormatters = NewDetailFormatters()
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewDetailFormatters function creates a registry having the built-in formatters.
+Values are formatted by the first of:
+
+  - A formatter registered for the value's exact type, including the built-in time.Time, time.Duration, and []byte.
+  - A formatter registered for an interface the value implements, most recently registered first,
+    then the built-in error, slog.LogValuer, json.Marshaler, encoding.TextMarshaler, and fmt.Stringer.
+  - Built-in formatters by kind: integers and floats of all widths, bool, string, maps, slices, arrays, structs, and pointers.
+  - The Go syntax representation, as from fmt's "%#v", without a ValueRaw.
+
+Maps, slices, arrays, and structs become JSON, with each member formatted as above.
+Struct fields are named as by encoding/json, honoring "json" tags.
+Unexported fields are included by their Go names and, as their methods cannot be called, are formatted by kind.
+Members nested deeper than OptionMaxDetailDepth become DetailMaxDepthMarker,
+and members containing themselves become DetailCycleMarker.
+
+Input
+  - options: Optionally, OptionMaxDetailDepth.
+
+Output
+  - A DetailFormatters to be extended by Register() and used with OptionDetailFormatters.
+*/
+func NewDetailFormatters(options ...interface{}) *DetailFormatters {
+	result := &DetailFormatters{
+		byType: map[reflect.Type]DetailFormatter{
+			reflect.TypeFor[[]byte]():        formatBytes,
+			reflect.TypeFor[time.Duration](): formatDuration,
+			reflect.TypeFor[time.Time]():     formatTime,
+		},
+		maxDepth: DefaultMaxDetailDepth,
+	}
+
+	for _, value := range options {
+		if typedValue, isMaxDepth := value.(OptionMaxDetailDepth); isMaxDepth {
+			result.maxDepth = typedValue.Value
+		}
+	}
+
+	result.byInterface = []interfaceFormatter{
+		{interfaceType: reflect.TypeFor[error](), formatter: formatError},
+		{interfaceType: reflect.TypeFor[slog.LogValuer]()},
+		{interfaceType: reflect.TypeFor[json.Marshaler](), formatter: formatJSONMarshaler},
+		{interfaceType: reflect.TypeFor[encoding.TextMarshaler](), formatter: formatTextMarshaler},
+		{interfaceType: reflect.TypeFor[fmt.Stringer](), formatter: formatStringer},
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Format method converts a detail value to a Detail without a Position.
+
+Input
+  - value: A detail, as given to NewJSON().
+
+Output
+  - The Type, Value, and ValueRaw of the detail.  ValueRaw is nil or can be encoded as JSON.
+*/
+func (formatters *DetailFormatters) Format(value interface{}) Detail {
+	return formatters.format(reflect.ValueOf(value), &formatState{visiting: map[visit]bool{}})
+}
+
+/*
+The Register method sets the formatter of a type.
+
+Input
+  - valueType: A type, such as reflect.TypeFor[uuid.UUID](), or an interface, such as reflect.TypeFor[fmt.Stringer]().
+  - formatter: The formatter of values of the type, or implementing the interface.
+*/
+func (formatters *DetailFormatters) Register(valueType reflect.Type, formatter DetailFormatter) {
+	formatters.mutex.Lock()
+	defer formatters.mutex.Unlock()
+
+	if valueType.Kind() != reflect.Interface {
+		formatters.byType[valueType] = formatter
+
+		return
+	}
+
+	formatters.byInterface = slices.DeleteFunc(formatters.byInterface, func(registered interfaceFormatter) bool {
+		return registered.interfaceType == valueType
+	})
+	formatters.byInterface = slices.Insert(formatters.byInterface, 0, interfaceFormatter{formatter: formatter, interfaceType: valueType})
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// The Detail, without a Position, that messageDetails() would produce for a value.
+func (messenger *BasicMessenger) formatDetail(value interface{}) Detail {
+	if messenger.detailFormatters == nil {
+		return defaultDetailFormatters.Format(value)
+	}
+
+	return messenger.detailFormatters.Format(value)
+}
+
+// Determine if a value would be formatted by a registered formatter.
+func (formatters *DetailFormatters) hasFormatter(value reflect.Value) bool {
+	value = exportSensitive(value)
+	if !value.CanInterface() {
+		return false
+	}
+
+	_, isRegistered := formatters.find(value.Type())
+
+	return isRegistered
+}
+
+// The registered formatter of a type, and whether there is one.  A nil formatter is the built-in slog.LogValuer formatter.
+func (formatters *DetailFormatters) find(valueType reflect.Type) (DetailFormatter, bool) {
+	formatters.mutex.RLock()
+	defer formatters.mutex.RUnlock()
+
+	if formatter, isRegistered := formatters.byType[valueType]; isRegistered {
+		return formatter, true
+	}
+
+	for _, registered := range formatters.byInterface {
+		if valueType.Implements(registered.interfaceType) {
+			return registered.formatter, true
+		}
+	}
+
+	return nil, false
+}
+
+// Format a value with a registered formatter or, if there is none or the value is from an unexported field, by its kind.
+func (formatters *DetailFormatters) format(value reflect.Value, state *formatState) Detail {
+	for value.Kind() == reflect.Interface && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	if !value.IsValid() || (isNilable(value.Kind()) && value.IsNil()) {
+		return Detail{Type: DetailTypeNil}
+	}
+
+	value = exportSensitive(value)
+	if value.CanInterface() {
+		formatter, isRegistered := formatters.find(value.Type())
+
+		switch {
+		case !isRegistered:
+		case formatter == nil:
+			return formatters.formatLogValuer(value, state)
+		default:
+			return callFormatter(formatter, value.Interface())
+		}
+	}
+
+	return formatters.formatKind(value, state)
+}
+
+// Format a value by its kind, so that named types such as "type Count int" are formatted as their underlying type.
+func (formatters *DetailFormatters) formatKind(value reflect.Value, state *formatState) Detail {
+	switch value.Kind() {
+	case reflect.Bool:
+		return Detail{Type: DetailTypeBoolean, Value: strconv.FormatBool(value.Bool()), ValueRaw: interfaceOf(value, value.Bool())}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Detail{Type: DetailTypeInteger, Value: strconv.FormatInt(value.Int(), 10), ValueRaw: interfaceOf(value, value.Int())}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Detail{Type: DetailTypeInteger, Value: strconv.FormatUint(value.Uint(), 10), ValueRaw: interfaceOf(value, value.Uint())}
+	case reflect.Float32, reflect.Float64:
+		return formatFloat(interfaceOf(value, value.Float()), value.Float(), value.Type().Bits())
+	case reflect.String:
+		return formatString(value.String())
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return formatBytes(value.Bytes())
+		}
+
+		return formatters.formatContainer(value, state)
+	case reflect.Map, reflect.Array, reflect.Struct, reflect.Pointer:
+		return formatters.formatContainer(value, state)
+	default:
+		return Detail{Type: value.Type().String(), Value: fmt.Sprintf("%#v", value)}
+	}
+}
+
+// Format a map, slice, array, struct, or pointer, stopping at cycles and at the maximum depth.
+func (formatters *DetailFormatters) formatContainer(value reflect.Value, state *formatState) Detail {
+	valueType := value.Type().String()
+
+	if value.Kind() != reflect.Array && value.Kind() != reflect.Struct {
+		current, isCycle := state.enter(value)
+		if isCycle {
+			return Detail{Type: valueType, Value: DetailCycleMarker}
+		}
+
+		defer delete(state.visiting, current)
+	}
+
+	// A pointer is formatted as the value it points to, keeping the type of the pointer.
+
+	if value.Kind() == reflect.Pointer {
+		result := formatters.format(value.Elem(), state)
+		result.Type = valueType
+
+		return result
+	}
+
+	if state.depth >= formatters.maxDepth {
+		return Detail{Type: valueType, Value: DetailMaxDepthMarker}
+	}
+
+	state.depth++
+	defer func() { state.depth-- }()
+
+	var raw interface{}
+
+	switch value.Kind() {
+	case reflect.Map:
+		members := make(map[string]interface{}, value.Len())
+
+		iterator := value.MapRange()
+		for iterator.Next() {
+			key := formatters.format(iterator.Key(), state).Value
+			members[key] = rawValue(formatters.format(iterator.Value(), state))
+		}
+
+		raw = members
+	case reflect.Struct:
+		raw = formatters.structMembers(value, state)
+	default:
+		elements := make([]interface{}, value.Len())
+		for index := range elements {
+			elements[index] = rawValue(formatters.format(value.Index(index), state))
+		}
+
+		raw = elements
+	}
+
+	text, err := marshalWithoutEscaping(raw)
+	if err != nil {
+		return Detail{Type: valueType, Value: fmt.Sprintf("%%!v(ERROR=%v)", err)}
+	}
+
+	return Detail{Type: valueType, Value: text, ValueRaw: json.RawMessage(text)}
+}
+
+// Record a map, slice, or pointer as being formatted.  Whether it already was, as when it contains itself.
+func (state *formatState) enter(value reflect.Value) (visit, bool) {
+	current := visit{pointer: value.Pointer(), valueType: value.Type()}
+	if value.Kind() == reflect.Slice {
+		current.length = value.Len()
+	}
+
+	if state.visiting[current] {
+		return current, true
+	}
+
+	state.visiting[current] = true
+
+	return current, false
+}
+
+// Format the value a slog.LogValuer resolves to, keeping the type of the slog.LogValuer.
+// The state is kept, so that a value resolving to one containing itself stops at a cycle or the maximum depth.
+func (formatters *DetailFormatters) formatLogValuer(value reflect.Value, state *formatState) Detail {
+	valueType := value.Type().String()
+
+	switch value.Kind() {
+	case reflect.Map, reflect.Pointer, reflect.Slice:
+		current, isCycle := state.enter(value)
+		if isCycle {
+			return Detail{Type: valueType, Value: DetailCycleMarker}
+		}
+
+		defer delete(state.visiting, current)
+	default:
+	}
+
+	resolved := slog.AnyValue(value.Interface()).Resolve() // Resolve() recovers from panics.
+
+	result := formatters.format(reflect.ValueOf(slogValueAsInterface(resolved)), state)
+	result.Type = valueType
+
+	return result
+}
+
+// The fields of a struct, named as by encoding/json.
+// Fields of embedded structs without a formatter are promoted unless hidden.
+func (formatters *DetailFormatters) structMembers(value reflect.Value, state *formatState) map[string]interface{} {
+	result := map[string]interface{}{}
+	promoted := map[string]interface{}{}
+
+	for index := range value.NumField() {
+		field := value.Type().Field(index)
+		fieldValue := value.Field(index)
+
+		name, omitEmpty, isSkipped := jsonFieldName(field)
+		if isSkipped || (omitEmpty && fieldValue.IsZero()) {
+			continue
+		}
+
+		embedded := reflect.Indirect(fieldValue)
+		if field.Anonymous && name == field.Name && embedded.Kind() == reflect.Struct && !formatters.hasFormatter(embedded) {
+			if state.depth >= formatters.maxDepth {
+				continue
+			}
+
+			state.depth++
+			maps.Copy(promoted, formatters.structMembers(embedded, state))
+			state.depth--
+
+			continue
+		}
+
+		result[name] = rawValue(formatters.format(fieldValue, state))
+	}
+
+	for name, member := range promoted {
+		if _, isHidden := result[name]; !isHidden {
+			result[name] = member
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Call a formatter, recovering from panics and dropping a ValueRaw that cannot be encoded as JSON.
+func callFormatter(formatter DetailFormatter, value interface{}) (result Detail) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result = Detail{Type: reflectTypeName(value), Value: fmt.Sprintf("%%!v(PANIC=%v)", recovered)}
+		}
+	}()
+
+	result = formatter(value)
+	if result.ValueRaw != nil {
+		if _, err := json.Marshal(result.ValueRaw); err != nil {
+			result.ValueRaw = nil
+		}
+	}
+
+	return result
+}
+
+func formatBytes(value interface{}) Detail {
+	return Detail{Type: DetailTypeBytes, Value: base64.StdEncoding.EncodeToString(value.([]byte))}
+}
+
+func formatDuration(value interface{}) Detail {
+	duration := value.(time.Duration)
+
+	return Detail{Type: DetailTypeDuration, Value: duration.String(), ValueRaw: int64(duration)}
+}
+
+func formatError(value interface{}) Detail {
+	return createErrorDetail(0, value.(error))
+}
+
+// Format a float.  NaN and infinities have no JSON representation, so they have no ValueRaw.
+func formatFloat(value interface{}, float float64, bitSize int) Detail {
+	result := Detail{Type: DetailTypeFloat, Value: strconv.FormatFloat(float, 'g', -1, bitSize)}
+	if !math.IsNaN(float) && !math.IsInf(float, 0) {
+		result.ValueRaw = value
+	}
+
+	return result
+}
+
+// Format the JSON of a json.Marshaler.  A JSON string is unquoted in Value.
+func formatJSONMarshaler(value interface{}) Detail {
+	raw, err := value.(json.Marshaler).MarshalJSON()
+	if err != nil || !json.Valid(raw) {
+		return Detail{Type: reflectTypeName(value), Value: fmt.Sprintf("%v", value)}
+	}
+
+	var compacted bytes.Buffer
+
+	_ = json.Compact(&compacted, raw)
+	result := Detail{Type: reflectTypeName(value), Value: compacted.String(), ValueRaw: json.RawMessage(compacted.Bytes())}
+
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		result.Value = text
+	}
+
+	return result
+}
+
+func formatString(value string) Detail {
+	return createStringDetail(0, value)
+}
+
+func formatStringer(value interface{}) Detail {
+	return Detail{Type: reflectTypeName(value), Value: value.(fmt.Stringer).String()}
+}
+
+func formatTextMarshaler(value interface{}) Detail {
+	text, err := value.(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return Detail{Type: reflectTypeName(value), Value: fmt.Sprintf("%v", value)}
+	}
+
+	return Detail{Type: reflectTypeName(value), Value: string(text)}
+}
+
+func formatTime(value interface{}) Detail {
+	return Detail{Type: DetailTypeTime, Value: value.(time.Time).Format(time.RFC3339Nano)}
+}
+
+// The value of a reflect.Value, or if it is from an unexported field, the fallback.
+func interfaceOf(value reflect.Value, fallback interface{}) interface{} {
+	if value.CanInterface() {
+		return value.Interface()
+	}
+
+	return fallback
+}
+
+// Determine if values of a kind may be nil.
+func isNilable(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice, reflect.UnsafePointer:
+		return true
+	default:
+		return false
+	}
+}
+
+// The name of a struct field as in encoding/json, whether it has "omitempty", and whether it is "-".
+func jsonFieldName(field reflect.StructField) (string, bool, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, tagOptions, _ := strings.Cut(tag, ",")
+	if len(name) == 0 {
+		name = field.Name
+	}
+
+	return name, slices.Contains(strings.Split(tagOptions, ","), "omitempty"), false
+}
+
+// The JSON representation of a formatted value inside a map, slice, array, or struct.
+func rawValue(detail Detail) interface{} {
+	switch {
+	case detail.ValueRaw != nil:
+		return detail.ValueRaw
+	case detail.Type == DetailTypeNil:
+		return nil
+	default:
+		return detail.Value
+	}
+}
+
+// Encode JSON without escaping HTML characters, as marshalMessageFormat does.
+func marshalWithoutEscaping(value interface{}) (string, error) {
+	var result bytes.Buffer
+
+	encoder := json.NewEncoder(&result)
+	encoder.SetEscapeHTML(false)
+
+	err := encoder.Encode(value)
+	if err != nil {
+		return "", fmt.Errorf("messenger.marshalWithoutEscaping error: %w", err)
+	}
+
+	return string(bytes.TrimSpace(result.Bytes())), nil
+}
+
+// Convert a slog.Value to a Go value; a group becomes a map.
+// A slog.LogValuer inside a group is not resolved here, but when it is formatted.
+func slogValueAsInterface(value slog.Value) interface{} {
+	if value.Kind() != slog.KindGroup {
+		return value.Any()
+	}
+
+	result := map[string]interface{}{}
+	for _, attr := range value.Group() {
+		result[attr.Key] = slogValueAsInterface(attr.Value)
+	}
+
+	return result
+}
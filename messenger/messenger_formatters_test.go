@@ -0,0 +1,398 @@
+package messenger_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+type testCount int
+
+type testStringer struct{ name string }
+
+func (stringer testStringer) String() string { return "stringer " + stringer.name }
+
+type testJSONMarshaler struct{ name string }
+
+func (marshaler testJSONMarshaler) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"name": marshaler.name})
+}
+
+type testLogValuer struct{ name string }
+
+func (logValuer testLogValuer) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("name", logValuer.name))
+}
+
+type testUser struct{ name string }
+
+type testLogNode struct{ Next *testLogNode }
+
+func (node testLogNode) LogValue() slog.Value {
+	return slog.AnyValue(struct{ Next *testLogNode }{node.Next})
+}
+
+type testLogGroup struct{ name string }
+
+func (group *testLogGroup) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("name", group.name), slog.Any("self", group))
+}
+
+type testAddress struct {
+	City    string `json:"city"`
+	Country string `json:"country,omitempty"`
+}
+
+type testPerson struct {
+	testAddress
+
+	Name     string        `json:"name"`
+	Password string        `json:"-"`
+	Friends  []*testPerson `json:"friends,omitempty"`
+	Updates  chan int
+	age      int
+	notify   func()
+}
+
+type testNode struct {
+	Next  *testNode
+	Value int
+}
+
+var testCasesForDetailFormatters = []struct {
+	name     string
+	value    interface{}
+	expected messenger.Detail
+}{
+	{
+		name:     "formatter-0001",
+		value:    nil,
+		expected: messenger.Detail{Type: "nil"},
+	},
+	{
+		name:     "formatter-0002",
+		value:    int8(-8),
+		expected: messenger.Detail{Type: "integer", Value: "-8", ValueRaw: int8(-8)},
+	},
+	{
+		name:     "formatter-0003",
+		value:    uint64(math.MaxUint64),
+		expected: messenger.Detail{Type: "integer", Value: "18446744073709551615", ValueRaw: uint64(math.MaxUint64)},
+	},
+	{
+		name:     "formatter-0004",
+		value:    testCount(3),
+		expected: messenger.Detail{Type: "integer", Value: "3", ValueRaw: testCount(3)},
+	},
+	{
+		name:     "formatter-0005",
+		value:    float32(0.1),
+		expected: messenger.Detail{Type: "float", Value: "0.1", ValueRaw: float32(0.1)},
+	},
+	{
+		name:     "formatter-0006",
+		value:    math.Inf(-1),
+		expected: messenger.Detail{Type: "float", Value: "-Inf"},
+	},
+	{
+		name:     "formatter-0007",
+		value:    time.Date(2026, 10, 18, 12, 30, 0, 5, time.UTC),
+		expected: messenger.Detail{Type: "time", Value: "2026-10-18T12:30:00.000000005Z"},
+	},
+	{
+		name:     "formatter-0008",
+		value:    []byte("Bob"),
+		expected: messenger.Detail{Type: "bytes", Value: "Qm9i"},
+	},
+	{
+		name:     "formatter-0009",
+		value:    testStringer{name: "Bob"},
+		expected: messenger.Detail{Type: "messenger_test.testStringer", Value: "stringer Bob"},
+	},
+	{
+		name:     "formatter-0010",
+		value:    testJSONMarshaler{name: "Bob"},
+		expected: messenger.Detail{Type: "messenger_test.testJSONMarshaler", Value: `{"name":"Bob"}`, ValueRaw: json.RawMessage(`{"name":"Bob"}`)},
+	},
+	{
+		name:     "formatter-0011",
+		value:    net.IPv4(127, 0, 0, 1),
+		expected: messenger.Detail{Type: "net.IP", Value: "127.0.0.1"},
+	},
+	{
+		name:     "formatter-0012",
+		value:    testLogValuer{name: "Bob"},
+		expected: messenger.Detail{Type: "messenger_test.testLogValuer", Value: `{"name":"Bob"}`, ValueRaw: json.RawMessage(`{"name":"Bob"}`)},
+	},
+	{
+		name:  "formatter-0013",
+		value: map[int][]time.Duration{1: {time.Second}},
+		expected: messenger.Detail{
+			Type:     "map[int][]time.Duration",
+			Value:    `{"1":[1000000000]}`,
+			ValueRaw: json.RawMessage(`{"1":[1000000000]}`),
+		},
+	},
+	{
+		name:  "formatter-0014",
+		value: []interface{}{"<Bob>", 1.5, math.NaN(), nil, testStringer{name: "Jane"}},
+		expected: messenger.Detail{
+			Type:     "[]interface {}",
+			Value:    `["<Bob>",1.5,"NaN",null,"stringer Jane"]`,
+			ValueRaw: json.RawMessage(`["<Bob>",1.5,"NaN",null,"stringer Jane"]`),
+		},
+	},
+	{
+		name:     "formatter-0015",
+		value:    complex(1, 2),
+		expected: messenger.Detail{Type: "complex128", Value: "(1+2i)"},
+	},
+	{
+		name:  "formatter-0016",
+		value: testPerson{testAddress: testAddress{City: "Las Vegas"}, Name: "Bob", Password: "secret", age: 42},
+		expected: messenger.Detail{
+			Type:     "messenger_test.testPerson",
+			Value:    `{"Updates":null,"age":42,"city":"Las Vegas","name":"Bob","notify":null}`,
+			ValueRaw: json.RawMessage(`{"Updates":null,"age":42,"city":"Las Vegas","name":"Bob","notify":null}`),
+		},
+	},
+	{
+		name:  "formatter-0017",
+		value: &testPerson{Name: "Bob", Friends: []*testPerson{{Name: "Jane"}, nil}},
+		expected: messenger.Detail{
+			Type:     "*messenger_test.testPerson",
+			Value:    `{"Updates":null,"age":0,"city":"","friends":[{"Updates":null,"age":0,"city":"","name":"Jane","notify":null},null],"name":"Bob","notify":null}`,
+			ValueRaw: json.RawMessage(`{"Updates":null,"age":0,"city":"","friends":[{"Updates":null,"age":0,"city":"","name":"Jane","notify":null},null],"name":"Bob","notify":null}`),
+		},
+	},
+	{
+		name:  "formatter-0018",
+		value: newTestCycleNode(),
+		expected: messenger.Detail{
+			Type:     "*messenger_test.testNode",
+			Value:    `{"Next":"[cycle]","Value":1}`,
+			ValueRaw: json.RawMessage(`{"Next":"[cycle]","Value":1}`),
+		},
+	},
+	{
+		name:  "formatter-0019",
+		value: newTestCycleSlice(),
+		expected: messenger.Detail{
+			Type:     "[]interface {}",
+			Value:    `[1,"[cycle]"]`,
+			ValueRaw: json.RawMessage(`[1,"[cycle]"]`),
+		},
+	},
+	{
+		name:  "formatter-0020",
+		value: newTestCycleMap(),
+		expected: messenger.Detail{
+			Type:     "map[string]interface {}",
+			Value:    `{"self":"[cycle]"}`,
+			ValueRaw: json.RawMessage(`{"self":"[cycle]"}`),
+		},
+	},
+	{
+		name:     "formatter-0021",
+		value:    (*testNode)(nil),
+		expected: messenger.Detail{Type: "nil"},
+	},
+	{
+		name:  "formatter-0022",
+		value: newTestLogNodeCycle(),
+		expected: messenger.Detail{
+			Type:     "*messenger_test.testLogNode",
+			Value:    `{"Next":"[cycle]"}`,
+			ValueRaw: json.RawMessage(`{"Next":"[cycle]"}`),
+		},
+	},
+	{
+		name:  "formatter-0023",
+		value: &testLogGroup{name: "Bob"},
+		expected: messenger.Detail{
+			Type:     "*messenger_test.testLogGroup",
+			Value:    `{"name":"Bob","self":"[cycle]"}`,
+			ValueRaw: json.RawMessage(`{"name":"Bob","self":"[cycle]"}`),
+		},
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestDetailFormatters_Format(test *testing.T) {
+	test.Parallel()
+
+	testObject := messenger.NewDetailFormatters()
+
+	for _, testCase := range testCasesForDetailFormatters {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+			assert.Equal(test, testCase.expected, testObject.Format(testCase.value))
+		})
+	}
+}
+
+func TestDetailFormatters_Register(test *testing.T) {
+	test.Parallel()
+
+	testObject := messenger.NewDetailFormatters()
+	testObject.Register(reflect.TypeFor[testUser](), func(value interface{}) messenger.Detail {
+		return messenger.Detail{Type: "user", Value: value.(testUser).name}
+	})
+	testObject.Register(reflect.TypeFor[fmt.Stringer](), func(value interface{}) messenger.Detail {
+		return messenger.Detail{Type: "stringer", Value: value.(fmt.Stringer).String()}
+	})
+
+	assert.Equal(test, messenger.Detail{Type: "user", Value: "Bob"}, testObject.Format(testUser{name: "Bob"}))
+	assert.Equal(test, messenger.Detail{Type: "stringer", Value: "stringer Jane"}, testObject.Format(testStringer{name: "Jane"}))
+	assert.Equal(test, messenger.Detail{Type: "error", Value: "error 1"}, testObject.Format(errTest1))
+	assert.Equal(test, "messenger_test.testStringer", messenger.NewDetailFormatters().Format(testStringer{name: "Jane"}).Type)
+
+	testObject.Register(reflect.TypeFor[url.URL](), func(value interface{}) messenger.Detail {
+		location := value.(url.URL)
+
+		return messenger.Detail{Type: "url", Value: location.String()}
+	})
+
+	embedded := struct {
+		url.URL
+		testAddress
+	}{URL: url.URL{Scheme: "https", Host: "example.com"}, testAddress: testAddress{City: "Las Vegas"}}
+	assert.JSONEq(test, `{"URL":"https://example.com","city":"Las Vegas"}`, testObject.Format(embedded).Value)
+}
+
+func TestDetailFormatters_Format_maxDepth(test *testing.T) {
+	test.Parallel()
+
+	testObject := messenger.NewDetailFormatters(messenger.OptionMaxDetailDepth{Value: 2})
+	assert.JSONEq(test, `[["[max depth]"]]`, testObject.Format([][][]int{{{1}}}).Value)
+	assert.JSONEq(test, `{"Next":{"Next":"[max depth]","Value":2},"Value":1}`, testObject.Format(&testNode{Value: 1, Next: &testNode{Value: 2, Next: &testNode{Value: 3}}}).Value)
+
+	deep := interface{}("bottom")
+	for range 100 {
+		deep = []interface{}{deep}
+	}
+
+	detail := messenger.NewDetailFormatters().Format(deep)
+	assert.Equal(test, strings.Repeat("[", messenger.DefaultMaxDetailDepth)+`"[max depth]"`+strings.Repeat("]", messenger.DefaultMaxDetailDepth), detail.Value)
+}
+
+func TestDetailFormatters_Register_unsafe(test *testing.T) {
+	test.Parallel()
+
+	testObject := messenger.NewDetailFormatters()
+	testObject.Register(reflect.TypeFor[testUser](), func(value interface{}) messenger.Detail {
+		return messenger.Detail{Type: "user", Value: value.(testUser).name, ValueRaw: make(chan int)}
+	})
+	testObject.Register(reflect.TypeFor[testCount](), func(interface{}) messenger.Detail {
+		panic("bad formatter")
+	})
+
+	assert.Equal(test, messenger.Detail{Type: "user", Value: "Bob"}, testObject.Format(testUser{name: "Bob"}))
+	assert.Equal(test, messenger.Detail{Type: "messenger_test.testCount", Value: "%!v(PANIC=bad formatter)"}, testObject.Format(testCount(1)))
+	assert.JSONEq(test, `["Bob","%!v(PANIC=bad formatter)"]`, testObject.Format([]interface{}{testUser{name: "Bob"}, testCount(1)}).Value)
+}
+
+func TestBasicMessenger_NewJSON_validJSON(test *testing.T) {
+	test.Parallel()
+
+	testObject, err := messenger.New(messenger.OptionMessageFields{Value: []string{"details"}})
+	require.NoError(test, err)
+
+	details := []interface{}{
+		testPerson{Updates: make(chan int), notify: func() {}},
+		newTestCycleNode(),
+		newTestCycleSlice(),
+		newTestCycleMap(),
+		map[interface{}]interface{}{1.5: math.Inf(1), true: complex(1, 2), "func": fmt.Println},
+		struct{ value interface{} }{value: newTestCycleMap()},
+	}
+
+	for index, detail := range details {
+		actual := testObject.NewJSON(2001, detail)
+		assert.True(test, json.Valid([]byte(actual)), "detail %d: %s", index, actual)
+		assert.Contains(test, actual, `"details":[{"position":1,`, "detail %d", index)
+	}
+}
+
+func TestBasicMessenger_NewJSON_structRedaction(test *testing.T) {
+	test.Parallel()
+
+	testObject, err := messenger.New(
+		messenger.OptionMessageFields{Value: []string{"details"}},
+		messenger.OptionRedactionRules{Value: []messenger.RedactionRule{{Key: "age"}}},
+	)
+	require.NoError(test, err)
+
+	actual := testObject.NewJSON(2001, testPerson{Name: "Bob", age: 42})
+	assert.JSONEq(test, `{"details":[{"position":1,"type":"messenger_test.testPerson",
+		"value":"{\"Updates\":null,\"age\":\"[REDACTED]\",\"city\":\"\",\"name\":\"Bob\",\"notify\":null}",
+		"valueRaw":{"Updates":null,"age":"[REDACTED]","city":"","name":"Bob","notify":null}}]}`, actual)
+}
+
+func TestBasicMessenger_NewJSON_detailFormatters(test *testing.T) {
+	test.Parallel()
+
+	detailFormatters := messenger.NewDetailFormatters()
+	detailFormatters.Register(reflect.TypeFor[testUser](), func(value interface{}) messenger.Detail {
+		return messenger.Detail{Type: "user", Value: value.(testUser).name, ValueRaw: map[string]string{"name": value.(testUser).name}}
+	})
+
+	testObject, err := messenger.New(
+		getOptionIDMessages(),
+		messenger.OptionMessageFields{Value: []string{"details"}},
+		messenger.OptionDetailFormatters{Value: detailFormatters},
+	)
+	require.NoError(test, err)
+
+	actual := testObject.NewJSON(2001, testUser{name: "Bob"}, math.NaN(), []byte("Jane"))
+	assert.JSONEq(test, `{"details":[
+		{"position":1,"type":"user","value":"Bob","valueRaw":{"name":"Bob"}},
+		{"position":2,"type":"float","value":"NaN"},
+		{"position":3,"type":"bytes","value":"SmFuZQ=="}]}`, actual)
+}
+
+// ----------------------------------------------------------------------------
+// Helper functions
+// ----------------------------------------------------------------------------
+
+func newTestCycleMap() map[string]interface{} {
+	result := map[string]interface{}{}
+	result["self"] = result
+
+	return result
+}
+
+func newTestLogNodeCycle() *testLogNode {
+	result := &testLogNode{}
+	result.Next = result
+
+	return result
+}
+
+func newTestCycleNode() *testNode {
+	result := &testNode{Value: 1}
+	result.Next = result
+
+	return result
+}
+
+func newTestCycleSlice() []interface{} {
+	result := []interface{}{1, nil}
+	result[1] = result
+
+	return result
+}
@@ -0,0 +1,105 @@
+package messenger
+
+import (
+	"fmt"
+	"sort"
+
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The MessageNumberLevel function returns the level of a message number from IDLevelRangesAsString,
+as messengers do when neither details nor rules set the level.
+
+Input
+  - messageNumber: A message identifier which indexes into "idMessages".
+
+Output
+  - A level name, such as LevelInfoName, or "UNKNOWN".
+*/
+func MessageNumberLevel(messageNumber int) string {
+	sortedIDLevelRanges := make([]int, 0, len(IDLevelRangesAsString))
+	for key := range IDLevelRangesAsString {
+		sortedIDLevelRanges = append(sortedIDLevelRanges, key)
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(sortedIDLevelRanges)))
+
+	return levelOfMessageNumber(messageNumber, sortedIDLevelRanges)
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Determine if a message is at or above the minimum level.  Its level is determined only if there is a minimum level.
+func (messenger *BasicMessenger) isEnabled(messageNumber int, details []interface{}) bool {
+	if messenger.configurator == nil && messenger.minimumLevel == nil {
+		return true
+	}
+
+	return messenger.isLevelEnabled(messageNumber, messenger.messageLevel(messageNumber, details))
+}
+
+// Determine if a message at the given level is at or above the minimum level of
+// OptionConfigurator, for the message id or all messages, else of OptionMinimumLevel.
+func (messenger *BasicMessenger) isLevelEnabled(messageNumber int, level slog.Level) bool {
+	if messenger.configurator != nil {
+		minimumLevel, isConfigured := messenger.configurator.minimumLevel(fmt.Sprintf(messenger.messageIDTemplate, messageNumber))
+		if isConfigured {
+			return level >= minimumLevel
+		}
+	}
+
+	if messenger.minimumLevel == nil {
+		return true
+	}
+
+	return level >= messenger.minimumLevel.Level()
+}
+
+// The level of a message: the value of a MessageLevel detail, else of a MessageRule, else the level of the message number.
+// Unknown levels are LevelPanicSlog, as in NewSlogLevel().
+func (messenger *BasicMessenger) messageLevel(messageNumber int, details []interface{}) slog.Level {
+	levelName := ""
+
+	for _, value := range details {
+		if typedValue, isMessageLevel := value.(MessageLevel); isMessageLevel {
+			levelName = typedValue.Value
+		}
+	}
+
+	if len(levelName) == 0 && messenger.hasRules() {
+		_, levelName = messenger.matchRules(messageNumber, fmt.Sprintf(messenger.messageIDTemplate, messageNumber))
+	}
+
+	if len(levelName) == 0 {
+		levelName = messenger.getLevel(messageNumber)
+	}
+
+	result, isOK := TextToLevelMap[levelName]
+	if !isOK {
+		return LevelPanicSlog
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The level of a message number, given the keys of IDLevelRangesAsString in descending order.
+func levelOfMessageNumber(messageNumber int, sortedIDLevelRanges []int) string {
+	for _, messageLevelKey := range sortedIDLevelRanges {
+		if messageNumber >= messageLevelKey {
+			return IDLevelRangesAsString[messageLevelKey]
+		}
+	}
+
+	return "UNKNOWN"
+}
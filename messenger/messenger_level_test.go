@@ -0,0 +1,174 @@
+package messenger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+var testCasesForMinimumLevel = []struct {
+	name            string
+	messageNumber   int
+	minimumLevel    slog.Leveler
+	details         []interface{}
+	expectedEnabled bool
+	expectedLevel   slog.Level
+}{
+	{
+		name:            "level-0001",
+		messageNumber:   2001,
+		expectedEnabled: true,
+		expectedLevel:   messenger.LevelInfoSlog,
+	},
+	{
+		name:            "level-0002",
+		messageNumber:   2001,
+		minimumLevel:    messenger.LevelWarnSlog,
+		expectedEnabled: false,
+		expectedLevel:   messenger.LevelInfoSlog,
+	},
+	{
+		name:            "level-0003",
+		messageNumber:   3001,
+		minimumLevel:    messenger.LevelWarnSlog,
+		expectedEnabled: true,
+		expectedLevel:   messenger.LevelWarnSlog,
+	},
+	{
+		name:            "level-0004",
+		messageNumber:   999,
+		minimumLevel:    messenger.LevelDebugSlog,
+		expectedEnabled: false,
+		expectedLevel:   messenger.LevelTraceSlog,
+	},
+	{
+		name:            "level-0005",
+		messageNumber:   2001,
+		minimumLevel:    messenger.LevelWarnSlog,
+		details:         []interface{}{messenger.MessageLevel{Value: messenger.LevelErrorName}},
+		expectedEnabled: true,
+		expectedLevel:   messenger.LevelErrorSlog,
+	},
+	{
+		name:            "level-0006",
+		messageNumber:   -1,
+		minimumLevel:    messenger.LevelFatalSlog,
+		expectedEnabled: true,
+		expectedLevel:   messenger.LevelPanicSlog,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicMessenger_minimumLevel(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForMinimumLevel {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			options := []interface{}{getOptionMessageIDTemplate(9999), getOptionMessageFields(), getOptionIDMessages()}
+			if testCase.minimumLevel != nil {
+				options = append(options, messenger.OptionMinimumLevel{Value: testCase.minimumLevel})
+			}
+
+			testObject, err := messenger.New(options...)
+			require.NoError(test, err)
+
+			details := append([]interface{}{"Bob", "Jane"}, testCase.details...)
+			message, level, keyValuePairs := testObject.NewSlogLevel(testCase.messageNumber, details...)
+			assert.Equal(test, testCase.expectedLevel, level)
+
+			if testCase.expectedEnabled {
+				assert.NotEmpty(test, testObject.NewJSON(testCase.messageNumber, details...))
+				assert.NotEmpty(test, keyValuePairs)
+			} else {
+				assert.Empty(test, testObject.NewJSON(testCase.messageNumber, details...))
+				assert.Empty(test, message)
+				assert.Nil(test, keyValuePairs)
+			}
+
+			if len(testCase.details) == 0 {
+				assert.Equal(test, testCase.expectedEnabled, levelEnabled(test, testObject, testCase.messageNumber))
+			}
+
+			require.Error(test, testObject.NewError(testCase.messageNumber, details...))
+		})
+	}
+}
+
+func TestBasicMessenger_minimumLevel_levelVar(test *testing.T) {
+	test.Parallel()
+
+	minimumLevel := &slog.LevelVar{}
+	minimumLevel.Set(messenger.LevelWarnSlog)
+
+	testObject, err := messenger.New(getOptionIDMessages(), messenger.OptionMinimumLevel{Value: minimumLevel})
+	require.NoError(test, err)
+	assert.False(test, levelEnabled(test, testObject, 2001))
+	assert.Empty(test, testObject.NewJSON(2001, "Bob", "Jane"))
+
+	minimumLevel.Set(messenger.LevelInfoSlog)
+	assert.True(test, levelEnabled(test, testObject, 2001))
+	assert.Equal(test, `{"id":"2001","text":"INFO: Bob works with Jane"}`, testObject.NewJSON(2001, "Bob", "Jane"))
+}
+
+func TestBasicMessenger_minimumLevel_slogHandler(test *testing.T) {
+	test.Parallel()
+
+	testObject, err := messenger.New(getOptionIDMessages(), messenger.OptionMinimumLevel{Value: messenger.LevelWarnSlog})
+	require.NoError(test, err)
+
+	buffer := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buffer, nil))
+
+	if levelEnabled(test, testObject, 2001) {
+		message, level, keyValuePairs := testObject.NewSlogLevel(2001, "Bob", "Jane")
+		logger.Log(context.Background(), level, message, keyValuePairs...)
+	}
+
+	assert.Empty(test, buffer.String())
+
+	if levelEnabled(test, testObject, 3001) {
+		message, level, keyValuePairs := testObject.NewSlogLevel(3001, "Bob", "Jane")
+		logger.Log(context.Background(), level, message, keyValuePairs...)
+	}
+
+	assert.Contains(test, buffer.String(), `"id":"3001"`)
+	assert.Contains(test, buffer.String(), `"level":"WARN"`)
+}
+
+func TestMessageNumberLevel(test *testing.T) {
+	test.Parallel()
+
+	testObject, err := messenger.New(messenger.OptionMessageFields{Value: []string{"level"}})
+	require.NoError(test, err)
+
+	for _, messageNumber := range []int{-1, 0, 999, 1000, 2001, 4001, 5999, 6000, 9999} {
+		messageFormat := &messenger.MessageFormat{}
+		require.NoError(test, json.Unmarshal([]byte(testObject.NewJSON(messageNumber)), messageFormat))
+		assert.Equal(test, messageFormat.Level, messenger.MessageNumberLevel(messageNumber), messageNumber)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions - names begin with lowercase letter
+// ----------------------------------------------------------------------------
+
+// The Enabled() method of a messenger, which must be a messenger.LevelEnabler.
+func levelEnabled(test *testing.T, testObject messenger.Messenger, messageNumber int) bool {
+	test.Helper()
+
+	levelEnabler, isLevelEnabler := testObject.(messenger.LevelEnabler)
+	require.True(test, isLevelEnabler)
+
+	return levelEnabler.Enabled(messageNumber)
+}
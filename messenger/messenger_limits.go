@@ -0,0 +1,254 @@
+package messenger
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// What has been truncated from a message, so that truncating again updates the markers rather than adding more.
+// It is tracked here rather than read back from the markers, so a value that happens to end like a marker is not one.
+type messageTruncation struct {
+	details        map[int]int // Bytes removed from detail values, by index.
+	errors         map[int]int // Bytes removed from errors, by index.
+	omittedDetails int         // Details replaced by the final marker detail.
+	omittedErrors  int         // Errors replaced by the final marker entry.
+	text           int         // Bytes removed from "text".
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Markers recorded in truncated messages.  See MessageLimits.
+const (
+	truncatedDetailsFormat = "%d details omitted"
+	truncatedErrorsFormat  = "[truncated: %d errors omitted]"
+	truncatedKey           = "truncated"
+	truncatedStringFormat  = "...[truncated %d bytes]"
+)
+
+// The smallest size to which MaxMessageBytes shrinks values before omitting details.
+const minimumValueBytes = 32
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Apply MaxTextLength, MaxValueBytes, and MaxDetailCount, returning what was truncated for marshalLimited().
+func (messenger *BasicMessenger) limitMessageFormat(messageFormat *MessageFormat) *messageTruncation {
+	limits := messenger.messageLimits
+	truncation := newMessageTruncation()
+
+	if limits.MaxTextLength > 0 {
+		messageFormat.Text, truncation.text = truncateString(messageFormat.Text, truncation.text, limits.MaxTextLength)
+	}
+
+	if limits.MaxValueBytes > 0 {
+		truncateValues(messageFormat, truncation, limits.MaxValueBytes)
+	}
+
+	if limits.MaxDetailCount > 0 && len(messageFormat.Details) > limits.MaxDetailCount {
+		omitDetails(messageFormat, truncation, len(messageFormat.Details)-limits.MaxDetailCount)
+	}
+
+	return truncation
+}
+
+// Marshal a message, shrinking it until it fits within MaxMessageBytes.
+// In order: drop "valueRaw" that duplicates "value", truncate values and errors,
+// omit stack frames, omit details, omit errors, and truncate "text".
+func (messenger *BasicMessenger) marshalLimited(messageFormat *MessageFormat, truncation *messageTruncation) (string, error) {
+	limit := messenger.messageLimits.MaxMessageBytes
+
+	result, err := marshalMessageFormat(messageFormat)
+	if err != nil || limit <= 0 || len(result) <= limit {
+		return result, err
+	}
+
+	fits := func() bool {
+		result, err = marshalMessageFormat(messageFormat)
+
+		return err != nil || len(result) <= limit
+	}
+
+	// Drop "valueRaw" when it is the JSON text already in "value".
+
+	for index := range messageFormat.Details {
+		if _, isRawJSON := messageFormat.Details[index].ValueRaw.(json.RawMessage); isRawJSON {
+			messageFormat.Details[index].ValueRaw = nil
+		}
+	}
+
+	if fits() {
+		return result, err
+	}
+
+	// Truncate values and errors, halving the allowed size each time.
+
+	for size := longestValue(messageFormat) / 2; size >= minimumValueBytes; size /= 2 {
+		truncateValues(messageFormat, truncation, size)
+
+		if fits() {
+			return result, err
+		}
+	}
+
+	// Omit stack frames, outermost first.
+
+	for len(messageFormat.Stack) > 0 {
+		messageFormat.Stack = messageFormat.Stack[:len(messageFormat.Stack)-1]
+
+		if fits() {
+			return result, err
+		}
+	}
+
+	// Omit details, then errors, from the end.
+
+	for countDetails(messageFormat, truncation) > 0 {
+		omitDetails(messageFormat, truncation, 1)
+
+		if fits() {
+			return result, err
+		}
+	}
+
+	for countErrors(messageFormat, truncation) > 0 {
+		omitErrors(messageFormat, truncation, 1)
+
+		if fits() {
+			return result, err
+		}
+	}
+
+	// Finally, truncate "text".  If the message still does not fit, it is returned as small as it can be made.
+
+	for len(messageFormat.Text) > 0 {
+		maxLength := len(messageFormat.Text) - (len(result) - limit)
+		if maxLength <= len(fmt.Sprintf(truncatedStringFormat, len(messageFormat.Text))) {
+			messageFormat.Text = ""
+		} else {
+			messageFormat.Text, truncation.text = truncateString(messageFormat.Text, truncation.text, maxLength)
+		}
+
+		if fits() {
+			return result, err
+		}
+	}
+
+	return result, err
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The number of details, not counting the truncation marker.
+func countDetails(messageFormat *MessageFormat, truncation *messageTruncation) int {
+	if truncation.omittedDetails > 0 {
+		return len(messageFormat.Details) - 1
+	}
+
+	return len(messageFormat.Details)
+}
+
+// The number of errors, not counting the truncation marker.
+func countErrors(messageFormat *MessageFormat, truncation *messageTruncation) int {
+	if truncation.omittedErrors > 0 {
+		return len(messageFormat.Errors) - 1
+	}
+
+	return len(messageFormat.Errors)
+}
+
+// The length of the longest detail value or error.
+func longestValue(messageFormat *MessageFormat) int {
+	result := 0
+
+	for _, value := range messageFormat.Errors {
+		result = max(result, len(value))
+	}
+
+	for _, detail := range messageFormat.Details {
+		result = max(result, len(detail.Value))
+	}
+
+	return result
+}
+
+func newMessageTruncation() *messageTruncation {
+	return &messageTruncation{
+		details: map[int]int{},
+		errors:  map[int]int{},
+	}
+}
+
+// Remove the last details, recording the total number omitted in a final marker detail.
+func omitDetails(messageFormat *MessageFormat, truncation *messageTruncation, count int) {
+	details := messageFormat.Details[:countDetails(messageFormat, truncation)]
+	count = min(count, len(details))
+	truncation.omittedDetails += count
+	details = append(details[:len(details)-count:len(details)-count], Detail{
+		Key:   truncatedKey,
+		Type:  truncatedKey,
+		Value: fmt.Sprintf(truncatedDetailsFormat, truncation.omittedDetails),
+	})
+	messageFormat.Details = details
+}
+
+// Remove the last errors, recording the total number omitted in a final marker entry.
+func omitErrors(messageFormat *MessageFormat, truncation *messageTruncation, count int) {
+	errorList := messageFormat.Errors[:countErrors(messageFormat, truncation)]
+	count = min(count, len(errorList))
+	truncation.omittedErrors += count
+	errorList = append(
+		errorList[:len(errorList)-count:len(errorList)-count],
+		fmt.Sprintf(truncatedErrorsFormat, truncation.omittedErrors),
+	)
+	messageFormat.Errors = errorList
+}
+
+// Shorten a string to at most maxLength bytes, including the truncation marker, returning the total bytes removed.
+// The cut is made on a UTF-8 boundary.  If maxLength is shorter than the marker, only the marker remains.
+// A string from which removed bytes were already truncated keeps a single marker counting all removed bytes.
+func truncateString(value string, removed int, maxLength int) (string, int) {
+	if len(value) <= maxLength {
+		return value, removed
+	}
+
+	if removed > 0 {
+		value = value[:len(value)-len(fmt.Sprintf(truncatedStringFormat, removed))]
+	}
+
+	keep := max(maxLength-len(fmt.Sprintf(truncatedStringFormat, len(value)+removed)), 0)
+	keep = min(keep, len(value))
+
+	for keep > 0 && keep < len(value) && !utf8.RuneStart(value[keep]) {
+		keep--
+	}
+
+	removed += len(value) - keep
+
+	return value[:keep] + fmt.Sprintf(truncatedStringFormat, removed), removed
+}
+
+// Truncate each error and detail value longer than maxLength, but not the markers of omitted ones.
+// A truncated detail loses its "valueRaw".
+func truncateValues(messageFormat *MessageFormat, truncation *messageTruncation, maxLength int) {
+	for index, value := range messageFormat.Errors[:countErrors(messageFormat, truncation)] {
+		messageFormat.Errors[index], truncation.errors[index] = truncateString(value, truncation.errors[index], maxLength)
+	}
+
+	for index, detail := range messageFormat.Details[:countDetails(messageFormat, truncation)] {
+		if len(detail.Value) > maxLength {
+			messageFormat.Details[index].Value, truncation.details[index] = truncateString(
+				detail.Value, truncation.details[index], maxLength)
+			messageFormat.Details[index].ValueRaw = nil
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package messenger_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCasesForMessageLimits = []struct {
+	name                string
+	messageNumber       int
+	limits              messenger.MessageLimits
+	details             []interface{}
+	expectedMessageJSON string
+}{
+	{
+		name:                "limits-0001",
+		messageNumber:       2001,
+		limits:              messenger.MessageLimits{MaxTextLength: 40},
+		details:             []interface{}{strings.Repeat("B", 20), "Jane"},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: BBBBBBBBBBB...[truncated 25 bytes]","details":[{"position":1,"type":"string","value":"BBBBBBBBBBBBBBBBBBBB"},{"position":2,"type":"string","value":"Jane"}]}`,
+	},
+	{
+		name:                "limits-0002",
+		messageNumber:       2001,
+		limits:              messenger.MessageLimits{MaxValueBytes: 30},
+		details:             []interface{}{"Bob", `{"name": "Jane Smith", "city": "Las Vegas"}`},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: Bob works with {\"name\": \"Jane Smith\", \"city\": \"Las Vegas\"}","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"{\"name\"...[truncated 36 bytes]"}]}`,
+	},
+	{
+		name:                "limits-0003",
+		messageNumber:       2001,
+		limits:              messenger.MessageLimits{MaxDetailCount: 2},
+		details:             []interface{}{"Bob", "Jane", 3, 4},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: Bob works with Jane","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"},{"key":"truncated","type":"truncated","value":"2 details omitted"}]}`,
+	},
+	{
+		name:                "limits-0004",
+		messageNumber:       2001,
+		limits:              messenger.MessageLimits{MaxMessageBytes: 220},
+		details:             []interface{}{"Bob", `{"name": "Jane"}`},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: Bob works with {\"name\": \"Jane\"}","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"{\"name\": \"Jane\"}"}]}`,
+	},
+	{
+		name:                "limits-0005",
+		messageNumber:       4001,
+		limits:              messenger.MessageLimits{MaxMessageBytes: 300},
+		details:             []interface{}{"Bob", "Jane", errors.New(strings.Repeat("x", 100))}, //nolint
+		expectedMessageJSON: `{"level":"ERROR","id":"SZSDK99994001","text":"ERROR: Bob works with Jane","errors":["xxxxxxxxxxxxxxxxxxxxxxxxxx...[truncated 74 bytes]"],"details":[{"position":1,"type":"string","value":"Bob"},{"key":"truncated","type":"truncated","value":"2 details omitted"}]}`,
+	},
+	{
+		name:                "limits-0006",
+		messageNumber:       2001,
+		limits:              messenger.MessageLimits{MaxMessageBytes: 200},
+		details:             []interface{}{strings.Repeat("é", 40), "Jane"},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: éééééééééééééééééééééé...[truncated 52 bytes]","details":[{"key":"truncated","type":"truncated","value":"2 details omitted"}]}`,
+	},
+	{
+		name:                "limits-0007",
+		messageNumber:       2001,
+		limits:              messenger.MessageLimits{MaxValueBytes: 40},
+		details:             []interface{}{"Bob", strings.Repeat("J", 30) + "...[truncated 5 bytes]"},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: Bob works with JJJJJJJJJJJJJJJJJJJJJJJJJJJJJJ...[truncated 5 bytes]","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"JJJJJJJJJJJJJJJJJ...[truncated 35 bytes]"}]}`,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicMessenger_NewJSON_messageLimits(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForMessageLimits {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			testObject, err := messenger.New(
+				getOptionMessageIDTemplate(9999),
+				getOptionMessageFields(),
+				getOptionIDMessages(),
+				messenger.OptionMessageLimits{Value: testCase.limits},
+			)
+			require.NoError(test, err)
+
+			actual := testObject.NewJSON(testCase.messageNumber, testCase.details...)
+			assert.Equal(test, testCase.expectedMessageJSON, actual)
+			assert.Equal(test, actual, testObject.NewJSON(testCase.messageNumber, testCase.details...))
+
+			if testCase.limits.MaxMessageBytes > 0 {
+				assert.LessOrEqual(test, len(actual), testCase.limits.MaxMessageBytes)
+			}
+		})
+	}
+}
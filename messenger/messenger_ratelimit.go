@@ -0,0 +1,399 @@
+package messenger
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A RateLimiter suppresses repetitive messages.
+// It is safe for concurrent use, so one RateLimiter may be shared by several messengers and slog handlers.
+// When Interval is set, windows are ended by a timer, so summaries are reported even for keys that fall quiet.
+type RateLimiter struct {
+	handlers  []slog.Handler // Handlers given to Handler(), which receive summary records.
+	lastSweep time.Time
+	mutex     sync.Mutex
+	rateLimit RateLimit
+	timer     *time.Timer // Pending sweep, if any windows are open.
+	windows   map[string]*rateWindow
+}
+
+// The counts for one key during one window.
+type rateWindow struct {
+	allowed int
+	id      string
+	seen    int
+	start   time.Time
+}
+
+// A slog.Handler which applies a RateLimiter before passing records to the next handler.
+type rateLimitHandler struct {
+	details interface{} // "details" given to WithAttrs(), if any.
+	id      string      // "id" given to WithAttrs(), if any.
+	limiter *RateLimiter
+	next    slog.Handler
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Message of the slog record emitted by RateLimiter.Handler() for a RateLimitSummary.
+const RateLimitSummaryMessage = "Messages suppressed by rate limit"
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewRateLimiter function creates a RateLimiter.
+
+Input
+  - rateLimit: Burst, Interval, and sampling configuration.
+
+Output
+  - A RateLimiter to be used with OptionRateLimiter or RateLimiter.Handler().
+*/
+func NewRateLimiter(rateLimit RateLimit) *RateLimiter {
+	if rateLimit.Clock == nil {
+		rateLimit.Clock = time.Now
+	}
+
+	return &RateLimiter{
+		rateLimit: rateLimit,
+		windows:   map[string]*rateWindow{},
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Allow method counts a message and determines if it should be emitted.
+
+Input
+  - id: The message id, such as the "id" field of the message.
+  - details: The message details.  Used only when RateLimit.ByDetails is set.
+
+Output
+  - true if the message should be emitted; false if it is suppressed.
+*/
+func (limiter *RateLimiter) Allow(id string, details ...interface{}) bool {
+	result, summaries := limiter.allow(id, details)
+	_ = limiter.report(context.Background(), summaries)
+
+	return result
+}
+
+/*
+The Flush method ends all windows, reporting suppressed counts to RateLimit.OnSummary and to handlers
+created by Handler().
+Call it on shutdown so that no suppressed counts are lost.
+
+Output
+  - The summaries of windows that suppressed messages, ordered by key.
+*/
+func (limiter *RateLimiter) Flush() []RateLimitSummary {
+	limiter.mutex.Lock()
+	now := limiter.rateLimit.Clock()
+	summaries := limiter.sweep(now, true)
+
+	if limiter.timer != nil {
+		limiter.timer.Stop()
+		limiter.timer = nil
+	}
+	limiter.mutex.Unlock()
+
+	_ = limiter.report(context.Background(), summaries)
+
+	return summaries
+}
+
+/*
+The Handler method wraps a slog.Handler so that records are rate limited by their "id" attribute.
+Records without an "id" attribute are not limited.
+When a window that suppressed messages ends, whether counted by this handler, by Allow(), or by a messenger
+using OptionRateLimiter, a RateLimitSummaryMessage record is passed to the next handler.
+
+Input
+  - next: The handler that receives allowed records.
+
+Output
+  - A slog.Handler.
+*/
+func (limiter *RateLimiter) Handler(next slog.Handler) slog.Handler {
+	limiter.mutex.Lock()
+	limiter.handlers = append(limiter.handlers, next)
+	limiter.mutex.Unlock()
+
+	return &rateLimitHandler{
+		limiter: limiter,
+		next:    next,
+	}
+}
+
+// Enabled implements slog.Handler.
+func (handler *rateLimitHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return handler.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler.
+func (handler *rateLimitHandler) Handle(ctx context.Context, record slog.Record) error {
+	id := handler.id
+	details := handler.details
+
+	record.Attrs(func(attr slog.Attr) bool {
+		switch attr.Key {
+		case "id":
+			id = attr.Value.String()
+		case "details":
+			details = attr.Value.Any()
+		}
+
+		return true
+	})
+
+	if len(id) == 0 {
+		return handler.next.Handle(ctx, record) //nolint:wrapcheck
+	}
+
+	allowed, summaries := handler.limiter.allow(id, []interface{}{details})
+
+	err := handler.limiter.report(ctx, summaries)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return nil
+	}
+
+	return handler.next.Handle(ctx, record) //nolint:wrapcheck
+}
+
+// WithAttrs implements slog.Handler.
+func (handler *rateLimitHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	result := *handler
+	result.next = handler.next.WithAttrs(attrs)
+
+	for _, attr := range attrs {
+		switch attr.Key {
+		case "id":
+			result.id = attr.Value.String()
+		case "details":
+			result.details = attr.Value.Any()
+		}
+	}
+
+	return &result
+}
+
+// WithGroup implements slog.Handler.
+func (handler *rateLimitHandler) WithGroup(name string) slog.Handler {
+	result := *handler
+	result.next = handler.next.WithGroup(name)
+
+	return &result
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Count a message.  Returns whether it is allowed and the summaries of windows that ended.
+func (limiter *RateLimiter) allow(id string, details []interface{}) (bool, []RateLimitSummary) {
+	key := limiter.key(id, details)
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	now := limiter.rateLimit.Clock()
+
+	var summaries []RateLimitSummary
+	if limiter.rateLimit.Interval > 0 && now.Sub(limiter.lastSweep) >= limiter.rateLimit.Interval {
+		summaries = limiter.sweep(now, false)
+		limiter.lastSweep = now
+	}
+
+	window, isOK := limiter.windows[key]
+	if isOK && limiter.rateLimit.Interval > 0 && !now.Before(window.start.Add(limiter.rateLimit.Interval)) {
+		summaries = append(summaries, limiter.endWindow(key, window, window.start.Add(limiter.rateLimit.Interval))...)
+		isOK = false
+	}
+
+	if !isOK {
+		window = &rateWindow{id: id, start: now}
+		limiter.windows[key] = window
+
+		if limiter.rateLimit.Interval > 0 && limiter.timer == nil {
+			limiter.timer = time.AfterFunc(limiter.rateLimit.Interval, limiter.tick)
+		}
+	}
+
+	window.seen++
+
+	result := window.seen <= limiter.rateLimit.Burst ||
+		(limiter.rateLimit.SampleEvery > 0 && (window.seen-limiter.rateLimit.Burst)%limiter.rateLimit.SampleEvery == 0)
+	if result {
+		window.allowed++
+	}
+
+	return result, summaries
+}
+
+// The key of a message.  With ByDetails, the details are hashed as formatted by "%v",
+// so Secret() and PII() values do not distinguish keys.
+// Details which set fields or options, such as MessageTime and MessageDuration, are not part of the key.
+func (limiter *RateLimiter) key(id string, details []interface{}) string {
+	if !limiter.rateLimit.ByDetails {
+		return id
+	}
+
+	keyDetails := make([]interface{}, 0, len(details))
+
+	for _, detail := range details {
+		switch detail.(type) {
+		case OptionMessageField, OptionMessageFields:
+			continue
+		}
+
+		if !isControlDetail(detail) {
+			keyDetails = append(keyDetails, detail)
+		}
+	}
+
+	hash := fnv.New64a()
+	_, _ = fmt.Fprintf(hash, "%v", keyDetails)
+
+	return fmt.Sprintf("%s/%016x", id, hash.Sum64())
+}
+
+// Send summaries to RateLimit.OnSummary and, as RateLimitSummaryMessage records, to the handlers given to
+// Handler().  Returns the first error of a handler.  Must not be called while holding the mutex.
+func (limiter *RateLimiter) report(ctx context.Context, summaries []RateLimitSummary) error {
+	var result error
+
+	if len(summaries) == 0 {
+		return result
+	}
+
+	limiter.mutex.Lock()
+	handlers := slices.Clone(limiter.handlers)
+	limiter.mutex.Unlock()
+
+	for _, summary := range summaries {
+		if limiter.rateLimit.OnSummary != nil {
+			limiter.rateLimit.OnSummary(summary)
+		}
+
+		summaryRecord := slog.NewRecord(summary.End, LevelWarnSlog, RateLimitSummaryMessage, 0)
+		summaryRecord.AddAttrs(
+			slog.String("id", summary.ID),
+			slog.Int("suppressed", summary.Suppressed),
+			slog.Int("allowed", summary.Allowed),
+			slog.Time("start", summary.Start),
+		)
+
+		for _, handler := range handlers {
+			if !handler.Enabled(ctx, LevelWarnSlog) {
+				continue
+			}
+
+			err := handler.Handle(ctx, summaryRecord.Clone())
+			if err != nil && result == nil {
+				result = err
+			}
+		}
+	}
+
+	return result
+}
+
+// Remove ended windows, or all windows if flushing.  Returns summaries of those that suppressed messages.
+// Must be called while holding the mutex.
+func (limiter *RateLimiter) sweep(now time.Time, flush bool) []RateLimitSummary {
+	var result []RateLimitSummary
+
+	for _, key := range sortedKeys(limiter.windows) {
+		window := limiter.windows[key]
+		end := now
+
+		if !flush {
+			end = window.start.Add(limiter.rateLimit.Interval)
+			if now.Before(end) {
+				continue
+			}
+		}
+
+		result = append(result, limiter.endWindow(key, window, end)...)
+	}
+
+	return result
+}
+
+// End the windows whose Interval has passed and report their summaries.  Called by the timer.
+// The timer is restarted for the earliest end of the remaining windows.
+func (limiter *RateLimiter) tick() {
+	limiter.mutex.Lock()
+	now := limiter.rateLimit.Clock()
+	summaries := limiter.sweep(now, false)
+	limiter.lastSweep = now
+	limiter.timer = nil
+
+	if len(limiter.windows) > 0 {
+		next := limiter.rateLimit.Interval
+
+		for _, window := range limiter.windows {
+			next = min(next, window.start.Add(limiter.rateLimit.Interval).Sub(now))
+		}
+
+		limiter.timer = time.AfterFunc(max(next, time.Millisecond), limiter.tick)
+	}
+	limiter.mutex.Unlock()
+
+	_ = limiter.report(context.Background(), summaries)
+}
+
+// Remove a window.  Returns its summary if it suppressed messages.  Must be called while holding the mutex.
+func (limiter *RateLimiter) endWindow(key string, window *rateWindow, end time.Time) []RateLimitSummary {
+	delete(limiter.windows, key)
+
+	if window.seen == window.allowed {
+		return nil
+	}
+
+	return []RateLimitSummary{{
+		Allowed:    window.allowed,
+		End:        end,
+		ID:         window.id,
+		Key:        key,
+		Start:      window.start,
+		Suppressed: window.seen - window.allowed,
+	}}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func sortedKeys[V any](aMap map[string]V) []string {
+	result := make([]string, 0, len(aMap))
+	for key := range aMap {
+		result = append(result, key)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
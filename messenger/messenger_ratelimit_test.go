@@ -0,0 +1,231 @@
+package messenger_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+// A clock that advances only when told to.
+type testClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+// A writer which sends each write to a channel.
+type testChannelWriter chan string
+
+var testCasesForRateLimiter = []struct {
+	name            string
+	rateLimit       messenger.RateLimit
+	messages        []string
+	expectedAllowed string
+}{
+	{
+		name:            "ratelimit-0001",
+		rateLimit:       messenger.RateLimit{Burst: 2},
+		messages:        []string{"a", "a", "a", "b", "a", "b"},
+		expectedAllowed: "aa b b",
+	},
+	{
+		name:            "ratelimit-0002",
+		rateLimit:       messenger.RateLimit{Burst: 1, SampleEvery: 3},
+		messages:        []string{"a", "a", "a", "a", "a", "a", "a", "a"},
+		expectedAllowed: "a  a  a ",
+	},
+	{
+		name:            "ratelimit-0003",
+		rateLimit:       messenger.RateLimit{Burst: 1, Interval: time.Second},
+		messages:        []string{"a", "a", "+", "a", "a", "+", "+", "a"},
+		expectedAllowed: "a a a",
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRateLimiter_Allow(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForRateLimiter {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			clock := &testClock{now: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)}
+			testCase.rateLimit.Clock = clock.Now
+			limiter := messenger.NewRateLimiter(testCase.rateLimit)
+			actual := ""
+
+			for _, message := range testCase.messages {
+				switch {
+				case message == "+":
+					clock.Advance(time.Second)
+				case limiter.Allow(message):
+					actual += message
+				default:
+					actual += " "
+				}
+			}
+
+			assert.Equal(test, testCase.expectedAllowed, actual)
+		})
+	}
+}
+
+func TestRateLimiter_Flush(test *testing.T) {
+	test.Parallel()
+
+	summaries := []messenger.RateLimitSummary{}
+	clock := &testClock{now: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	start := clock.Now()
+	limiter := messenger.NewRateLimiter(messenger.RateLimit{
+		Burst:     1,
+		ByDetails: true,
+		Clock:     clock.Now,
+		Interval:  time.Minute,
+		OnSummary: func(summary messenger.RateLimitSummary) { summaries = append(summaries, summary) },
+	})
+
+	assert.True(test, limiter.Allow("SZSDK99993001", "Bob"))
+	assert.False(test, limiter.Allow("SZSDK99993001", "Bob"))
+	assert.False(test, limiter.Allow("SZSDK99993001", "Bob"))
+	assert.True(test, limiter.Allow("SZSDK99993001", "Jane"))
+
+	clock.Advance(time.Second)
+
+	flushed := limiter.Flush()
+	require.Len(test, flushed, 1)
+	assert.Equal(test, flushed, summaries)
+	assert.Equal(test, "SZSDK99993001", flushed[0].ID)
+	assert.True(test, strings.HasPrefix(flushed[0].Key, "SZSDK99993001/"))
+	assert.Equal(test, 1, flushed[0].Allowed)
+	assert.Equal(test, 2, flushed[0].Suppressed)
+	assert.Equal(test, start, flushed[0].Start)
+	assert.Equal(test, start.Add(time.Second), flushed[0].End)
+	assert.Empty(test, limiter.Flush())
+}
+
+func TestRateLimiter_Allow_byDetails(test *testing.T) {
+	test.Parallel()
+
+	limiter := messenger.NewRateLimiter(messenger.RateLimit{Burst: 1, ByDetails: true})
+
+	assert.True(test, limiter.Allow("SZSDK99993001", "Bob", getTimestamp(), messenger.MessageDuration{Value: 1}))
+	assert.False(test, limiter.Allow("SZSDK99993001", "Bob", messenger.MessageTime{Value: time.Now()}, messenger.MessageDuration{Value: 2}))
+	assert.True(test, limiter.Allow("SZSDK99993001", "Jane", getTimestamp()))
+}
+
+func TestRateLimiter_timer(test *testing.T) {
+	test.Parallel()
+
+	summaries := make(chan messenger.RateLimitSummary, 1)
+	records := make(chan string, 1)
+	limiter := messenger.NewRateLimiter(messenger.RateLimit{
+		Burst:     1,
+		Interval:  10 * time.Millisecond,
+		OnSummary: func(summary messenger.RateLimitSummary) { summaries <- summary },
+	})
+	_ = limiter.Handler(slog.NewJSONHandler(testChannelWriter(records), nil))
+
+	testObject, err := messenger.New(getOptionIDMessages(), messenger.OptionRateLimiter{Value: limiter})
+	require.NoError(test, err)
+
+	// The key falls quiet after the suppressed message, so only the timer can report it.
+
+	assert.NotEmpty(test, testObject.NewJSON(3001, "Bob", "Jane"))
+	assert.Empty(test, testObject.NewJSON(3001, "Bob", "Jane"))
+
+	select {
+	case summary := <-summaries:
+		assert.Equal(test, "3001", summary.ID)
+		assert.Equal(test, 1, summary.Suppressed)
+	case <-time.After(5 * time.Second):
+		require.Fail(test, "no summary")
+	}
+
+	select {
+	case record := <-records:
+		assert.Contains(test, record, `"msg":"Messages suppressed by rate limit","id":"3001","suppressed":1,"allowed":1`)
+	case <-time.After(5 * time.Second):
+		require.Fail(test, "no summary record")
+	}
+}
+
+func TestBasicMessenger_NewJSON_rateLimiter(test *testing.T) {
+	test.Parallel()
+
+	testObject, err := messenger.New(
+		messenger.OptionMessageFields{Value: []string{"id", "text"}},
+		getOptionIDMessages(),
+		messenger.OptionRateLimiter{Value: messenger.NewRateLimiter(messenger.RateLimit{Burst: 1})},
+	)
+	require.NoError(test, err)
+
+	assert.Equal(test, `{"id":"3001","text":"WARN: Bob works with Jane"}`, testObject.NewJSON(3001, "Bob", "Jane"))
+	assert.Empty(test, testObject.NewJSON(3001, "Bob", "Jane"))
+	assert.Equal(test, `{"id":"3002","text":"WARN: Bob works with Jane"}`, testObject.NewJSON(3002, "Bob", "Jane"))
+	assert.Equal(test, `{"id":"3001","text":"WARN: Bob works with Jane"}`, testObject.NewError(3001, "Bob", "Jane").Error())
+}
+
+func TestRateLimiter_Handler(test *testing.T) {
+	test.Parallel()
+
+	var buffer bytes.Buffer
+
+	clock := &testClock{now: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := messenger.NewRateLimiter(messenger.RateLimit{Burst: 1, Clock: clock.Now, Interval: time.Minute})
+	logger := slog.New(limiter.Handler(slog.NewJSONHandler(&buffer, nil)))
+
+	testObject, err := messenger.New(getOptionMessageFields(), getOptionIDMessages())
+	require.NoError(test, err)
+
+	for range 3 {
+		message, keyValuePairs := testObject.NewSlog(3001, "Bob", "Jane")
+		logger.Warn(message, keyValuePairs...)
+	}
+
+	logger.Info("no id")
+	clock.Advance(time.Minute)
+
+	message, keyValuePairs := testObject.NewSlog(3001, "Bob", "Jane")
+	logger.Warn(message, keyValuePairs...)
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	require.Len(test, lines, 4)
+	assert.Contains(test, lines[0], `"msg":"WARN: Bob works with Jane","level":"WARN","id":"3001"`)
+	assert.Contains(test, lines[1], `"msg":"no id"`)
+	assert.Contains(test, lines[2], `"msg":"Messages suppressed by rate limit","id":"3001","suppressed":2,"allowed":1`)
+	assert.Contains(test, lines[3], `"msg":"WARN: Bob works with Jane","level":"WARN","id":"3001"`)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions - names begin with lowercase letter
+// ----------------------------------------------------------------------------
+
+func (writer testChannelWriter) Write(buffer []byte) (int, error) {
+	writer <- string(buffer)
+
+	return len(buffer), nil
+}
+
+func (clock *testClock) Advance(duration time.Duration) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	clock.now = clock.now.Add(duration)
+}
+
+func (clock *testClock) Now() time.Time {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+
+	return clock.now
+}
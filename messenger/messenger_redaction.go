@@ -0,0 +1,519 @@
+package messenger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A detail that has been redacted as a whole.
+// It replaces the original value so that "text" and "details" never see the original.
+type redactedValue struct {
+	detailType string          // The "type" the original value would have had.
+	dropped    bool            // If true, omit from "details".
+	raw        json.RawMessage // The "valueRaw", if the replacement is JSON.
+	text       string          // Replacement text.
+}
+
+// The detail holding a value being redacted, against which the Position and Type of rules are matched.
+type redactionTarget struct {
+	keyed    bool        // If true, only rules with a Key apply; otherwise only rules without one.
+	position int32       // Position of the detail, or 0 for text outside of details, such as "text" and "errors".
+	value    interface{} // The detail as given to the messenger.
+}
+
+// An error detail that has been redacted as a whole.
+type redactedError struct {
+	text string
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// Error implements the error interface.
+func (redacted redactedError) Error() string {
+	return redacted.text
+}
+
+// Format implements fmt.Formatter so the replacement is used regardless of the verb in the template.
+func (redacted redactedError) Format(state fmt.State, _ rune) {
+	_, _ = state.Write([]byte(redacted.text))
+}
+
+// Format implements fmt.Formatter so the replacement is used regardless of the verb in the template.
+func (redacted redactedValue) Format(state fmt.State, _ rune) {
+	_, _ = state.Write([]byte(redacted.text))
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Redact a string according to the strategy.
+func (messenger *BasicMessenger) redact(value string, strategy RedactionStrategy) string {
+	switch strategy {
+	case RedactHash:
+		digest := sha256.Sum256([]byte(messenger.redactionSalt + value))
+
+		return "sha256:" + hex.EncodeToString(digest[:8])
+	case RedactDrop:
+		return ""
+	default:
+		return RedactionMask
+	}
+}
+
+// Resolve Sensitive values, replace details matched as a whole by Position or Type rules,
+// and apply rules with a Key to the members of details, so that "text" and "reason" never see the originals.
+func (messenger *BasicMessenger) redactDetails(details []interface{}) []interface{} {
+	result := make([]interface{}, len(details))
+	position := int32(0)
+	hasKeyRules := messenger.hasKeyRules()
+
+	for index, value := range details {
+		if sensitive, isSensitive := value.(Sensitive); isSensitive {
+			value = messenger.resolveSensitive(sensitive)
+		}
+
+		result[index] = value
+
+		if _, isRedacted := value.(redactedValue); isRedacted {
+			position++
+
+			continue
+		}
+
+		if _, isRedacted := value.(redactedError); isRedacted {
+			position++
+
+			continue
+		}
+
+		if isControlDetail(value) {
+			continue
+		}
+
+		position++
+
+		if hasKeyRules {
+			result[index] = messenger.redactMembers(value, redactionTarget{keyed: true, position: position, value: value})
+		}
+
+		for _, rule := range messenger.redactionRules {
+			if !rule.matchesDetail(position, value) {
+				continue
+			}
+
+			// A Pattern applies to the text of the detail with its members already redacted.
+
+			formatted := messenger.formatDetail(value)
+			original := messenger.redactedText(result[index], formatted)
+			text := messenger.redact(original, rule.Strategy)
+
+			if rule.Pattern != nil {
+				text = rule.Pattern.ReplaceAllStringFunc(original, func(match string) string {
+					return messenger.redact(match, rule.Strategy)
+				})
+				if text == original {
+					continue
+				}
+			}
+
+			switch {
+			case rule.Strategy == RedactDrop && rule.Pattern == nil:
+				result[index] = redactedValue{detailType: formatted.Type, dropped: true, text: RedactionMask}
+			case isError(value):
+				result[index] = redactedError{text: text}
+			default:
+				result[index] = redactedValue{detailType: formatted.Type, text: text}
+			}
+
+			break
+		}
+
+		if hasHiddenSensitive(reflect.ValueOf(result[index]), map[visit]bool{}) {
+			result[index] = messenger.hideSensitive(result[index])
+		}
+	}
+
+	return result
+}
+
+// Apply Pattern rules without a Key to a message that has already been populated.  Rules with a Key were applied
+// by redactDetails().  The values are the details given to the messenger, less control details, in the order of their positions.
+func (messenger *BasicMessenger) redactMessageFormat(messageFormat *MessageFormat, values []interface{}) {
+	if len(messenger.redactionRules) == 0 {
+		return
+	}
+
+	messageFormat.Text = messenger.redactPatterns(messageFormat.Text, "", redactionTarget{})
+	messageFormat.Reason = messenger.redactPatterns(messageFormat.Reason, "", redactionTarget{})
+
+	for index, value := range messageFormat.Errors {
+		messageFormat.Errors[index] = messenger.redactString(value)
+	}
+
+	details := make([]Detail, 0, len(messageFormat.Details))
+
+	for _, detail := range messageFormat.Details {
+		target := redactionTarget{position: detail.Position}
+		if detail.Position > 0 && int(detail.Position) <= len(values) {
+			target.value = values[detail.Position-1]
+		}
+
+		redactedDetail, keep := messenger.redactDetail(detail, target)
+		if keep {
+			details = append(details, redactedDetail)
+		}
+	}
+
+	if messageFormat.Details != nil {
+		messageFormat.Details = details
+	}
+}
+
+// Apply Pattern rules without a Key to a single detail.
+func (messenger *BasicMessenger) redactDetail(detail Detail, target redactionTarget) (Detail, bool) {
+	if rawJSON, isRawJSON := detail.ValueRaw.(json.RawMessage); isRawJSON {
+		redactedJSON, changed := messenger.redactJSON(rawJSON, target)
+		if changed {
+			detail.ValueRaw = json.RawMessage(redactedJSON)
+			detail.Value = string(redactedJSON)
+		}
+
+		return detail, true
+	}
+
+	redactedValue := messenger.redactPatterns(detail.Value, detail.Key, target)
+	if redactedValue != detail.Value {
+		detail.Value = redactedValue
+		detail.ValueRaw = nil
+	}
+
+	return detail, true
+}
+
+// Apply rules to JSON text.  Returns the new text and whether anything changed.
+func (messenger *BasicMessenger) redactJSON(rawJSON []byte, target redactionTarget) ([]byte, bool) {
+	var document interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(rawJSON))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&document)
+	if err != nil {
+		return rawJSON, false
+	}
+
+	document, changed := messenger.redactJSONValue(document, "", target)
+	if !changed {
+		return rawJSON, false
+	}
+
+	var result bytes.Buffer
+
+	encoder := json.NewEncoder(&result)
+	encoder.SetEscapeHTML(false)
+
+	err = encoder.Encode(document)
+	if err != nil {
+		return rawJSON, false
+	}
+
+	return bytes.TrimSpace(result.Bytes()), true
+}
+
+// Walk a decoded JSON value.  The key is the name of the object member holding the value, if any.
+func (messenger *BasicMessenger) redactJSONValue(value interface{}, key string, target redactionTarget) (interface{}, bool) {
+	changed := false
+
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for memberKey, memberValue := range typedValue {
+			redactedValue, keep, memberChanged := messenger.redactJSONMember(memberKey, memberValue, target)
+			if !keep {
+				delete(typedValue, memberKey)
+			} else {
+				typedValue[memberKey] = redactedValue
+			}
+
+			changed = changed || memberChanged
+		}
+	case []interface{}:
+		for index, element := range typedValue {
+			redactedElement, elementChanged := messenger.redactJSONValue(element, key, target)
+			typedValue[index] = redactedElement
+			changed = changed || elementChanged
+		}
+	case string:
+		redactedString := messenger.redactPatterns(typedValue, key, target)
+
+		return redactedString, redactedString != typedValue
+	case json.Number:
+		redactedString := messenger.redactPatterns(typedValue.String(), key, target)
+		if redactedString != typedValue.String() {
+			return redactedString, true
+		}
+	}
+
+	return value, changed
+}
+
+// Redact an object member.  Returns the new value, whether to keep the member, and whether anything changed.
+func (messenger *BasicMessenger) redactJSONMember(
+	key string,
+	value interface{},
+	target redactionTarget,
+) (interface{}, bool, bool) {
+	if rule, isMatched := messenger.matchKeyRule(key, target); isMatched {
+		if rule.Strategy == RedactDrop {
+			return nil, false, true
+		}
+
+		return messenger.redact(jsonValueAsString(value), rule.Strategy), true, true
+	}
+
+	redactedValue, changed := messenger.redactJSONValue(value, key, target)
+
+	return redactedValue, true, changed
+}
+
+/*
+Apply rules with a Key to the members of a detail: the entries of a map[string]string,
+and the members of JSON text in a string or error, or of the JSON a value is formatted as.
+
+Input
+  - value: A detail as given to the messenger.
+  - target: The detail, with keyed set.
+
+Output
+  - The value to use in place of the detail.  The detail itself if nothing was redacted.
+*/
+func (messenger *BasicMessenger) redactMembers(value interface{}, target redactionTarget) interface{} {
+	switch typedValue := value.(type) {
+	case string:
+		if !isJSON(typedValue) {
+			return value
+		}
+
+		redactedJSON, changed := messenger.redactJSON([]byte(cleanTabsAndNewlines(typedValue)), target)
+		if changed {
+			return string(redactedJSON)
+		}
+	case error:
+		text := cleanErrorString(typedValue)
+		if !isJSON(text) {
+			return value
+		}
+
+		redactedJSON, changed := messenger.redactJSON([]byte(text), target)
+		if changed {
+			return redactedError{text: string(redactedJSON)}
+		}
+	case map[string]string:
+		result := make(map[string]string, len(typedValue))
+		changed := false
+
+		for key, member := range typedValue {
+			redactedMember, keep := messenger.redactMapEntry(key, member, target)
+			if keep {
+				result[key] = redactedMember
+			}
+
+			changed = changed || !keep || redactedMember != member
+		}
+
+		if changed {
+			return result
+		}
+	default:
+		formatted := messenger.formatDetail(value)
+
+		rawJSON, isRawJSON := formatted.ValueRaw.(json.RawMessage)
+		if !isRawJSON {
+			return value
+		}
+
+		redactedJSON, changed := messenger.redactJSON(rawJSON, target)
+		if changed {
+			return redactedValue{detailType: formatted.Type, raw: json.RawMessage(redactedJSON), text: string(redactedJSON)}
+		}
+	}
+
+	return value
+}
+
+// Apply rules with a Key to an entry of a map[string]string.  Returns the new value and whether to keep the entry.
+func (messenger *BasicMessenger) redactMapEntry(key string, value string, target redactionTarget) (string, bool) {
+	if rule, isMatched := messenger.matchKeyRule(key, target); isMatched {
+		return messenger.redact(value, rule.Strategy), rule.Strategy != RedactDrop
+	}
+
+	if isJSON(value) {
+		redactedJSON, _ := messenger.redactJSON([]byte(cleanTabsAndNewlines(value)), target)
+
+		return string(redactedJSON), true
+	}
+
+	return messenger.redactPatterns(value, key, target), true
+}
+
+// The text of a detail after redactMembers(), given the formatted original.
+func (messenger *BasicMessenger) redactedText(value interface{}, formatted Detail) string {
+	switch typedValue := value.(type) {
+	case redactedValue:
+		return typedValue.text
+	case redactedError:
+		return typedValue.text
+	case string, map[string]string:
+		return messenger.formatDetail(value).Value
+	default:
+		return formatted.Value
+	}
+}
+
+// Apply Pattern rules to a string.  The key, if any, is the map or JSON member key holding the string.
+// Only rules with a Key apply if the target is keyed, and only rules without one otherwise.
+// Pattern rules without a Key but with a Position or Type were applied to whole details by redactDetails().
+func (messenger *BasicMessenger) redactPatterns(value string, key string, target redactionTarget) string {
+	result := value
+
+	for _, rule := range messenger.redactionRules {
+		if rule.Pattern == nil || (len(rule.Key) > 0) != target.keyed ||
+			(len(rule.Key) == 0 && (rule.Position != 0 || len(rule.Type) > 0)) {
+			continue
+		}
+
+		if len(rule.Key) > 0 && !rule.matchesKey(key) {
+			continue
+		}
+
+		if !rule.matchesTarget(target) {
+			continue
+		}
+
+		result = rule.Pattern.ReplaceAllStringFunc(result, func(match string) string {
+			return messenger.redact(match, rule.Strategy)
+		})
+	}
+
+	return result
+}
+
+// Apply Pattern rules without a Key to a string that may be JSON, such as an error from NewError().
+func (messenger *BasicMessenger) redactString(value string) string {
+	if isJSON(value) {
+		redactedJSON, _ := messenger.redactJSON([]byte(cleanTabsAndNewlines(value)), redactionTarget{})
+
+		return string(redactedJSON)
+	}
+
+	return messenger.redactPatterns(value, "", redactionTarget{})
+}
+
+// Determine if any rule has a Key.
+func (messenger *BasicMessenger) hasKeyRules() bool {
+	for _, rule := range messenger.redactionRules {
+		if len(rule.Key) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The first rule with a Key and no Pattern that matches a map or JSON member key, if the target is keyed.
+func (messenger *BasicMessenger) matchKeyRule(key string, target redactionTarget) (RedactionRule, bool) {
+	if !target.keyed {
+		return RedactionRule{}, false
+	}
+
+	for _, rule := range messenger.redactionRules {
+		if len(rule.Key) > 0 && rule.Pattern == nil && rule.matchesKey(key) && rule.matchesTarget(target) {
+			return rule, true
+		}
+	}
+
+	return RedactionRule{}, false
+}
+
+// Determine if a rule, having no Key, identifies a whole detail by Position or Type.
+func (rule RedactionRule) matchesDetail(position int32, value interface{}) bool {
+	if len(rule.Key) > 0 || (rule.Position == 0 && len(rule.Type) == 0) {
+		return false
+	}
+
+	return rule.matchesTarget(redactionTarget{position: position, value: value})
+}
+
+func (rule RedactionRule) matchesKey(key string) bool {
+	return len(key) > 0 && strings.EqualFold(rule.Key, key)
+}
+
+// Determine if the Position and Type of a rule, if any, match the detail holding a value.
+// Text outside of details matches only rules with neither.
+func (rule RedactionRule) matchesTarget(target redactionTarget) bool {
+	if rule.Position != 0 && rule.Position != target.position {
+		return false
+	}
+
+	switch {
+	case len(rule.Type) == 0:
+		return true
+	case target.position == 0:
+		return false
+	case rule.Type == "error":
+		return isError(target.value)
+	default:
+		return rule.Type == reflectTypeName(target.value)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Determine if a detail overrides a field or option rather than becoming part of "details".
+func isControlDetail(value interface{}) bool {
+	switch value.(type) {
+	case MessageCode, MessageDuration, MessageHelp, MessageID, MessageLevel, MessageLocation, MessageReason,
+		MessageRemediation, MessageStatus, MessageText, MessageTime, OptionCallerSkip, time.Duration:
+		return true
+	default:
+		return false
+	}
+}
+
+// The text of a decoded JSON value; strings and numbers without JSON quoting.
+func jsonValueAsString(value interface{}) string {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue
+	case json.Number:
+		return typedValue.String()
+	default:
+		result, _ := json.Marshal(typedValue)
+
+		return string(result)
+	}
+}
+
+func isError(value interface{}) bool {
+	_, result := value.(error)
+
+	return result
+}
+
+func reflectTypeName(value interface{}) string {
+	return fmt.Sprintf("%+v", reflect.TypeOf(value))
+}
@@ -0,0 +1,184 @@
+package messenger_test
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSSN struct {
+	SSN string `json:"ssn"`
+}
+
+var ssnPattern = regexp.MustCompile(`\d{3}-\d{2}-\d{4}`)
+
+var testCasesForRedaction = []struct {
+	name                string
+	messageNumber       int
+	rules               []messenger.RedactionRule
+	details             []interface{}
+	expectedMessageJSON string
+}{
+	{
+		name:                "redaction-0001",
+		messageNumber:       2001,
+		rules:               []messenger.RedactionRule{{Position: 2}},
+		details:             []interface{}{"Bob", "Jane"},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: Bob works with [REDACTED]","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"[REDACTED]"}]}`,
+	},
+	{
+		name:                "redaction-0002",
+		messageNumber:       2001,
+		rules:               []messenger.RedactionRule{{Position: 1, Strategy: messenger.RedactDrop}},
+		details:             []interface{}{"Bob", "Jane"},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: [REDACTED] works with Jane","details":[{"position":2,"type":"string","value":"Jane"}]}`,
+	},
+	{
+		name:                "redaction-0003",
+		messageNumber:       2001,
+		rules:               []messenger.RedactionRule{{Type: "int", Strategy: messenger.RedactHash}},
+		details:             []interface{}{"Bob", 123456789},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: Bob works with sha256:e0b823f60b2bcdf7","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"integer","value":"sha256:e0b823f60b2bcdf7"}]}`,
+	},
+	{
+		name:                "redaction-0004",
+		messageNumber:       2001,
+		rules:               []messenger.RedactionRule{{Key: "ssn"}},
+		details:             []interface{}{"Bob", "Jane", map[string]string{"SSN": "123-45-6789"}},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: Bob works with Jane","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"},{"key":"SSN","position":3,"type":"map[string]string","value":"[REDACTED]"}]}`,
+	},
+	{
+		name:                "redaction-0005",
+		messageNumber:       2001,
+		rules:               []messenger.RedactionRule{{Key: "ssn"}, {Key: "dob", Strategy: messenger.RedactDrop}},
+		details:             []interface{}{"Bob", "Jane", `{"name": "Bob", "ids": [{"ssn": "123-45-6789", "dob": "1970"}]}`},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: Bob works with Jane","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"},{"position":3,"type":"string","value":"{\"ids\":[{\"ssn\":\"[REDACTED]\"}],\"name\":\"Bob\"}","valueRaw":{"ids":[{"ssn":"[REDACTED]"}],"name":"Bob"}}]}`,
+	},
+	{
+		name:                "redaction-0006",
+		messageNumber:       4001,
+		rules:               []messenger.RedactionRule{{Pattern: ssnPattern}},
+		details:             []interface{}{"Bob (123-45-6789)", "Jane", errors.New("bad ssn 987-65-4321")}, //nolint
+		expectedMessageJSON: `{"level":"ERROR","id":"SZSDK99994001","text":"ERROR: Bob ([REDACTED]) works with Jane","errors":["bad ssn [REDACTED]"],"details":[{"position":1,"type":"string","value":"Bob ([REDACTED])"},{"position":2,"type":"string","value":"Jane"},{"position":3,"type":"error","value":"bad ssn [REDACTED]"}]}`,
+	},
+	{
+		name:                "redaction-0007",
+		messageNumber:       4001,
+		rules:               []messenger.RedactionRule{{Type: "error"}},
+		details:             []interface{}{"Bob", "Jane", errors.New("secret")}, //nolint
+		expectedMessageJSON: `{"level":"ERROR","id":"SZSDK99994001","text":"ERROR: Bob works with Jane","errors":["[REDACTED]"],"details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"},{"position":3,"type":"error","value":"[REDACTED]"}]}`,
+	},
+	{
+		name:                "redaction-0008",
+		messageNumber:       4001,
+		rules:               []messenger.RedactionRule{{Key: "ssn", Pattern: regexp.MustCompile(`^\d{3}`)}},
+		details:             []interface{}{"Bob", "Jane", errors.New(`{"id": "SZSDK99994002", "ssn": "123-45-6789", "other": "123"}`)}, //nolint
+		expectedMessageJSON: `{"level":"ERROR","id":"SZSDK99994001","text":"ERROR: Bob works with Jane","errors":["{\"id\":\"SZSDK99994002\",\"other\":\"123\",\"ssn\":\"[REDACTED]-45-6789\"}"],"details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"},{"position":3,"type":"error","value":"{\"id\":\"SZSDK99994002\",\"other\":\"123\",\"ssn\":\"[REDACTED]-45-6789\"}","valueRaw":{"id":"SZSDK99994002","other":"123","ssn":"[REDACTED]-45-6789"}}]}`,
+	},
+	{
+		name:                "redaction-0009",
+		messageNumber:       2001,
+		rules:               []messenger.RedactionRule{{Position: 1, Pattern: regexp.MustCompile(`o`), Strategy: messenger.RedactDrop}},
+		details:             []interface{}{"Bob", "Jane", messenger.MessageReason{Value: "Bob"}},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: Bb works with Jane","reason":"Bob","details":[{"position":1,"type":"string","value":"Bb"},{"position":2,"type":"string","value":"Jane"}]}`,
+	},
+	{
+		name:                "redaction-0010",
+		messageNumber:       2001,
+		rules:               []messenger.RedactionRule{{Key: "ssn", Type: "string"}},
+		details:             []interface{}{"Bob", "Jane", map[string]string{"SSN": "123-45-6789"}, `{"ssn": "987-65-4321"}`},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: Bob works with Jane","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"},{"key":"SSN","position":3,"type":"map[string]string","value":"123-45-6789"},{"position":4,"type":"string","value":"{\"ssn\":\"[REDACTED]\"}","valueRaw":{"ssn":"[REDACTED]"}}]}`,
+	},
+	{
+		name:                "redaction-0011",
+		messageNumber:       2001,
+		rules:               []messenger.RedactionRule{{Key: "ssn", Type: "map[string]string", Pattern: regexp.MustCompile(`^\d{3}`)}},
+		details:             []interface{}{"Bob", "Jane", map[string]string{"SSN": "123-45-6789"}, `{"ssn": "987-65-4321"}`},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: Bob works with Jane","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"},{"key":"SSN","position":3,"type":"map[string]string","value":"[REDACTED]-45-6789"},{"position":4,"type":"string","value":"{\"ssn\": \"987-65-4321\"}","valueRaw":{"ssn":"987-65-4321"}}]}`,
+	},
+	{
+		name:                "redaction-0012",
+		messageNumber:       2001,
+		rules:               []messenger.RedactionRule{{Key: "ssn"}},
+		details:             []interface{}{`{"ssn": "123-45-6789"}`, map[string]string{"ssn": "987-65-4321"}},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: {\"ssn\":\"[REDACTED]\"} works with map[ssn:[REDACTED]]","details":[{"position":1,"type":"string","value":"{\"ssn\":\"[REDACTED]\"}","valueRaw":{"ssn":"[REDACTED]"}},{"key":"ssn","position":2,"type":"map[string]string","value":"[REDACTED]"}]}`,
+	},
+	{
+		name:                "redaction-0013",
+		messageNumber:       4001,
+		rules:               []messenger.RedactionRule{{Key: "ssn", Strategy: messenger.RedactHash}},
+		details:             []interface{}{errors.New(`{"ssn": "123-45-6789"}`), testSSN{SSN: "987-65-4321"}}, //nolint
+		expectedMessageJSON: `{"level":"ERROR","id":"SZSDK99994001","text":"ERROR: {\"ssn\":\"sha256:c4bf0915280b7344\"} works with {\"ssn\":\"sha256:3db4dc8f6baf37b9\"}","errors":["{\"ssn\":\"sha256:c4bf0915280b7344\"}"],"details":[{"position":1,"type":"error","value":"{\"ssn\":\"sha256:c4bf0915280b7344\"}","valueRaw":{"ssn":"sha256:c4bf0915280b7344"}},{"position":2,"type":"messenger_test.testSSN","value":"{\"ssn\":\"sha256:3db4dc8f6baf37b9\"}","valueRaw":{"ssn":"sha256:3db4dc8f6baf37b9"}}]}`,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicMessenger_NewJSON_redaction(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForRedaction {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			testObject, err := messenger.New(
+				getOptionMessageIDTemplate(9999),
+				getOptionMessageFields(),
+				getOptionIDMessages(),
+				messenger.OptionRedactionRules{Value: testCase.rules},
+				messenger.OptionRedactionSalt{Value: "salt"},
+			)
+			require.NoError(test, err)
+
+			actual := testObject.NewJSON(testCase.messageNumber, testCase.details...)
+			assert.Equal(test, testCase.expectedMessageJSON, actual)
+
+			actualError := testObject.NewError(testCase.messageNumber, testCase.details...)
+			assert.Equal(test, testCase.expectedMessageJSON, actualError.Error())
+		})
+	}
+}
+
+func TestBasicMessenger_NewSlogLevel_redaction(test *testing.T) {
+	test.Parallel()
+
+	testObject, err := messenger.New(
+		getOptionMessageIDTemplate(9999),
+		getOptionMessageFields(),
+		getOptionIDMessages(),
+		messenger.OptionRedactionRules{Value: []messenger.RedactionRule{{Pattern: ssnPattern}}},
+	)
+	require.NoError(test, err)
+
+	message, _, keyValuePairs := testObject.NewSlogLevel(2001, "Bob", "123-45-6789")
+	assert.Equal(test, "INFO: Bob works with [REDACTED]", message)
+	assert.NotContains(test, keyValuePairs, "123-45-6789")
+	assert.Contains(test, keyValuePairs, []messenger.Detail{
+		{Position: 1, Type: "string", Value: "Bob"},
+		{Position: 2, Type: "string", Value: "[REDACTED]"},
+	})
+}
+
+func TestBasicMessenger_NewSlogLevel_redactionKey(test *testing.T) {
+	test.Parallel()
+
+	testObject, err := messenger.New(
+		getOptionMessageIDTemplate(9999),
+		getOptionMessageFields(),
+		getOptionIDMessages(),
+		messenger.OptionRedactionRules{Value: []messenger.RedactionRule{{Key: "ssn"}}},
+	)
+	require.NoError(test, err)
+
+	message, _, keyValuePairs := testObject.NewSlogLevel(2001, map[string]string{"ssn": "123-45-6789"}, `{"ssn": "987-65-4321"}`)
+	assert.Equal(test, `INFO: map[ssn:[REDACTED]] works with {"ssn":"[REDACTED]"}`, message)
+	assert.NotContains(test, fmt.Sprint(keyValuePairs), "123-45-6789")
+	assert.NotContains(test, fmt.Sprint(keyValuePairs), "987-65-4321")
+}
@@ -0,0 +1,213 @@
+package messenger
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The parsed value of SENZING_MESSAGE_FIELDS.
+type messageFieldsVariable struct {
+	fields []string // Fields of all messages, or nil if not given.
+	rules  []MessageRule
+	value  string // The value that was parsed.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Separators in SENZING_MESSAGE_FIELDS.
+const (
+	ruleSeparator     = ";"
+	selectorSeparator = ":"
+	levelSelector     = "level>="
+	levelSetting      = "level="
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// The most recently parsed SENZING_MESSAGE_FIELDS, shared by all messengers.
+var parsedMessageFieldsVariable atomic.Pointer[messageFieldsVariable]
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Determine if there are any rules, from SENZING_MESSAGE_FIELDS or OptionMessageRules.
+func (messenger *BasicMessenger) hasRules() bool {
+	return len(messenger.messageRules) > 0 || len(environmentMessageFields().rules) > 0
+}
+
+// Find the fields and level of the first matching rules that set them, from SENZING_MESSAGE_FIELDS then OptionMessageRules.
+// Empty results mean no rule applies.
+func (messenger *BasicMessenger) matchRules(messageNumber int, id string) ([]string, string) {
+	var (
+		fields []string
+		level  string
+	)
+
+	numberLevel := TextToLevelMap[messenger.getLevel(messageNumber)]
+
+	for _, rules := range [][]MessageRule{environmentMessageFields().rules, messenger.messageRules} {
+		for _, rule := range rules {
+			if !rule.matches(messageNumber, id, numberLevel) {
+				continue
+			}
+
+			if fields == nil && len(rule.Fields) > 0 {
+				fields = rule.Fields
+			}
+
+			if len(level) == 0 {
+				level = rule.Level
+			}
+		}
+	}
+
+	return fields, level
+}
+
+// Determine if all non-zero criteria of a rule match a message.
+func (rule *MessageRule) matches(messageNumber int, id string, numberLevel slog.Level) bool {
+	if len(rule.ID) > 0 && rule.ID != id {
+		return false
+	}
+
+	if rule.IDMax > 0 && (messageNumber < rule.IDMin || messageNumber > rule.IDMax) {
+		return false
+	}
+
+	if len(rule.MinimumLevel) > 0 && numberLevel < TextToLevelMap[rule.MinimumLevel] {
+		return false
+	}
+
+	return true
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// The parsed SENZING_MESSAGE_FIELDS, parsed again only when its value changes.
+func environmentMessageFields() *messageFieldsVariable {
+	value := os.Getenv("SENZING_MESSAGE_FIELDS")
+
+	result := parsedMessageFieldsVariable.Load()
+	if result == nil || result.value != value {
+		result = parseMessageFieldsVariable(value)
+		parsedMessageFieldsVariable.Store(result)
+	}
+
+	return result
+}
+
+// Validate a rule, expanding "all" and upper-casing levels.
+func normalizeMessageRule(rule MessageRule) (MessageRule, error) {
+	var err error
+
+	result := rule
+
+	result.Fields, err = normalizeMessageFields(rule.Fields)
+	if err == nil {
+		result.Level, err = normalizeLevel(rule.Level)
+	}
+
+	if err == nil {
+		result.MinimumLevel, err = normalizeLevel(rule.MinimumLevel)
+	}
+
+	return result, err
+}
+
+/*
+Parse SENZING_MESSAGE_FIELDS: fields of all messages, then ";"-separated rules of the form "selector:fields".
+A selector is a message id, a message number range such as "4000-4999", or a level such as "level>=ERROR".
+The fields of a rule may include "level=NAME" to set the level of matching messages.
+Unknown fields and malformed rules are ignored.
+
+	id,text; level>=ERROR: id,text,location,errors; SZSDK99993001: id,text,level=ERROR
+*/
+func parseMessageFieldsVariable(value string) *messageFieldsVariable {
+	result := &messageFieldsVariable{value: value}
+	segments := strings.Split(value, ruleSeparator)
+	result.fields = parseFieldList(segments[0])
+
+	for _, segment := range segments[1:] {
+		selector, fieldList, isRule := strings.Cut(segment, selectorSeparator)
+		if !isRule {
+			continue
+		}
+
+		rule, isValid := parseSelector(strings.TrimSpace(selector))
+		if !isValid {
+			continue
+		}
+
+		for _, field := range strings.Split(fieldList, ",") {
+			level, isLevel := strings.CutPrefix(strings.ToLower(strings.TrimSpace(field)), levelSetting)
+			if isLevel {
+				rule.Level = strings.ToUpper(level)
+			}
+		}
+
+		rule.Fields = parseFieldList(fieldList)
+
+		normalized, err := normalizeMessageRule(rule)
+		if err == nil {
+			result.rules = append(result.rules, normalized)
+		}
+	}
+
+	return result
+}
+
+// Parse a comma-separated list of fields, or "all".  Nil if the list is empty.
+func parseFieldList(fieldList string) []string {
+	fieldList = strings.TrimSpace(strings.ToLower(fieldList))
+
+	switch fieldList {
+	case "":
+		return nil
+	case allFieldsName:
+		return AllMessageFields
+	}
+
+	result := []string{}
+
+	for _, value := range strings.Split(fieldList, ",") {
+		valueTrimmed := strings.TrimSpace(value)
+		if isMessageField(valueTrimmed) {
+			result = append(result, valueTrimmed)
+		}
+	}
+
+	return result
+}
+
+// Parse the selector of a rule: "level>=NAME", "N-M", or a message id.
+func parseSelector(selector string) (MessageRule, bool) {
+	if level, isLevel := strings.CutPrefix(strings.ToLower(selector), levelSelector); isLevel {
+		return MessageRule{MinimumLevel: strings.ToUpper(strings.TrimSpace(level))}, true
+	}
+
+	if low, high, isRange := strings.Cut(selector, "-"); isRange {
+		idMin, errMin := strconv.Atoi(strings.TrimSpace(low))
+		idMax, errMax := strconv.Atoi(strings.TrimSpace(high))
+
+		if errMin == nil && errMax == nil {
+			return MessageRule{IDMin: idMin, IDMax: idMax}, idMax >= idMin && idMax > 0
+		}
+	}
+
+	return MessageRule{ID: selector}, len(selector) > 0
+}
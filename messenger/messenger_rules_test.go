@@ -0,0 +1,129 @@
+package messenger_test
+
+import (
+	"testing"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMessageRules = []messenger.MessageRule{
+	{ID: "SZSDK99993003", Level: messenger.LevelErrorName},
+	{MinimumLevel: messenger.LevelErrorName, Fields: []string{"level", "id", "text", "errors"}},
+	{IDMin: 2000, IDMax: 2999, Fields: []string{"id", "text"}},
+	{IDMin: 2000, IDMax: 2999, Fields: []string{"all"}, Level: messenger.LevelWarnName},
+}
+
+var testCasesForMessageRules = []struct {
+	name          string
+	messageNumber int
+	details       []interface{}
+	expectedJSON  string
+}{
+	{
+		name:          "rules-0001",
+		messageNumber: 2001,
+		expectedJSON:  `{"id":"SZSDK99992001","text":"INFO: Bob works with Jane"}`,
+	},
+	{
+		name:          "rules-0002",
+		messageNumber: 4001,
+		details:       []interface{}{errTest1},
+		expectedJSON:  `{"level":"ERROR","id":"SZSDK99994001","text":"ERROR: Bob works with Jane","errors":["error 1"]}`,
+	},
+	{
+		name:          "rules-0003",
+		messageNumber: 3001,
+		expectedJSON:  `{"level":"WARN","id":"SZSDK99993001","text":"WARN: Bob works with Jane","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"}]}`,
+	},
+	{
+		name:          "rules-0004",
+		messageNumber: 3003,
+		expectedJSON:  `{"level":"ERROR","id":"SZSDK99993003","text":"WARN: Bob works with Jane","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"}]}`,
+	},
+	{
+		name:          "rules-0005",
+		messageNumber: 2001,
+		details:       []interface{}{messenger.OptionMessageFields{Value: []string{"id"}}, messenger.MessageLevel{Value: messenger.LevelDebugName}},
+		expectedJSON:  `{"id":"SZSDK99992001"}`,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicMessenger_NewJSON_messageRules(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForMessageRules {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			testObject, err := messenger.New(
+				getOptionMessageIDTemplate(9999),
+				getOptionMessageFields(),
+				getOptionIDMessages(),
+				messenger.OptionMessageRules{Value: testMessageRules},
+			)
+			require.NoError(test, err)
+
+			details := append([]interface{}{"Bob", "Jane"}, testCase.details...)
+			assert.Equal(test, testCase.expectedJSON, testObject.NewJSON(testCase.messageNumber, details...))
+		})
+	}
+}
+
+func TestBasicMessenger_NewSlogLevel_messageRules(test *testing.T) {
+	test.Parallel()
+
+	testObject, err := messenger.New(
+		getOptionMessageIDTemplate(9999),
+		getOptionIDMessages(),
+		messenger.OptionMessageRules{Value: testMessageRules},
+		messenger.OptionMinimumLevel{Value: messenger.LevelErrorSlog},
+	)
+	require.NoError(test, err)
+
+	message, level, keyValuePairs := testObject.NewSlogLevel(3003, "Bob", "Jane")
+	assert.Equal(test, "WARN: Bob works with Jane", message)
+	assert.Equal(test, messenger.LevelErrorSlog, level)
+	assert.Equal(test, []interface{}{"id", "SZSDK99993003"}, keyValuePairs)
+	assert.True(test, levelEnabled(test, testObject, 3003))
+	assert.False(test, levelEnabled(test, testObject, 3001))
+
+	_, level, _ = testObject.NewSlogLevel(2001, "Bob", "Jane")
+	assert.Equal(test, messenger.LevelWarnSlog, level)
+}
+
+func TestBasicMessenger_New_messageRules_invalid(test *testing.T) {
+	test.Parallel()
+
+	_, err := messenger.New(messenger.OptionMessageRules{Value: []messenger.MessageRule{
+		{ID: "SZSDK99992001", Fields: []string{"id"}},
+		{MinimumLevel: "LOUD"},
+	}})
+	require.ErrorIs(test, err, messenger.ErrInvalidConfiguration)
+	require.EqualError(test, err, `messenger.New error: message rule 1: level "LOUD": invalid configuration`)
+}
+
+func Test_NewJSON_envvar_rules(test *testing.T) {
+	test.Setenv("SENZING_MESSAGE_FIELDS", "id, text; level>=error: level,id,errors; 3001-3002: id, level=ERROR; SZSDK99992001: ALL; bogus; 3-x:id")
+
+	testObject, err := messenger.New(
+		getOptionMessageIDTemplate(9999),
+		getOptionIDMessages(),
+		messenger.OptionMessageRules{Value: []messenger.MessageRule{{IDMin: 3000, IDMax: 3999, Fields: []string{"status"}}}},
+	)
+	require.NoError(test, err)
+
+	assert.Equal(test, `{"level":"ERROR","id":"SZSDK99994001","errors":["error 1"]}`, testObject.NewJSON(4001, "Bob", errTest1))
+	assert.Equal(test, `{"id":"SZSDK99993001"}`, testObject.NewJSON(3001, "Bob", "Jane"))
+	assert.Equal(test, `{"status":"TestStatus"}`, testObject.NewJSON(3003, getMessageStatus()))
+	assert.Equal(test, `{"id":"SZSDK99991001","text":"DEBUG: Bob works with Jane"}`, testObject.NewJSON(1001, "Bob", "Jane"))
+	assert.Contains(test, testObject.NewJSON(2001, "Bob", "Jane"), `"level":"INFO","id":"SZSDK99992001"`)
+
+	_, level, _ := testObject.NewSlogLevel(3002)
+	assert.Equal(test, messenger.LevelErrorSlog, level)
+}
@@ -0,0 +1,209 @@
+package messenger
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"golang.org/x/exp/slog"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A Sensitive value is marked at the call site as one that must not be emitted.
+// It is rendered as a placeholder, such as "[SECRET]", in "text", "details", and slog attributes.
+// Create with Secret() or PII().
+type Sensitive struct {
+	category string
+	value    interface{}
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Categories of Sensitive values.
+const (
+	SensitivePII    = "PII"
+	SensitiveSecret = "SECRET"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The PII function marks personally identifiable information, such as a name or address.
+
+Input
+  - value: The value to protect.
+
+Output
+  - A detail rendered as "[PII]" unless revealed.
+*/
+func PII(value interface{}) Sensitive {
+	return Sensitive{category: SensitivePII, value: value}
+}
+
+/*
+The Secret function marks a secret, such as a password or API key.
+
+Input
+  - value: The value to protect.
+
+Output
+  - A detail rendered as "[SECRET]" unless revealed.
+*/
+func Secret(value interface{}) Sensitive {
+	return Sensitive{category: SensitiveSecret, value: value}
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+// Category returns SensitivePII or SensitiveSecret.
+func (sensitive Sensitive) Category() string {
+	return sensitive.category
+}
+
+// Format implements fmt.Formatter so that no verb, including %#v, shows the value.
+func (sensitive Sensitive) Format(state fmt.State, _ rune) {
+	_, _ = state.Write([]byte(sensitive.String()))
+}
+
+// LogValue implements slog.LogValuer so that slog handlers never see the value.
+func (sensitive Sensitive) LogValue() slog.Value {
+	return slog.StringValue(sensitive.String())
+}
+
+// MarshalJSON implements json.Marshaler so that JSON encoding never shows the value.
+func (sensitive Sensitive) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sensitive.String()) //nolint:wrapcheck
+}
+
+// String implements fmt.Stringer.
+func (sensitive Sensitive) String() string {
+	return "[" + sensitive.category + "]"
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Replace a Sensitive value with its placeholder, its salted hash, or, if allowed, its value.
+func (messenger *BasicMessenger) resolveSensitive(sensitive Sensitive) interface{} {
+	if messenger.revealSensitive && revealSensitiveAllowed {
+		return sensitive.value
+	}
+
+	// The hash is of the formatted value, so that it is the same for equal values behind different pointers.
+
+	formatted := messenger.formatDetail(sensitive.value)
+
+	text := sensitive.String()
+	if messenger.hashSensitive {
+		text = messenger.redact(formatted.Value, RedactHash)
+	}
+
+	if isError(sensitive.value) {
+		return redactedError{text: text}
+	}
+
+	return redactedValue{detailType: formatted.Type, text: text}
+}
+
+// Replace a detail holding a Sensitive value in an unexported field, which fmt would print without its Format method,
+// with its formatted text, in which the Sensitive value is a placeholder.
+func (messenger *BasicMessenger) hideSensitive(value interface{}) interface{} {
+	formatted := messenger.formatDetail(value)
+	if isError(value) {
+		return redactedError{text: formatted.Value}
+	}
+
+	result := redactedValue{detailType: formatted.Type, text: formatted.Value}
+
+	if formatted.ValueRaw != nil {
+		raw, err := json.Marshal(formatted.ValueRaw)
+		if err == nil {
+			result.raw = raw
+		}
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Determine if a value holds a Sensitive value in an unexported field, where neither fmt nor a registered formatter
+// would see its methods.  Pointers, maps, and slices being visited are skipped, so that cycles end.
+func hasHiddenSensitive(value reflect.Value, visiting map[visit]bool) bool {
+	if !value.IsValid() {
+		return false
+	}
+
+	if value.Type() == reflect.TypeFor[Sensitive]() {
+		return !value.CanInterface()
+	}
+
+	switch value.Kind() {
+	case reflect.Map, reflect.Pointer, reflect.Slice:
+		if value.IsNil() {
+			return false
+		}
+
+		current := visit{pointer: value.Pointer(), valueType: value.Type()}
+		if value.Kind() == reflect.Slice {
+			current.length = value.Len()
+		}
+
+		if visiting[current] {
+			return false
+		}
+
+		visiting[current] = true
+		defer delete(visiting, current)
+	default:
+	}
+
+	switch value.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		return hasHiddenSensitive(value.Elem(), visiting)
+	case reflect.Array, reflect.Slice:
+		for index := range value.Len() {
+			if hasHiddenSensitive(value.Index(index), visiting) {
+				return true
+			}
+		}
+	case reflect.Map:
+		iterator := value.MapRange()
+		for iterator.Next() {
+			if hasHiddenSensitive(iterator.Key(), visiting) || hasHiddenSensitive(iterator.Value(), visiting) {
+				return true
+			}
+		}
+	case reflect.Struct:
+		for index := range value.NumField() {
+			if hasHiddenSensitive(value.Field(index), visiting) {
+				return true
+			}
+		}
+	default:
+	}
+
+	return false
+}
+
+// A Sensitive value from an unexported field, which cannot be formatted by its registered formatter,
+// as a copy without the value that can.  Other values are returned unchanged.
+func exportSensitive(value reflect.Value) reflect.Value {
+	if value.CanInterface() || value.Type() != reflect.TypeFor[Sensitive]() {
+		return value
+	}
+
+	return reflect.ValueOf(Sensitive{category: value.FieldByName("category").String()})
+}
@@ -0,0 +1,6 @@
+//go:build messenger_debug
+
+package messenger
+
+// Builds with the "messenger_debug" tag honor OptionRevealSensitive.
+const revealSensitiveAllowed = true
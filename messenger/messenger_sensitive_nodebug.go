@@ -0,0 +1,6 @@
+//go:build !messenger_debug
+
+package messenger
+
+// OptionRevealSensitive is ignored unless built with the "messenger_debug" tag.
+const revealSensitiveAllowed = false
@@ -0,0 +1,19 @@
+//go:build !messenger_debug
+
+package messenger_test
+
+import (
+	"testing"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasicMessenger_NewJSON_revealSensitive(test *testing.T) {
+	test.Parallel()
+
+	testObject, err := messenger.New(getOptionMessageFields(), messenger.OptionRevealSensitive{Value: true})
+	require.NoError(test, err)
+	assert.NotContains(test, testObject.NewJSON(2001, messenger.Secret("hunter2")), "hunter2")
+}
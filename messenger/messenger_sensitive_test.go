@@ -0,0 +1,150 @@
+package messenger_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCredentials struct {
+	User     string
+	password messenger.Sensitive
+	token    *messenger.Sensitive
+}
+
+var testCasesForSensitive = []struct {
+	name                string
+	messageNumber       int
+	options             []interface{}
+	details             []interface{}
+	expectedMessageJSON string
+}{
+	{
+		name:                "sensitive-0001",
+		messageNumber:       2001,
+		details:             []interface{}{messenger.PII("Bob"), messenger.Secret("Jane")},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: [PII] works with [SECRET]","details":[{"position":1,"type":"string","value":"[PII]"},{"position":2,"type":"string","value":"[SECRET]"}]}`,
+	},
+	{
+		name:                "sensitive-0002",
+		messageNumber:       2001,
+		options:             []interface{}{messenger.OptionHashSensitive{Value: true}},
+		details:             []interface{}{"Bob", messenger.Secret(123456789)},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: Bob works with sha256:e0b823f60b2bcdf7","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"integer","value":"sha256:e0b823f60b2bcdf7"}]}`,
+	},
+	{
+		name:                "sensitive-0003",
+		messageNumber:       4001,
+		details:             []interface{}{"Bob", "Jane", messenger.Secret(errors.New("password is hunter2"))}, //nolint
+		expectedMessageJSON: `{"level":"ERROR","id":"SZSDK99994001","text":"ERROR: Bob works with Jane","errors":["[SECRET]"],"details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"},{"position":3,"type":"error","value":"[SECRET]"}]}`,
+	},
+	{
+		name:                "sensitive-0004",
+		messageNumber:       2001,
+		options:             []interface{}{messenger.OptionRedactionRules{Value: []messenger.RedactionRule{{Position: 2}}}},
+		details:             []interface{}{messenger.PII(map[string]string{"name": "Bob"}), "Jane"},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: [PII] works with [REDACTED]","details":[{"position":1,"type":"map[string]string","value":"[PII]"},{"position":2,"type":"string","value":"[REDACTED]"}]}`,
+	},
+	{
+		name:                "sensitive-0005",
+		messageNumber:       2001,
+		details:             []interface{}{"Bob", messenger.Secret(&testAddress{City: "Las Vegas"})},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: Bob works with [SECRET]","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"*messenger_test.testAddress","value":"[SECRET]"}]}`,
+	},
+	{
+		name:                "sensitive-0006",
+		messageNumber:       2001,
+		details:             []interface{}{"Bob", testCredentials{User: "Jane", password: messenger.Secret("hunter2"), token: &[]messenger.Sensitive{messenger.Secret("abc123")}[0]}},
+		expectedMessageJSON: `{"level":"INFO","id":"SZSDK99992001","text":"INFO: Bob works with {\"User\":\"Jane\",\"password\":\"[SECRET]\",\"token\":\"[SECRET]\"}","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"messenger_test.testCredentials","value":"{\"User\":\"Jane\",\"password\":\"[SECRET]\",\"token\":\"[SECRET]\"}","valueRaw":{"User":"Jane","password":"[SECRET]","token":"[SECRET]"}}]}`,
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicMessenger_NewJSON_sensitive(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForSensitive {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			options := []interface{}{
+				getOptionMessageIDTemplate(9999),
+				getOptionMessageFields(),
+				getOptionIDMessages(),
+				messenger.OptionRedactionSalt{Value: "salt"},
+			}
+			testObject, err := messenger.New(append(options, testCase.options...)...)
+			require.NoError(test, err)
+
+			actual := testObject.NewJSON(testCase.messageNumber, testCase.details...)
+			assert.Equal(test, testCase.expectedMessageJSON, actual)
+
+			actualError := testObject.NewError(testCase.messageNumber, testCase.details...)
+			assert.Equal(test, testCase.expectedMessageJSON, actualError.Error())
+		})
+	}
+}
+
+func TestBasicMessenger_NewSlogLevel_sensitive(test *testing.T) {
+	test.Parallel()
+
+	testObject, err := messenger.New(getOptionMessageFields(), getOptionIDMessages())
+	require.NoError(test, err)
+
+	message, _, keyValuePairs := testObject.NewSlogLevel(2001, "Bob", messenger.PII("Jane"))
+	assert.Equal(test, "INFO: Bob works with [PII]", message)
+	assert.Contains(test, keyValuePairs, []messenger.Detail{
+		{Position: 1, Type: "string", Value: "Bob"},
+		{Position: 2, Type: "string", Value: "[PII]"},
+	})
+}
+
+func TestBasicMessenger_NewSlogLevel_sensitiveUnexported(test *testing.T) {
+	test.Parallel()
+
+	testObject, err := messenger.New(getOptionMessageFields(), getOptionIDMessages())
+	require.NoError(test, err)
+
+	credentials := testCredentials{User: "Jane", password: messenger.Secret("hunter2")}
+	message, _, keyValuePairs := testObject.NewSlogLevel(2001, "Bob", map[string][]testCredentials{"team": {credentials}})
+	assert.NotContains(test, message, "hunter2")
+	assert.Contains(test, message, "[SECRET]")
+	assert.NotContains(test, fmt.Sprintf("%v", keyValuePairs), "hunter2")
+}
+
+func TestBasicMessenger_NewJSON_sensitiveHashPointer(test *testing.T) {
+	test.Parallel()
+
+	testObject, err := messenger.New(getOptionIDMessages(), messenger.OptionHashSensitive{Value: true})
+	require.NoError(test, err)
+
+	ages := []int{42, 42, 43}
+	first := testObject.NewJSON(2001, "Bob", messenger.PII(&ages[0]))
+	second := testObject.NewJSON(2001, "Bob", messenger.PII(&ages[1]))
+	other := testObject.NewJSON(2001, "Bob", messenger.PII(&ages[2]))
+	assert.Contains(test, first, "sha256:")
+	assert.Equal(test, first, second)
+	assert.NotEqual(test, first, other)
+}
+
+func TestSensitive_rendering(test *testing.T) {
+	test.Parallel()
+
+	secret := messenger.Secret("hunter2")
+	assert.Equal(test, messenger.SensitiveSecret, secret.Category())
+	assert.Equal(test, "[SECRET]", secret.String())
+	assert.Equal(test, "[SECRET] [SECRET] [SECRET] [SECRET]", fmt.Sprintf("%v %+v %#v %q", secret, secret, secret, secret))
+	assert.Equal(test, "[SECRET]", secret.LogValue().String())
+
+	marshaled, err := json.Marshal(map[string]interface{}{"password": secret})
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"password": "[SECRET]"}`, string(marshaled))
+}
@@ -0,0 +1,185 @@
+package messenger
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const defaultStackDepth = 32
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// The import path of this package, whose frames are never part of a stack.
+var messengerPackage = reflect.TypeOf(BasicMessenger{}).PkgPath()
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Create the "stack" field for a message at the given level, or nil if below the level threshold.
+func (messenger *BasicMessenger) captureStack(level string, details []interface{}) []Frame {
+	threshold := messenger.stackTrace.Level
+	if len(threshold) == 0 {
+		threshold = LevelErrorName
+	}
+
+	messageLevel, isOK := TextToLevelMap[level]
+	if !isOK || messageLevel < TextToLevelMap[threshold] {
+		return nil
+	}
+
+	depth := messenger.stackTrace.Depth
+	if depth <= 0 {
+		depth = defaultStackDepth
+	}
+
+	programCounters := stackOfDetails(details)
+	if programCounters == nil {
+		// Extra room for the runtime and messenger frames that are removed.
+		programCounters = make([]uintptr, depth+defaultStackDepth)
+		programCounters = programCounters[:runtime.Callers(1, programCounters)]
+	}
+
+	return messenger.stackFrames(programCounters, depth)
+}
+
+// Convert program counters to frames, omitting runtime, messenger, and SkipPackages frames.
+func (messenger *BasicMessenger) stackFrames(programCounters []uintptr, depth int) []Frame {
+	var result []Frame
+
+	frames := runtime.CallersFrames(programCounters)
+
+	for len(result) < depth {
+		frame, more := frames.Next()
+		packageName, functionName := splitFunctionName(frame.Function)
+
+		if len(packageName) > 0 && !messenger.isSkippedPackage(packageName) {
+			result = append(result, Frame{
+				File:     frame.File,
+				Function: functionName,
+				Line:     int32(frame.Line), //nolint:gosec
+				Package:  packageName,
+			})
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return result
+}
+
+func (messenger *BasicMessenger) isSkippedPackage(packageName string) bool {
+	if packageName == "runtime" || packageName == messengerPackage {
+		return true
+	}
+
+	for _, skippedPackage := range messenger.stackTrace.SkipPackages {
+		if packageName == skippedPackage || strings.HasPrefix(packageName, skippedPackage+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Split "example.com/a/b.(*T).Method" into "example.com/a/b" and "(*T).Method".
+// Dots in the last element of the package path, escaped as "%2e" in function names, are unescaped.
+func splitFunctionName(name string) (string, string) {
+	lastSlash := strings.LastIndex(name, "/")
+
+	dot := strings.Index(name[lastSlash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+
+	packageName := name[:lastSlash+1+dot]
+	if unescaped, err := url.PathUnescape(packageName); err == nil {
+		packageName = unescaped
+	}
+
+	return packageName, name[lastSlash+2+dot:]
+}
+
+// The program counters of the innermost error detail that carries a stack trace, or nil.
+func stackOfDetails(details []interface{}) []uintptr {
+	var result []uintptr
+
+	for _, detail := range details {
+		if err, isError := detail.(error); isError {
+			if programCounters := stackOfError(err); programCounters != nil {
+				result = programCounters
+			}
+		}
+	}
+
+	return result
+}
+
+// The program counters of the innermost error in the chain that carries a stack trace, or nil.
+// Recognizes "Callers() []uintptr" and "StackTrace() T" where T is a slice of a uintptr type,
+// as in github.com/pkg/errors.
+func stackOfError(err error) []uintptr {
+	var result []uintptr
+
+	queue := []error{err}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if programCounters := stackOfMethod(current); programCounters != nil {
+			result = programCounters
+		}
+
+		switch wrapper := current.(type) { //nolint:errorlint
+		case interface{ Unwrap() []error }:
+			queue = append(queue, wrapper.Unwrap()...)
+		default:
+			if wrapped := errors.Unwrap(current); wrapped != nil {
+				queue = append(queue, wrapped)
+			}
+		}
+	}
+
+	return result
+}
+
+func stackOfMethod(err error) []uintptr {
+	if caller, isOK := err.(interface{ Callers() []uintptr }); isOK { //nolint:errorlint
+		return slices.Clone(caller.Callers())
+	}
+
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil
+	}
+
+	resultType := method.Type().Out(0)
+	if resultType.Kind() != reflect.Slice || resultType.Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	trace := method.Call(nil)[0]
+	result := make([]uintptr, trace.Len())
+
+	for index := range result {
+		result[index] = uintptr(trace.Index(index).Uint())
+	}
+
+	return result
+}
@@ -0,0 +1,62 @@
+package messenger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testCasesForSplitFunctionName = []struct {
+	name             string
+	function         string
+	expectedPackage  string
+	expectedFunction string
+}{
+	{
+		name:             "split-0001",
+		function:         "github.com/senzing-garage/go-messaging/messenger.(*BasicMessenger).NewJSON",
+		expectedPackage:  "github.com/senzing-garage/go-messaging/messenger",
+		expectedFunction: "(*BasicMessenger).NewJSON",
+	},
+	{
+		name:             "split-0002",
+		function:         "main.main.func1",
+		expectedPackage:  "main",
+		expectedFunction: "main.func1",
+	},
+	{
+		name:             "split-0003",
+		function:         "gopkg.in/yaml%2ev3.(*decoder).unmarshal",
+		expectedPackage:  "gopkg.in/yaml.v3",
+		expectedFunction: "(*decoder).unmarshal",
+	},
+	{
+		name:             "split-0004",
+		function:         "example.com/a.b/c%2ed%2ee.Run",
+		expectedPackage:  "example.com/a.b/c.d.e",
+		expectedFunction: "Run",
+	},
+	{
+		name:             "split-0005",
+		function:         "noPackage",
+		expectedFunction: "noPackage",
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test private functions
+// ----------------------------------------------------------------------------
+
+func Test_splitFunctionName(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForSplitFunctionName {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			packageName, functionName := splitFunctionName(testCase.function)
+			assert.Equal(test, testCase.expectedPackage, packageName)
+			assert.Equal(test, testCase.expectedFunction, functionName)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package messenger_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// A frame, like github.com/pkg/errors.Frame.
+type tracedFrame uintptr
+
+// An error carrying a stack trace, like those from github.com/pkg/errors.
+type tracedError struct {
+	message string
+	stack   []tracedFrame
+}
+
+const testPackage = "github.com/senzing-garage/go-messaging/messenger_test"
+
+var testCasesForStack = []struct {
+	name             string
+	messageNumber    int
+	stackTrace       messenger.StackTrace
+	details          []interface{}
+	expectedFunction string
+	expectedPackage  string
+	expectedDepth    int
+}{
+	{
+		name:             "stack-0001",
+		messageNumber:    4001,
+		expectedFunction: "TestBasicMessenger_NewJSON_stack.func1",
+		expectedPackage:  testPackage,
+	},
+	{
+		name:          "stack-0002",
+		messageNumber: 3001,
+	},
+	{
+		name:             "stack-0003",
+		messageNumber:    3001,
+		stackTrace:       messenger.StackTrace{Level: messenger.LevelWarnName, Depth: 1},
+		expectedFunction: "TestBasicMessenger_NewJSON_stack.func1",
+		expectedPackage:  testPackage,
+		expectedDepth:    1,
+	},
+	{
+		name:             "stack-0004",
+		messageNumber:    4001,
+		details:          []interface{}{fmt.Errorf("wrapped: %w", newTracedError("traced"))},
+		expectedFunction: "newTracedError",
+		expectedPackage:  testPackage,
+	},
+	{
+		name:             "stack-0005",
+		messageNumber:    4001,
+		stackTrace:       messenger.StackTrace{SkipPackages: []string{testPackage}},
+		expectedFunction: "tRunner",
+		expectedPackage:  "testing",
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestBasicMessenger_NewJSON_stack(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForStack {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			testObject, err := messenger.New(
+				messenger.OptionMessageFields{Value: []string{"id", "stack"}},
+				messenger.OptionStackTrace{Value: testCase.stackTrace},
+			)
+			require.NoError(test, err)
+
+			messageFormat := &messenger.MessageFormat{}
+			require.NoError(test, json.Unmarshal([]byte(testObject.NewJSON(testCase.messageNumber, testCase.details...)), messageFormat))
+
+			if len(testCase.expectedFunction) == 0 {
+				assert.Empty(test, messageFormat.Stack)
+
+				return
+			}
+
+			require.NotEmpty(test, messageFormat.Stack)
+			assert.Equal(test, testCase.expectedFunction, messageFormat.Stack[0].Function)
+			assert.Equal(test, testCase.expectedPackage, messageFormat.Stack[0].Package)
+			assert.Positive(test, messageFormat.Stack[0].Line)
+			assert.NotEmpty(test, messageFormat.Stack[0].File)
+
+			for _, frame := range messageFormat.Stack {
+				assert.NotEqual(test, "github.com/senzing-garage/go-messaging/messenger", frame.Package)
+				assert.NotEqual(test, "runtime", frame.Package)
+			}
+
+			if testCase.expectedDepth > 0 {
+				assert.Len(test, messageFormat.Stack, testCase.expectedDepth)
+			}
+		})
+	}
+}
+
+func TestBasicMessenger_NewJSON_stackWithoutOption(test *testing.T) {
+	test.Parallel()
+
+	testObject, err := messenger.New(getOptionMessageFieldsAll())
+	require.NoError(test, err)
+
+	messageFormat := &messenger.MessageFormat{}
+	require.NoError(test, json.Unmarshal([]byte(testObject.NewJSON(4001)), messageFormat))
+	assert.Empty(test, messageFormat.Stack)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions - names begin with lowercase letter
+// ----------------------------------------------------------------------------
+
+func newTracedError(message string) error {
+	programCounters := make([]uintptr, 32)
+	programCounters = programCounters[:runtime.Callers(1, programCounters)]
+
+	result := &tracedError{message: message}
+	for _, programCounter := range programCounters {
+		result.stack = append(result.stack, tracedFrame(programCounter))
+	}
+
+	return result
+}
+
+func (err *tracedError) Error() string {
+	return err.message
+}
+
+func (err *tracedError) StackTrace() []tracedFrame {
+	return err.stack
+}
@@ -179,7 +179,7 @@ var testCasesForMessage = []struct {
 			"id",
 			"SZSDK99993002",
 			"errors",
-			[]interface{}{"error 1", "error 2"},
+			[]string{"error 1", "error 2"},
 			"details",
 			[]messenger.Detail{
 				{Key: "", Position: 1, Type: "string", Value: "Bob", ValueRaw: interface{}(nil)},
@@ -201,7 +201,7 @@ var testCasesForMessage = []struct {
 			getOptionIDMessages(),
 		},
 		details:             []interface{}{"Bob", "Jane", int64(12345)},
-		expectedMessageJSON: `{"level":"WARN","id":"SZSDK99993003","text":"WARN: Bob works with Jane","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"},{"position":3,"type":"int64","value":"12345","valueRaw":12345}]}`,
+		expectedMessageJSON: `{"level":"WARN","id":"SZSDK99993003","text":"WARN: Bob works with Jane","details":[{"position":1,"type":"string","value":"Bob"},{"position":2,"type":"string","value":"Jane"},{"position":3,"type":"integer","value":"12345","valueRaw":12345}]}`,
 		expectedMessageSlog: []interface{}{
 			"level",
 			"WARN",
@@ -211,7 +211,7 @@ var testCasesForMessage = []struct {
 			[]messenger.Detail{
 				{Key: "", Position: 1, Type: "string", Value: "Bob", ValueRaw: interface{}(nil)},
 				{Key: "", Position: 2, Type: "string", Value: "Jane", ValueRaw: interface{}(nil)},
-				{Key: "", Position: 3, Type: "int64", Value: "12345", ValueRaw: int64(12345)},
+				{Key: "", Position: 3, Type: "integer", Value: "12345", ValueRaw: int64(12345)},
 			},
 		},
 		expectedText:      "WARN: Bob works with Jane",
@@ -520,6 +520,46 @@ var testCasesForMessage = []struct {
 		expectedText:      "",
 		expectedSlogLevel: messenger.LevelPanicSlog,
 	},
+	{
+		name:          "messenger-4001-help",
+		messageNumber: 4001,
+		comment:       "Remediation from the catalog and help URL from the template.",
+		options: []interface{}{
+			getOptionMessageIDTemplate(9999),
+			messenger.OptionMessageFields{Value: []string{"id", "text", "remediation", "help"}},
+			getOptionIDMessages(),
+			messenger.OptionIDRemediations{Value: map[int]string{4001: "Check that Bob and Jane exist."}},
+			messenger.OptionHelpURLTemplate{Value: "https://example.com/errors.html#%s"},
+		},
+		details:             []interface{}{"Bob", "Jane"},
+		expectedMessageJSON: `{"id":"SZSDK99994001","text":"ERROR: Bob works with Jane","remediation":"Check that Bob and Jane exist.","help":"https://example.com/errors.html#SZSDK99994001"}`,
+		expectedMessageSlog: []interface{}{
+			"id",
+			"SZSDK99994001",
+			"remediation",
+			"Check that Bob and Jane exist.",
+			"help",
+			"https://example.com/errors.html#SZSDK99994001",
+		},
+		expectedText:      "ERROR: Bob works with Jane",
+		expectedSlogLevel: messenger.LevelErrorSlog,
+	},
+	{
+		name:          "messenger-4001-help-override",
+		messageNumber: 4001,
+		comment:       "Remediation and help given with the message.",
+		options: []interface{}{
+			getOptionMessageIDTemplate(9999),
+			messenger.OptionMessageFields{Value: []string{"id", "remediation", "help"}},
+			messenger.OptionIDRemediations{Value: map[int]string{4001: "Check that Bob and Jane exist."}},
+			messenger.OptionHelpURLTemplate{Value: "https://example.com/errors.html#%s"},
+		},
+		details: []interface{}{
+			messenger.MessageRemediation{Value: "Restart."},
+			messenger.MessageHelp{Value: "https://example.com/restart.html"},
+		},
+		expectedMessageJSON: `{"id":"SZSDK99994001","remediation":"Restart.","help":"https://example.com/restart.html"}`,
+	},
 }
 
 // var testCasesForMessageDetails = []struct {
@@ -567,7 +607,7 @@ var testCasesForMessage = []struct {
 // 	{
 // 		name:     "int64",
 // 		input:    int64(1),
-// 		expected: []messenger.Detail{{Position: 1, Type: "int64", Value: "1", ValueRaw: int64(1)}},
+// 		expected: []messenger.Detail{{Position: 1, Type: "integer", Value: "1", ValueRaw: int64(1)}},
 // 	},
 // }
 
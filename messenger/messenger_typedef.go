@@ -0,0 +1,142 @@
+package messenger
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/senzing-garage/go-messaging/go/typedef"
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The FromSenzingMessage function converts the consumer representation of a message
+into the producer representation.
+It is the inverse of ToSenzingMessage.
+
+Input
+  - senzingMessage: A message, usually from parser.Parse() or parser.ParseLossless().
+
+Output
+  - The equivalent MessageFormat.
+*/
+func FromSenzingMessage(senzingMessage *typedef.SenzingMessage) *MessageFormat {
+	result := &MessageFormat{
+		Level:       senzingMessage.Level,
+		ID:          senzingMessage.ID,
+		Text:        senzingMessage.Text,
+		Code:        senzingMessage.Code,
+		Reason:      senzingMessage.Reason,
+		Remediation: senzingMessage.Remediation,
+		Help:        senzingMessage.Help,
+		Status:      senzingMessage.Status,
+		Duration:    senzingMessage.Duration,
+		Location:    senzingMessage.Location,
+	}
+
+	if !senzingMessage.Time.IsZero() {
+		result.Time = senzingMessage.Time.Format(time.RFC3339Nano)
+	}
+
+	if senzingMessage.Caller != nil {
+		caller := Frame(*senzingMessage.Caller)
+		result.Caller = &caller
+	}
+
+	if len(senzingMessage.Errors) > 0 {
+		result.Errors = append([]string{}, senzingMessage.Errors...)
+	}
+
+	if len(senzingMessage.Details) > 0 {
+		result.Details = make([]Detail, 0, len(senzingMessage.Details))
+		for _, detail := range senzingMessage.Details {
+			result.Details = append(result.Details, Detail(detail))
+		}
+	}
+
+	if len(senzingMessage.Stack) > 0 {
+		result.Stack = make([]Frame, 0, len(senzingMessage.Stack))
+		for _, frame := range senzingMessage.Stack {
+			result.Stack = append(result.Stack, Frame(frame))
+		}
+	}
+
+	return result
+}
+
+/*
+The MarshalSenzingMessage function creates the canonical JSON representation of a message.
+For a message parsed by parser.ParseLossless() from the output of NewJSON(),
+the result is identical to the original NewJSON() output.
+
+Input
+  - senzingMessage: A message, usually from parser.ParseLossless().
+
+Output
+  - A JSON string with fields in MessageFormat order and empty fields omitted.
+*/
+func MarshalSenzingMessage(senzingMessage *typedef.SenzingMessage) (string, error) {
+	return marshalMessageFormat(FromSenzingMessage(senzingMessage))
+}
+
+/*
+The ToSenzingMessage function converts the producer representation of a message
+into the consumer representation, as returned by parser.Parse().
+
+Input
+  - messageFormat: A message, usually from populating a BasicMessenger message.
+
+Output
+  - The equivalent typedef.SenzingMessage.
+  - An error if the "time" field is not in RFC3339 format.
+*/
+func ToSenzingMessage(messageFormat *MessageFormat) (*typedef.SenzingMessage, error) {
+	result := &typedef.SenzingMessage{
+		Code:        messageFormat.Code,
+		Duration:    messageFormat.Duration,
+		Help:        messageFormat.Help,
+		ID:          messageFormat.ID,
+		Level:       messageFormat.Level,
+		Location:    messageFormat.Location,
+		Reason:      messageFormat.Reason,
+		Remediation: messageFormat.Remediation,
+		Status:      messageFormat.Status,
+		Text:        messageFormat.Text,
+	}
+
+	if len(messageFormat.Time) > 0 {
+		messageTime, err := time.Parse(time.RFC3339Nano, messageFormat.Time)
+		if err != nil {
+			return nil, fmt.Errorf("messenger.ToSenzingMessage error: %w", err)
+		}
+
+		result.Time = messageTime
+	}
+
+	if messageFormat.Caller != nil {
+		caller := typedef.Frame(*messageFormat.Caller)
+		result.Caller = &caller
+	}
+
+	if len(messageFormat.Errors) > 0 {
+		result.Errors = append(typedef.Errors{}, messageFormat.Errors...)
+	}
+
+	if len(messageFormat.Details) > 0 {
+		result.Details = make(typedef.Details, 0, len(messageFormat.Details))
+		for _, detail := range messageFormat.Details {
+			result.Details = append(result.Details, typedef.Detail(detail))
+		}
+	}
+
+	if len(messageFormat.Stack) > 0 {
+		result.Stack = make(typedef.Stack, 0, len(messageFormat.Stack))
+		for _, frame := range messageFormat.Stack {
+			result.Stack = append(result.Stack, typedef.Frame(frame))
+		}
+	}
+
+	return result, nil
+}
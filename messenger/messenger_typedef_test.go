@@ -0,0 +1,216 @@
+package messenger_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/senzing-garage/go-messaging/go/typedef"
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/senzing-garage/go-messaging/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Random details for property-based tests.
+type randomDetails []interface{}
+
+// Characters that exercise JSON escaping and HTML-escaping.
+const randomAlphabet = `abcXYZ019 <>&"\/é日本` + "\t\n"
+
+var roundTripMessageNumbers = []int{1, 1001, 2001, 3001, 3004, 4001, 5001, 6001}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestMarshalSenzingMessage_roundTrip(test *testing.T) {
+	test.Parallel()
+
+	testObject, err := messenger.New(
+		getOptionMessageIDTemplate(9999),
+		getOptionMessageFieldsAll(),
+		getOptionIDMessages(),
+		getOptionIDStatuses(),
+		getOptionCallerSkip(),
+	)
+	require.NoError(test, err)
+
+	property := func(messageNumberIndex uint8, details randomDetails) bool {
+		messageNumber := roundTripMessageNumbers[int(messageNumberIndex)%len(roundTripMessageNumbers)]
+		original := testObject.NewJSON(messageNumber, details...)
+
+		parsedMessage, err := parser.ParseLossless(original)
+		if err != nil {
+			test.Logf("ParseLossless(%s): %v", original, err)
+
+			return false
+		}
+
+		actual, err := messenger.MarshalSenzingMessage(parsedMessage)
+		if err != nil || actual != original {
+			test.Logf("\nexpected: %s\nactual:   %s\nerror:    %v", original, actual, err)
+
+			return false
+		}
+
+		return true
+	}
+
+	require.NoError(test, quick.Check(property, &quick.Config{MaxCount: 500}))
+}
+
+func TestToSenzingMessage_roundTrip(test *testing.T) {
+	test.Parallel()
+
+	testObject, err := messenger.New(getOptionMessageFieldsAll(), getOptionIDMessages(), getOptionIDStatuses())
+	require.NoError(test, err)
+
+	property := func(details randomDetails) bool {
+		messageFormat := &messenger.MessageFormat{}
+
+		err := json.Unmarshal([]byte(testObject.NewJSON(4001, details...)), messageFormat)
+		if err != nil {
+			return false
+		}
+
+		senzingMessage, err := messenger.ToSenzingMessage(messageFormat)
+		if err != nil {
+			return false
+		}
+
+		return reflect.DeepEqual(messageFormat, messenger.FromSenzingMessage(senzingMessage))
+	}
+
+	require.NoError(test, quick.Check(property, &quick.Config{MaxCount: 500}))
+}
+
+func TestToSenzingMessage(test *testing.T) {
+	test.Parallel()
+
+	messageFormat := &messenger.MessageFormat{
+		Time:     "2000-01-01T00:00:00.5Z",
+		ID:       "SZSDK99994001",
+		Errors:   []string{"error 1"},
+		Details:  []messenger.Detail{{Position: 1, Type: "integer", Value: "5", ValueRaw: 5}},
+		Duration: 1234,
+	}
+	expected := &typedef.SenzingMessage{
+		Time:     time.Date(2000, time.January, 1, 0, 0, 0, 500000000, time.UTC),
+		ID:       "SZSDK99994001",
+		Errors:   typedef.Errors{"error 1"},
+		Details:  typedef.Details{{Position: 1, Type: "integer", Value: "5", ValueRaw: 5}},
+		Duration: 1234,
+	}
+
+	actual, err := messenger.ToSenzingMessage(messageFormat)
+	require.NoError(test, err)
+	assert.Equal(test, expected, actual)
+	assert.Equal(test, messageFormat, messenger.FromSenzingMessage(actual))
+}
+
+func TestToSenzingMessage_badTime(test *testing.T) {
+	test.Parallel()
+
+	_, err := messenger.ToSenzingMessage(&messenger.MessageFormat{Time: "yesterday"})
+	require.Error(test, err)
+}
+
+func TestMarshalSenzingMessage_empty(test *testing.T) {
+	test.Parallel()
+
+	actual, err := messenger.MarshalSenzingMessage(&typedef.SenzingMessage{})
+	require.NoError(test, err)
+	assert.Equal(test, "{}", actual)
+}
+
+// ----------------------------------------------------------------------------
+// Internal functions - names begin with lowercase letter
+// ----------------------------------------------------------------------------
+
+// Generate implements quick.Generator.
+func (randomDetails) Generate(random *rand.Rand, size int) reflect.Value {
+	result := randomDetails{}
+
+	for range random.Intn(size%8 + 1) {
+		result = append(result, randomDetail(random))
+	}
+
+	return reflect.ValueOf(result)
+}
+
+func randomDetail(random *rand.Rand) interface{} {
+	switch random.Intn(12) {
+	case 0:
+		return nil
+	case 1:
+		return random.Int()
+	case 2:
+		return random.NormFloat64() * 1e6
+	case 3:
+		return random.Intn(2) == 0
+	case 4:
+		return errors.New(randomString(random)) //nolint
+	case 5:
+		return randomJSON(random, 3)
+	case 6:
+		return map[string]string{randomString(random): randomString(random), "json": randomJSON(random, 2)}
+	case 7:
+		return time.Duration(random.Int63())
+	case 8:
+		return messenger.MessageReason{Value: randomString(random)}
+	case 9:
+		return messenger.MessageCode{Value: randomString(random)}
+	case 10:
+		return int64(random.Int63())
+	default:
+		return randomString(random)
+	}
+}
+
+// A JSON document with keys in random, unsorted order and numbers that do not survive float64.
+func randomJSON(random *rand.Rand, depth int) string {
+	if depth == 0 {
+		switch random.Intn(4) {
+		case 0:
+			return fmt.Sprintf("%d", random.Int63())
+		case 1:
+			return "1.50"
+		case 2:
+			return "null"
+		default:
+			value, _ := json.Marshal(randomString(random))
+
+			return string(value)
+		}
+	}
+
+	members := []string{}
+	for index := range random.Intn(4) {
+		key := fmt.Sprintf("%c%d", 'z'-rune(index), random.Intn(100))
+		members = append(members, fmt.Sprintf("%q: %s", key, randomJSON(random, depth-1)))
+	}
+
+	if random.Intn(2) == 0 {
+		return "[" + randomJSON(random, depth-1) + ", " + randomJSON(random, 0) + "]"
+	}
+
+	return "{\n\t" + strings.Join(members, ",\n\t") + "\n}"
+}
+
+func randomString(random *rand.Rand) string {
+	alphabet := []rune(randomAlphabet)
+	result := make([]rune, random.Intn(12))
+
+	for index := range result {
+		result[index] = alphabet[random.Intn(len(alphabet))]
+	}
+
+	return string(result)
+}
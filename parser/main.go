@@ -2,11 +2,40 @@ package parser //revive:disable-line var-naming
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
 
 	"github.com/senzing-garage/go-messaging/go/typedef"
+	"github.com/senzing-garage/go-messaging/registry"
 )
 
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A typedef.Detail whose "valueRaw" is kept as undecoded JSON.
+type losslessDetail struct {
+	typedef.Detail
+	ValueRaw json.RawMessage `json:"valueRaw"`
+}
+
+// A typedef.SenzingMessage whose details keep "valueRaw" as undecoded JSON.
+type losslessMessage struct {
+	typedef.SenzingMessage
+	Details []losslessDetail `json:"details"`
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var ErrLocationFormat = errors.New("location is not in the form \"In function() at file:line\"")
+
+// The legacy "location" format, e.g. "In func1() at messenger_test.go:173".
+var locationPattern = regexp.MustCompile(`^In (.*)\(\) at (.*):(\d+)$`)
+
 // ----------------------------------------------------------------------------
 // Public functions
 // ----------------------------------------------------------------------------
@@ -24,3 +53,76 @@ func Parse(message string) (*typedef.SenzingMessage, error) {
 
 	return result, err
 }
+
+/*
+The ParseLossless function is like Parse, except that each Detail.ValueRaw is a
+json.RawMessage holding the original JSON text rather than a decoded value.
+This keeps object key order and number precision, so
+messenger.MarshalSenzingMessage() can reproduce the original message exactly.
+*/
+func ParseLossless(message string) (*typedef.SenzingMessage, error) {
+	parsedMessage := &losslessMessage{}
+
+	err := json.Unmarshal([]byte(message), parsedMessage)
+	if err != nil {
+		return &parsedMessage.SenzingMessage, fmt.Errorf("parser.ParseLossless error: %w", err)
+	}
+
+	result := &parsedMessage.SenzingMessage
+	if parsedMessage.Details != nil {
+		result.Details = make(typedef.Details, 0, len(parsedMessage.Details))
+	}
+
+	for _, detail := range parsedMessage.Details {
+		if len(detail.ValueRaw) > 0 && string(detail.ValueRaw) != "null" {
+			detail.Detail.ValueRaw = detail.ValueRaw
+		}
+
+		result.Details = append(result.Details, detail.Detail)
+	}
+
+	return result, nil
+}
+
+/*
+The ParseLocation function decomposes a legacy "location" string, such as
+"In func1() at messenger_test.go:173", into a typedef.Frame.
+The package is unknown, the function is unqualified, and the file has no directory.
+When available, use the structured "caller" field instead.
+*/
+func ParseLocation(location string) (*typedef.Frame, error) {
+	matches := locationPattern.FindStringSubmatch(location)
+	if matches == nil {
+		return nil, fmt.Errorf("parser.ParseLocation error: %q: %w", location, ErrLocationFormat)
+	}
+
+	line, err := strconv.ParseInt(matches[3], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parser.ParseLocation error: %w", err)
+	}
+
+	result := &typedef.Frame{
+		File:     matches[2],
+		Function: matches[1],
+		Line:     int32(line),
+	}
+
+	return result, nil
+}
+
+/*
+The Resolve function identifies the component, message number, and template of a parsed message.
+If aRegistry is nil, registry.Default() is used.
+*/
+func Resolve(message *typedef.SenzingMessage, aRegistry *registry.Registry) (*registry.Resolution, error) {
+	if aRegistry == nil {
+		aRegistry = registry.Default()
+	}
+
+	result, err := aRegistry.Resolve(message.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parser.Resolve error: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,446 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/senzing-garage/go-messaging/go/typedef"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+A Follower reads a growing log file, as "tail -F" does, for use as a Scanner.
+When the file is renamed or removed and recreated, as by log rotation, the Follower
+reads the rest of the old file and continues with the new one.
+When the file is truncated, the Follower continues from its start.
+Truncation is noticed even if the file grows past the offset before the next read,
+as the last bytes read are then no longer found just before the offset.
+*/
+type Follower struct {
+	ctx          context.Context
+	err          error
+	file         *os.File
+	filename     string
+	identity     fileIdentity // Identity of the file of the current line.
+	line         string
+	message      *typedef.SenzingMessage
+	offset       int64  // Offset just past the current line.
+	partial      []byte // Bytes read after the last complete line.
+	pending      []pendingLine
+	pollInterval time.Duration
+	readBuffer   []byte
+	readOffset   int64           // Offset in the current file of the next byte to read.
+	resume       *FollowPosition // Position to check against the file when it is first opened.
+	size         int64           // Bytes of the file of the current line known to exist.
+	tail         []byte          // The last bytes read, up to followTailBytes, which end at readOffset.
+}
+
+/*
+A FollowPosition is where a Follower is in a file, with the identity of the file, so that following can be resumed
+safely with OptionFollowPosition.  It can be saved as JSON.
+*/
+type FollowPosition struct {
+	Device uint64 `json:"device,omitempty"` // Device, or volume serial number, of the file.  Zero if unknown.
+	Inode  uint64 `json:"inode,omitempty"`  // Inode, or file index, of the file.  Zero if unknown.
+	Offset int64  `json:"offset"`           // Offset just past the current line.
+	Size   int64  `json:"size,omitempty"`   // Bytes of the file known to exist when the position was taken.
+}
+
+// The device and inode, or volume serial number and file index, of a file.
+type fileIdentity struct {
+	device uint64
+	inode  uint64
+}
+
+// A complete line not yet returned by Scan.
+type pendingLine struct {
+	end  int64 // Offset in its file just past the line ending.
+	text []byte
+}
+
+// Offset in the current file at which to start following.  Use FollowFromEnd to skip existing lines.
+type OptionFollowOffset struct {
+	Value int64
+}
+
+// Position, from Follower.Position(), at which to resume following.
+// If the file is not the one the position was taken from, or has shrunk since, following starts at its start.
+type OptionFollowPosition struct {
+	Value FollowPosition
+}
+
+// How often to check for new lines, rotation, and truncation.  Default: DefaultPollInterval.
+type OptionFollowPollInterval struct {
+	Value time.Duration
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Value for OptionFollowOffset which starts at the end of the file.
+const FollowFromEnd = -1
+
+// Default for OptionFollowPollInterval.
+const DefaultPollInterval = 250 * time.Millisecond
+
+// Bytes read at a time.
+const followReadBytes = 64 * 1024
+
+// Bytes kept from the end of the last read for detecting truncation.
+const followTailBytes = 256
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var ErrLineTooLong = errors.New("line longer than MaxLineBytes")
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewFollower function creates a Follower of a file.
+The file need not exist yet.
+
+Input
+  - ctx: Following stops when ctx is done.
+  - filename: The file to follow.
+  - options: OptionFollowOffset, OptionFollowPosition, and OptionFollowPollInterval.
+
+Output
+  - A Follower, which must be closed.
+  - An error if the file exists but cannot be opened.
+*/
+func NewFollower(ctx context.Context, filename string, options ...interface{}) (*Follower, error) {
+	result := &Follower{
+		ctx:          ctx,
+		filename:     filename,
+		pollInterval: DefaultPollInterval,
+		readBuffer:   make([]byte, followReadBytes),
+	}
+
+	for _, value := range options {
+		switch typedValue := value.(type) {
+		case OptionFollowOffset:
+			result.readOffset = typedValue.Value
+		case OptionFollowPosition:
+			result.readOffset = typedValue.Value.Offset
+			result.resume = &typedValue.Value
+		case OptionFollowPollInterval:
+			result.pollInterval = typedValue.Value
+		}
+	}
+
+	err := result.open()
+	if err != nil {
+		return nil, err
+	}
+
+	result.offset = result.readOffset
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Close method closes the followed file.
+*/
+func (follower *Follower) Close() error {
+	if follower.file == nil {
+		return nil
+	}
+
+	err := follower.file.Close()
+	follower.file = nil
+
+	if err != nil {
+		return fmt.Errorf("parser.Follower error: %w", err)
+	}
+
+	return nil
+}
+
+/*
+The Err method returns the first error following the file.
+It is nil if Scan stopped because ctx is done.
+*/
+func (follower *Follower) Err() error {
+	return follower.err
+}
+
+/*
+The Line method returns the text of the current line, without the line ending.
+*/
+func (follower *Follower) Line() string {
+	return follower.line
+}
+
+/*
+The Message method returns the current line parsed as a message, or nil if it is not a message.
+*/
+func (follower *Follower) Message() *typedef.SenzingMessage {
+	return follower.message
+}
+
+/*
+The Offset method returns the offset, in the current file, just past the current line.
+Saving it and passing it to OptionFollowOffset resumes after the current line.
+*/
+func (follower *Follower) Offset() int64 {
+	return follower.offset
+}
+
+/*
+The Position method returns the Offset() with the identity of the file it is in.
+Saving it and passing it to OptionFollowPosition resumes after the current line,
+unless the file has since been rotated or truncated.
+*/
+func (follower *Follower) Position() FollowPosition {
+	return FollowPosition{
+		Device: follower.identity.device,
+		Inode:  follower.identity.inode,
+		Offset: follower.offset,
+		Size:   max(follower.size, follower.offset),
+	}
+}
+
+/*
+The Scan method waits for and advances to the next complete line.
+
+Output
+  - False when ctx is done or on an error.  See Err().
+*/
+func (follower *Follower) Scan() bool {
+	follower.line = ""
+	follower.message = nil
+
+	if follower.ctx.Err() != nil {
+		return false
+	}
+
+	for len(follower.pending) == 0 {
+		if follower.err != nil {
+			return false
+		}
+
+		isRead, err := follower.read()
+		if err != nil {
+			follower.err = err
+
+			return false
+		}
+
+		if isRead {
+			continue
+		}
+
+		select {
+		case <-follower.ctx.Done():
+			return false
+		case <-time.After(follower.pollInterval):
+		}
+	}
+
+	line := follower.pending[0]
+	follower.pending = follower.pending[1:]
+	follower.offset = line.end
+	follower.line = string(bytes.TrimRight(line.text, "\r"))
+	follower.message = parseLine(follower.line)
+
+	return true
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Open the file, if it exists, at the requested offset or, if the file is shorter or not the resumed file, at its start.
+func (follower *Follower) open() error {
+	resume := follower.resume
+	follower.resume = nil
+
+	file, err := os.Open(follower.filename)
+	if errors.Is(err, os.ErrNotExist) {
+		follower.readOffset = 0
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("parser.Follower error: %w", err)
+	}
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		file.Close()
+
+		return fmt.Errorf("parser.Follower error: %w", err)
+	}
+
+	identity, hasIdentity := identityOf(file)
+
+	switch {
+	case follower.readOffset == FollowFromEnd:
+		follower.readOffset = fileInfo.Size()
+	case follower.readOffset > fileInfo.Size() || follower.readOffset < 0:
+		follower.readOffset = 0
+	case resume != nil && resume.Size > fileInfo.Size():
+		follower.readOffset = 0
+	case resume != nil && hasIdentity && (resume.Device != 0 || resume.Inode != 0) &&
+		(resume.Device != identity.device || resume.Inode != identity.inode):
+		follower.readOffset = 0
+	}
+
+	_, err = file.Seek(follower.readOffset, io.SeekStart)
+	if err != nil {
+		file.Close()
+
+		return fmt.Errorf("parser.Follower error: %w", err)
+	}
+
+	follower.file = file
+	follower.identity = identity
+	follower.size = follower.readOffset
+	follower.tail = nil
+
+	return nil
+}
+
+/*
+Read available bytes into pending lines.
+At the end of the file, check for truncation and rotation.
+
+Output
+  - True if anything was read or the file changed, so that reading should be retried at once.
+*/
+func (follower *Follower) read() (bool, error) {
+	if follower.file == nil {
+		err := follower.open()
+
+		return follower.file != nil, err
+	}
+
+	isTruncated, err := follower.isTruncated()
+	if err != nil {
+		return false, err
+	}
+
+	if isTruncated {
+		return true, follower.restart()
+	}
+
+	count, err := follower.file.Read(follower.readBuffer)
+	if count > 0 {
+		follower.split(follower.readBuffer[:count])
+
+		return true, nil
+	}
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("parser.Follower error: %w", err)
+	}
+
+	return follower.reopenIfChanged()
+}
+
+// At the end of the file, start over if the file was truncated, or switch to a new file if it was rotated.
+func (follower *Follower) reopenIfChanged() (bool, error) {
+	current, err := follower.file.Stat()
+	if err != nil {
+		return false, fmt.Errorf("parser.Follower error: %w", err)
+	}
+
+	if current.Size() < follower.readOffset {
+		return true, follower.restart()
+	}
+
+	named, err := os.Stat(follower.filename)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && os.SameFile(current, named)) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("parser.Follower error: %w", err)
+	}
+
+	// Rotated.  A partial last line of the old file is complete.
+
+	if len(follower.partial) > 0 {
+		follower.pending = append(follower.pending, pendingLine{end: follower.readOffset, text: follower.partial})
+		follower.partial = nil
+	}
+
+	err = follower.Close()
+	follower.readOffset = 0
+
+	return true, err
+}
+
+// Determine if the file was truncated since the last read, even if it has grown again since, by checking that the last bytes read are still before the read offset.
+func (follower *Follower) isTruncated() (bool, error) {
+	if len(follower.tail) == 0 {
+		return false, nil
+	}
+
+	buffer := make([]byte, len(follower.tail))
+
+	count, err := follower.file.ReadAt(buffer, follower.readOffset-int64(len(buffer)))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("parser.Follower error: %w", err)
+	}
+
+	return !bytes.Equal(buffer[:count], follower.tail), nil
+}
+
+// Continue from the start of a truncated file.
+func (follower *Follower) restart() error {
+	follower.partial = nil
+	follower.readOffset = 0
+	follower.size = 0
+	follower.tail = nil
+
+	_, err := follower.file.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("parser.Follower error: %w", err)
+	}
+
+	return nil
+}
+
+// Append bytes to the partial line, moving complete lines to pending.
+func (follower *Follower) split(data []byte) {
+	tail := append(follower.tail, data...) //nolint:gocritic
+	follower.tail = append([]byte(nil), tail[max(0, len(tail)-followTailBytes):]...)
+	follower.size = max(follower.size, follower.readOffset+int64(len(data)))
+	follower.readOffset += int64(len(data))
+	follower.partial = append(follower.partial, data...)
+	start := follower.readOffset - int64(len(follower.partial))
+
+	for {
+		index := bytes.IndexByte(follower.partial, '\n')
+		if index < 0 {
+			break
+		}
+
+		start += int64(index + 1)
+		follower.pending = append(follower.pending, pendingLine{end: start, text: follower.partial[:index:index]})
+		follower.partial = follower.partial[index+1:]
+	}
+
+	if len(follower.partial) > MaxLineBytes {
+		follower.err = fmt.Errorf("parser.Follower error: %w", ErrLineTooLong)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix && !windows
+
+package parser
+
+import "os"
+
+// Files have no identity on this platform, so a FollowPosition is checked only by size.
+func identityOf(_ *os.File) (fileIdentity, bool) {
+	return fileIdentity{}, false
+}
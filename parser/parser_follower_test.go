@@ -0,0 +1,243 @@
+package parser_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-messaging/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	followLine1 = `{"level":"INFO","id":"SZSDK99992001","text":"one"}`
+	followLine2 = `{"level":"WARN","id":"SZSDK99993001","text":"two"}`
+	followLine3 = `{"level":"ERROR","id":"SZSDK99994001","text":"three"}`
+)
+
+const followPollInterval = 5 * time.Millisecond
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestFollower_Scan(test *testing.T) {
+	test.Parallel()
+
+	filename := filepath.Join(test.TempDir(), "follow.log")
+	writeFile(test, filename, followLine1+"\nnot a message\n")
+
+	follower := newTestFollower(test, filename)
+
+	requireScan(test, follower, followLine1)
+	assert.Equal(test, "SZSDK99992001", follower.Message().ID)
+	assert.Equal(test, int64(len(followLine1)+1), follower.Offset())
+	requireScan(test, follower, "not a message")
+	assert.Nil(test, follower.Message())
+
+	// A partial line waits for its line ending.
+
+	appendFile(test, filename, followLine2[:10])
+	appendFile(test, filename, followLine2[10:]+"\r\n")
+	requireScan(test, follower, followLine2)
+	assert.Equal(test, "SZSDK99993001", follower.Message().ID)
+}
+
+func TestFollower_Scan_notExist(test *testing.T) {
+	test.Parallel()
+
+	filename := filepath.Join(test.TempDir(), "follow.log")
+	follower := newTestFollower(test, filename, parser.OptionFollowOffset{Value: parser.FollowFromEnd})
+
+	writeFile(test, filename, followLine1+"\n")
+	requireScan(test, follower, followLine1)
+}
+
+func TestFollower_Scan_fromEnd(test *testing.T) {
+	test.Parallel()
+
+	filename := filepath.Join(test.TempDir(), "follow.log")
+	writeFile(test, filename, followLine1+"\n")
+
+	follower := newTestFollower(test, filename, parser.OptionFollowOffset{Value: parser.FollowFromEnd})
+	assert.Equal(test, int64(len(followLine1)+1), follower.Offset())
+
+	appendFile(test, filename, followLine2+"\n")
+	requireScan(test, follower, followLine2)
+}
+
+func TestFollower_Scan_resume(test *testing.T) {
+	test.Parallel()
+
+	filename := filepath.Join(test.TempDir(), "follow.log")
+	writeFile(test, filename, followLine1+"\n"+followLine2+"\n")
+
+	follower := newTestFollower(test, filename)
+	requireScan(test, follower, followLine1)
+	offset := follower.Offset()
+	require.NoError(test, follower.Close())
+
+	follower = newTestFollower(test, filename, parser.OptionFollowOffset{Value: offset})
+	requireScan(test, follower, followLine2)
+}
+
+func TestFollower_Scan_truncated(test *testing.T) {
+	test.Parallel()
+
+	filename := filepath.Join(test.TempDir(), "follow.log")
+	writeFile(test, filename, followLine1+"\n"+followLine2+"\n")
+
+	follower := newTestFollower(test, filename)
+	requireScan(test, follower, followLine1)
+	requireScan(test, follower, followLine2)
+
+	writeFile(test, filename, followLine3+"\n")
+	requireScan(test, follower, followLine3)
+	assert.Equal(test, int64(len(followLine3)+1), follower.Offset())
+}
+
+func TestFollower_Scan_truncatedAndGrown(test *testing.T) {
+	test.Parallel()
+
+	filename := filepath.Join(test.TempDir(), "follow.log")
+	writeFile(test, filename, followLine1+"\n")
+
+	follower := newTestFollower(test, filename)
+	requireScan(test, follower, followLine1)
+
+	// Truncated, then grown past the offset before the next read.
+
+	writeFile(test, filename, followLine2+"\n"+followLine3+"\n")
+	requireScan(test, follower, followLine2)
+	requireScan(test, follower, followLine3)
+}
+
+func TestFollower_Position(test *testing.T) {
+	test.Parallel()
+
+	filename := filepath.Join(test.TempDir(), "follow.log")
+	writeFile(test, filename, followLine1+"\n"+followLine2+"\n")
+
+	follower := newTestFollower(test, filename)
+	requireScan(test, follower, followLine1)
+	position := follower.Position()
+	require.NoError(test, follower.Close())
+	assert.Equal(test, int64(len(followLine1)+1), position.Offset)
+	assert.Equal(test, int64(len(followLine1)+len(followLine2)+2), position.Size)
+
+	follower = newTestFollower(test, filename, parser.OptionFollowPosition{Value: position})
+	requireScan(test, follower, followLine2)
+	require.NoError(test, follower.Close())
+
+	// Shrunk since the position was taken.
+
+	writeFile(test, filename, followLine3+"\n")
+	follower = newTestFollower(test, filename, parser.OptionFollowPosition{Value: position})
+	requireScan(test, follower, followLine3)
+	require.NoError(test, follower.Close())
+}
+
+func TestFollower_Position_rotated(test *testing.T) {
+	test.Parallel()
+
+	filename := filepath.Join(test.TempDir(), "follow.log")
+	writeFile(test, filename, followLine1+"\n")
+
+	follower := newTestFollower(test, filename)
+	requireScan(test, follower, followLine1)
+	position := follower.Position()
+	require.NoError(test, follower.Close())
+
+	if position.Device == 0 && position.Inode == 0 {
+		test.Skip("files have no identity on this platform")
+	}
+
+	// Rotated while stopped, to a new file longer than the old one.
+
+	require.NoError(test, os.Rename(filename, filename+".1"))
+	writeFile(test, filename, followLine2+"\n"+followLine3+"\n")
+
+	follower = newTestFollower(test, filename, parser.OptionFollowPosition{Value: position})
+	requireScan(test, follower, followLine2)
+}
+
+func TestFollower_Scan_rotated(test *testing.T) {
+	test.Parallel()
+
+	filename := filepath.Join(test.TempDir(), "follow.log")
+	writeFile(test, filename, followLine1+"\n")
+
+	follower := newTestFollower(test, filename)
+	requireScan(test, follower, followLine1)
+
+	// Lines written to the old file before rotation are read, including a last line without a line ending.
+
+	appendFile(test, filename, followLine2)
+	require.NoError(test, os.Rename(filename, filename+".1"))
+	writeFile(test, filename, followLine3+"\n")
+
+	requireScan(test, follower, followLine2)
+	requireScan(test, follower, followLine3)
+	assert.Equal(test, int64(len(followLine3)+1), follower.Offset())
+}
+
+func TestFollower_Scan_cancel(test *testing.T) {
+	test.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	filename := filepath.Join(test.TempDir(), "follow.log")
+	follower, err := parser.NewFollower(ctx, filename, parser.OptionFollowPollInterval{Value: followPollInterval})
+	require.NoError(test, err)
+
+	defer follower.Close()
+
+	time.AfterFunc(10*followPollInterval, cancel)
+	assert.False(test, follower.Scan())
+	require.NoError(test, follower.Err())
+}
+
+// ----------------------------------------------------------------------------
+// Helpers
+// ----------------------------------------------------------------------------
+
+func appendFile(test *testing.T, filename string, text string) {
+	test.Helper()
+
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(test, err)
+
+	_, err = file.WriteString(text)
+	require.NoError(test, err)
+	require.NoError(test, file.Close())
+}
+
+// Create a Follower that gives up after a few seconds, so a missing line fails rather than hangs.
+func newTestFollower(test *testing.T, filename string, options ...interface{}) *parser.Follower {
+	test.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	test.Cleanup(cancel)
+
+	follower, err := parser.NewFollower(ctx, filename, append(options, parser.OptionFollowPollInterval{Value: followPollInterval})...)
+	require.NoError(test, err)
+	test.Cleanup(func() { follower.Close() })
+
+	return follower
+}
+
+func requireScan(test *testing.T, follower *parser.Follower, expected string) {
+	test.Helper()
+
+	require.True(test, follower.Scan())
+	require.NoError(test, follower.Err())
+	assert.Equal(test, expected, follower.Line())
+}
+
+func writeFile(test *testing.T, filename string, text string) {
+	test.Helper()
+
+	require.NoError(test, os.WriteFile(filename, []byte(text), 0o600))
+}
@@ -0,0 +1,23 @@
+//go:build unix
+
+package parser
+
+import (
+	"os"
+	"syscall"
+)
+
+// The device and inode of an open file.
+func identityOf(file *os.File) (fileIdentity, bool) {
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return fileIdentity{}, false
+	}
+
+	stat, isStat := fileInfo.Sys().(*syscall.Stat_t)
+	if !isStat {
+		return fileIdentity{}, false
+	}
+
+	return fileIdentity{device: uint64(stat.Dev), inode: uint64(stat.Ino)}, true //nolint:gosec,unconvert
+}
@@ -0,0 +1,23 @@
+//go:build windows
+
+package parser
+
+import (
+	"os"
+	"syscall"
+)
+
+// The volume serial number and file index of an open file.
+func identityOf(file *os.File) (fileIdentity, bool) {
+	var information syscall.ByHandleFileInformation
+
+	err := syscall.GetFileInformationByHandle(syscall.Handle(file.Fd()), &information)
+	if err != nil {
+		return fileIdentity{}, false
+	}
+
+	return fileIdentity{
+		device: uint64(information.VolumeSerialNumber),
+		inode:  uint64(information.FileIndexHigh)<<32 | uint64(information.FileIndexLow), //nolint:mnd
+	}, true
+}
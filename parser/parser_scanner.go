@@ -0,0 +1,162 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/senzing-garage/go-messaging/go/typedef"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+/*
+A Scanner reads a log of newline-delimited messages, as written by NewJSON().
+Lines that are not messages, such as output of other loggers, do not stop the scan;
+for those lines Message() returns nil.
+A line may have a prefix before the message, as added by the log package.
+A line longer than MaxLineBytes is cut to MaxLineBytes and is not a message, so it does not stop the scan either.
+*/
+type Scanner struct {
+	err     error
+	line    string
+	message *typedef.SenzingMessage
+	reader  *bufio.Reader
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Longest line a Scanner keeps, and a Follower accepts.
+const MaxLineBytes = 16 * 1024 * 1024
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The NewScanner function creates a Scanner reading from reader.
+*/
+func NewScanner(reader io.Reader) *Scanner {
+	return &Scanner{reader: bufio.NewReaderSize(reader, bufio.MaxScanTokenSize)}
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Err method returns the first error reading the input, other than io.EOF.
+*/
+func (scanner *Scanner) Err() error {
+	if scanner.err != nil {
+		return fmt.Errorf("parser.Scanner error: %w", scanner.err)
+	}
+
+	return nil
+}
+
+/*
+The Line method returns the text of the current line, without the line ending.
+*/
+func (scanner *Scanner) Line() string {
+	return scanner.line
+}
+
+/*
+The Message method returns the current line parsed as a message, or nil if it is not a message.
+A line is a message if, after any prefix, it is a JSON object with an "id" or "level".
+*/
+func (scanner *Scanner) Message() *typedef.SenzingMessage {
+	return scanner.message
+}
+
+/*
+The Scan method advances to the next line.
+
+Output
+  - False at the end of the input or on an error.  See Err().
+*/
+func (scanner *Scanner) Scan() bool {
+	scanner.line = ""
+	scanner.message = nil
+
+	if scanner.err != nil {
+		return false
+	}
+
+	line, isCut, isRead := scanner.readLine()
+	if !isRead {
+		return false
+	}
+
+	scanner.line = strings.TrimRight(line, "\r")
+	if !isCut {
+		scanner.message = parseLine(scanner.line)
+	}
+
+	return true
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Read the next line without its line ending, keeping at most MaxLineBytes of it.
+// Returns the line, whether it was cut, and whether there was a line.
+func (scanner *Scanner) readLine() (string, bool, bool) {
+	var (
+		line    []byte
+		isCut   bool
+		isEmpty = true
+	)
+
+	for {
+		chunk, err := scanner.reader.ReadSlice('\n')
+		isEmpty = isEmpty && len(chunk) == 0
+		chunk = bytes.TrimSuffix(chunk, []byte("\n"))
+
+		if keep := MaxLineBytes - len(line); len(chunk) > keep {
+			chunk = chunk[:keep]
+			isCut = true
+		}
+
+		line = append(line, chunk...)
+
+		switch {
+		case errors.Is(err, bufio.ErrBufferFull):
+			continue
+		case err == nil || errors.Is(err, io.EOF):
+			return string(line), isCut, !isEmpty
+		default:
+			scanner.err = err
+
+			return "", false, false
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Parse a line as a message, skipping any prefix, or return nil.
+func parseLine(line string) *typedef.SenzingMessage {
+	start := strings.Index(line, "{")
+	if start < 0 {
+		return nil
+	}
+
+	result, err := Parse(line[start:])
+	if err != nil || (len(result.ID) == 0 && len(result.Level) == 0) {
+		return nil
+	}
+
+	return result
+}
@@ -0,0 +1,65 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/senzing-garage/go-messaging/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestScanner_Scan(test *testing.T) {
+	test.Parallel()
+
+	log := message1 + "\r\n" +
+		"not a message\n" +
+		`2000/01/01 00:00:00 {"level":"WARN","id":"SZSDK99993001","text":"prefixed"}` + "\n" +
+		`{"other":"json"}` + "\n" +
+		`{"id": truncated`
+
+	scanner := parser.NewScanner(strings.NewReader(log))
+
+	var (
+		ids   []string
+		lines []string
+	)
+
+	for scanner.Scan() {
+		lines = append(lines, scanner.Line())
+
+		if scanner.Message() != nil {
+			ids = append(ids, scanner.Message().ID)
+		}
+	}
+
+	require.NoError(test, scanner.Err())
+	assert.Equal(test, []string{"SZSDK99990001", "SZSDK99993001"}, ids)
+	assert.Len(test, lines, 5)
+	assert.Equal(test, message1, lines[0])
+	assert.Equal(test, "not a message", lines[1])
+}
+
+func TestScanner_Scan_longLine(test *testing.T) {
+	test.Parallel()
+
+	// A message too long to keep is cut and is not a message, and the lines after it are still read.
+
+	longMessage := `{"level":"INFO","id":"SZSDK99992001","text":"` + strings.Repeat("x", parser.MaxLineBytes) + `"}`
+	scanner := parser.NewScanner(strings.NewReader(longMessage + "\r\n" + message1 + "\n"))
+
+	require.True(test, scanner.Scan())
+	assert.Len(test, scanner.Line(), parser.MaxLineBytes)
+	assert.Nil(test, scanner.Message())
+
+	require.True(test, scanner.Scan())
+	assert.Equal(test, message1, scanner.Line())
+	assert.Equal(test, "SZSDK99990001", scanner.Message().ID)
+
+	assert.False(test, scanner.Scan())
+	require.NoError(test, scanner.Err())
+}
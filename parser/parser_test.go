@@ -1,13 +1,16 @@
 package parser_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/senzing-garage/go-messaging/go/typedef"
 	"github.com/senzing-garage/go-messaging/parser"
+	"github.com/senzing-garage/go-messaging/registry"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var testCasesForMessage = []struct {
@@ -97,6 +100,58 @@ func TestParse(test *testing.T) {
 	}
 }
 
+func TestParseLossless(test *testing.T) {
+	test.Parallel()
+
+	message := `{"id":"SZSDK99990001","details":[{"position":1,"type":"string","value":"{\"b\": 12345678901234567890, \"a\": 1.50}","valueRaw":{"b":12345678901234567890,"a":1.50}},{"position":2,"value":"x","valueRaw":null}]}`
+
+	parsedMessage, err := parser.ParseLossless(message)
+	require.NoError(test, err)
+	assert.Equal(test, "SZSDK99990001", parsedMessage.ID)
+	require.Len(test, parsedMessage.Details, 2)
+	assert.Equal(test, json.RawMessage(`{"b":12345678901234567890,"a":1.50}`), parsedMessage.Details[0].ValueRaw)
+	assert.Nil(test, parsedMessage.Details[1].ValueRaw)
+
+	_, err = parser.ParseLossless("{Not really JSON}")
+	require.Error(test, err)
+}
+
+func TestParseLocation(test *testing.T) {
+	test.Parallel()
+
+	frame, err := parser.ParseLocation("In func1() at messenger_test.go:173")
+	require.NoError(test, err)
+	assert.Equal(test, &typedef.Frame{File: "messenger_test.go", Function: "func1", Line: 173}, frame)
+
+	_, err = parser.ParseLocation("Test location")
+	require.ErrorIs(test, err, parser.ErrLocationFormat)
+}
+
+func TestResolve(test *testing.T) {
+	test.Parallel()
+
+	aRegistry := registry.New()
+	require.NoError(test, aRegistry.Register(registry.Component{
+		Name:       "test-component",
+		IDTemplate: "SZSDK9999%04d",
+		IDMin:      0,
+		IDMax:      9999,
+		IDMessages: map[int]string{1: "%s works with %s"},
+	}))
+
+	parsedMessage, err := parser.Parse(message1)
+	require.NoError(test, err)
+
+	resolution, err := parser.Resolve(parsedMessage, aRegistry)
+	require.NoError(test, err)
+	assert.Equal(test, "test-component", resolution.Component)
+	assert.Equal(test, 1, resolution.MessageNumber)
+	assert.Equal(test, "%s works with %s", resolution.Template)
+
+	_, err = parser.Resolve(&typedef.SenzingMessage{ID: "SZSDK00010001"}, aRegistry)
+	require.ErrorIs(test, err, registry.ErrUnknownID)
+}
+
 // ----------------------------------------------------------------------------
 // Examples for godoc documentation
 // ----------------------------------------------------------------------------
@@ -64,6 +64,26 @@ class SenzingMessage:
     Time message was generated in RFC3339 format.
     """
 
+    caller: 'Optional[Frame]'
+    """
+    Location in the code, as package, function, file, and line.
+    """
+
+    help: 'Optional[str]'
+    """
+    URL of documentation for the message.
+    """
+
+    remediation: 'Optional[str]'
+    """
+    What to do about the message.
+    """
+
+    stack: 'Optional[Stack]'
+    """
+    Stack trace where the message or its error was created.
+    """
+
 
     @classmethod
     def from_json_data(cls, data: Any) -> 'SenzingMessage':
@@ -79,6 +99,10 @@ class SenzingMessage:
             _from_json_data(str, data.get("status")),
             _from_json_data(str, data.get("text")),
             _from_json_data(datetime, data.get("time")),
+            _from_json_data(Optional[Frame], data.get("caller")),
+            _from_json_data(Optional[str], data.get("help")),
+            _from_json_data(Optional[str], data.get("remediation")),
+            _from_json_data(Optional[Stack], data.get("stack")),
         )
 
     def to_json_data(self) -> Any:
@@ -94,6 +118,14 @@ class SenzingMessage:
         data["status"] = _to_json_data(self.status)
         data["text"] = _to_json_data(self.text)
         data["time"] = _to_json_data(self.time)
+        if self.caller is not None:
+            data["caller"] = _to_json_data(self.caller)
+        if self.help is not None:
+            data["help"] = _to_json_data(self.help)
+        if self.remediation is not None:
+            data["remediation"] = _to_json_data(self.remediation)
+        if self.stack is not None:
+            data["stack"] = _to_json_data(self.stack)
         return data
 
 @dataclass
@@ -192,6 +224,65 @@ class Errors:
     def to_json_data(self) -> Any:
         return _to_json_data(self.value)
 
+@dataclass
+class Frame:
+    """
+    A location in the code.
+    """
+
+    file: 'str'
+    """
+    Source file of the frame.
+    """
+
+    function: 'str'
+    """
+    Function, without the package path.
+    """
+
+    line: 'int'
+    """
+    Line number in the source file.
+    """
+
+    package: 'str'
+    """
+    Package, as an import path.
+    """
+
+
+    @classmethod
+    def from_json_data(cls, data: Any) -> 'Frame':
+        return cls(
+            _from_json_data(str, data.get("file")),
+            _from_json_data(str, data.get("function")),
+            _from_json_data(int, data.get("line")),
+            _from_json_data(str, data.get("package")),
+        )
+
+    def to_json_data(self) -> Any:
+        data: Dict[str, Any] = {}
+        data["file"] = _to_json_data(self.file)
+        data["function"] = _to_json_data(self.function)
+        data["line"] = _to_json_data(self.line)
+        data["package"] = _to_json_data(self.package)
+        return data
+
+@dataclass
+class Stack:
+    """
+    A list of frames.  The innermost frame is first.
+    """
+
+    value: 'List[Frame]'
+
+    @classmethod
+    def from_json_data(cls, data: Any) -> 'Stack':
+        return cls(_from_json_data(List[Frame], data))
+
+    def to_json_data(self) -> Any:
+        return _to_json_data(self.value)
+
 def _from_json_data(cls: Any, data: Any) -> Any:
     if data is None or cls in [bool, int, float, str, object] or cls is Any:
         return data
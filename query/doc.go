@@ -0,0 +1,27 @@
+/*
+Package query selects messages with a small expression language, where grep is
+inadequate for the nested "details" and "errors" of a message.
+
+A query compares fields of a typedef.SenzingMessage with values, combined with
+"and", "or", "not", and parentheses:
+
+	level >= WARN and details.key == "DATA_SOURCE" and errors contains "0027E"
+
+Fields are the names in messenger.AllMessageFields, plus:
+
+	caller.package, caller.function, caller.file, caller.line
+	details.key, details.position, details.type, details.value
+	detail.<key|position>   The value of the detail with that key or, if it has no key, that position.
+	stack.package, stack.function, stack.file, stack.line
+
+Operators are ==, !=, <, <=, >, >=, contains, and matches, whose value is a regular expression.
+A field alone, such as "code", is true if the field is not empty.
+Values are double-quoted Go strings, single-quoted strings, or bare words such as WARN, 27, or 1.5s.
+
+Levels compare in TRACE, DEBUG, INFO, WARN, ERROR, FATAL, PANIC order.
+"duration" compares with integers, in nanoseconds, or with durations such as "1.5s".
+"time" compares with RFC3339 times.
+Fields with several values, such as "errors" and "details.key", are true if any value
+satisfies the comparison, except "!=", which is true if no value is equal.
+*/
+package query
@@ -0,0 +1,70 @@
+package query
+
+import (
+	"errors"
+
+	"github.com/senzing-garage/go-messaging/go/typedef"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A Query is a compiled expression.  It is safe for concurrent use.
+type Query struct {
+	root node
+	text string
+}
+
+// A node of the expression tree.
+type node interface {
+	match(message *typedef.SenzingMessage) bool
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var (
+	ErrSyntax       = errors.New("query syntax error")
+	ErrUnknownField = errors.New("unknown query field")
+	ErrValue        = errors.New("invalid query value")
+)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Compile function parses a query.
+
+Input
+  - text: The query, e.g. `level >= WARN and errors contains "0027E"`.
+
+Output
+  - The compiled query.
+  - An error wrapping ErrSyntax, ErrUnknownField, or ErrValue.
+*/
+func Compile(text string) (*Query, error) {
+	aParser := &queryParser{text: text}
+
+	root, err := aParser.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{root: root, text: text}, nil
+}
+
+/*
+The MustCompile function is like Compile, but panics if the query cannot be parsed.
+It is intended for queries that are constants.
+*/
+func MustCompile(text string) *Query {
+	result, err := Compile(text)
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
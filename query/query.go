@@ -0,0 +1,106 @@
+package query
+
+import (
+	"github.com/senzing-garage/go-messaging/go/typedef"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Both operands are true.
+type andNode struct {
+	left  node
+	right node
+}
+
+// A field compared with a value.  With negate, no value of the field may satisfy predicate.
+type comparisonNode struct {
+	field     field
+	negate    bool
+	predicate func(value interface{}) bool
+}
+
+// A field that is not empty.
+type existsNode struct {
+	field field
+}
+
+// The operand is false.
+type notNode struct {
+	operand node
+}
+
+// Either operand is true.
+type orNode struct {
+	left  node
+	right node
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Match method determines if a message satisfies the query.
+
+Input
+  - message: A message, as returned by parser.Parse().
+
+Output
+  - True if the message satisfies the query.
+*/
+func (query *Query) Match(message *typedef.SenzingMessage) bool {
+	if message == nil {
+		return false
+	}
+
+	return query.root.match(message)
+}
+
+/*
+The String method returns the text of the query, as given to Compile.
+*/
+func (query *Query) String() string {
+	return query.text
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (aNode *andNode) match(message *typedef.SenzingMessage) bool {
+	return aNode.left.match(message) && aNode.right.match(message)
+}
+
+func (aNode *comparisonNode) match(message *typedef.SenzingMessage) bool {
+	isFound := false
+
+	for _, value := range aNode.field.get(message) {
+		if aNode.predicate(value) {
+			isFound = true
+
+			break
+		}
+	}
+
+	return isFound != aNode.negate
+}
+
+func (aNode *existsNode) match(message *typedef.SenzingMessage) bool {
+	for _, value := range aNode.field.get(message) {
+		if isPresent(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (aNode *notNode) match(message *typedef.SenzingMessage) bool {
+	return !aNode.operand.match(message)
+}
+
+func (aNode *orNode) match(message *typedef.SenzingMessage) bool {
+	return aNode.left.match(message) || aNode.right.match(message)
+}
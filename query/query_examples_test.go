@@ -0,0 +1,42 @@
+package query_test
+
+import (
+	"fmt"
+
+	"github.com/senzing-garage/go-messaging/parser"
+	"github.com/senzing-garage/go-messaging/query"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleQuery_Match() {
+	// For more information, visit https://github.com/senzing-garage/go-messaging/blob/main/query/query_examples_test.go
+	aQuery, err := query.Compile(`level >= WARN and details.key == "DATA_SOURCE" and errors contains "0027E"`)
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	for _, line := range []string{
+		`{"level":"INFO","id":"SZSDK99992001","details":[{"key":"DATA_SOURCE","position":1,"value":"TEST"}]}`,
+		`{"level":"ERROR","id":"SZSDK99994001","errors":["0027E Invalid data"],"details":[{"key":"DATA_SOURCE","position":1,"value":"TEST"}]}`,
+	} {
+		message, err := parser.Parse(line)
+		if err != nil {
+			fmt.Println(err)
+		}
+
+		fmt.Println(message.ID, aQuery.Match(message))
+	}
+	// Output:
+	// SZSDK99992001 false
+	// SZSDK99994001 true
+}
+
+func ExampleCompile() {
+	// For more information, visit https://github.com/senzing-garage/go-messaging/blob/main/query/query_examples_test.go
+	_, err := query.Compile(`level >= LOUD`)
+	fmt.Println(err)
+	// Output: query.Compile error: at 10: for "level": invalid query value: "LOUD" is not a level
+}
@@ -0,0 +1,277 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/senzing-garage/go-messaging/go/typedef"
+	"github.com/senzing-garage/go-messaging/messenger"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// How values of a field compare.
+type fieldKind int
+
+// A field of a message.  Values are strings, int64s, or time.Times, according to kind.
+type field struct {
+	get        func(message *typedef.SenzingMessage) []interface{}
+	isDuration bool
+	kind       fieldKind
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	kindString fieldKind = iota
+	kindLevel
+	kindNumber
+	kindTime
+)
+
+// Prefix of the field of one detail, e.g. "detail.DATA_SOURCE" or "detail.2".
+const detailPrefix = "detail."
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var fields = map[string]field{
+	"caller.file":      callerField(kindString, func(frame *typedef.Frame) interface{} { return frame.File }),
+	"caller.function":  callerField(kindString, func(frame *typedef.Frame) interface{} { return frame.Function }),
+	"caller.line":      callerField(kindNumber, func(frame *typedef.Frame) interface{} { return int64(frame.Line) }),
+	"caller.package":   callerField(kindString, func(frame *typedef.Frame) interface{} { return frame.Package }),
+	"code":             stringField(func(message *typedef.SenzingMessage) string { return message.Code }),
+	"details.key":      detailsField(kindString, func(detail typedef.Detail) interface{} { return detail.Key }),
+	"details.position": detailsField(kindNumber, func(detail typedef.Detail) interface{} { return int64(detail.Position) }),
+	"details.type":     detailsField(kindString, func(detail typedef.Detail) interface{} { return detail.Type }),
+	"details.value":    detailsField(kindString, func(detail typedef.Detail) interface{} { return detail.Value }),
+	"duration": {
+		get:        func(message *typedef.SenzingMessage) []interface{} { return []interface{}{message.Duration} },
+		isDuration: true,
+		kind:       kindNumber,
+	},
+	"errors": {
+		get: func(message *typedef.SenzingMessage) []interface{} {
+			result := make([]interface{}, 0, len(message.Errors))
+			for _, anError := range message.Errors {
+				result = append(result, anError)
+			}
+
+			return result
+		},
+		kind: kindString,
+	},
+	"help":     stringField(func(message *typedef.SenzingMessage) string { return message.Help }),
+	"id":       stringField(func(message *typedef.SenzingMessage) string { return message.ID }),
+	"level":    {get: func(message *typedef.SenzingMessage) []interface{} { return []interface{}{message.Level} }, kind: kindLevel},
+	"location": stringField(func(message *typedef.SenzingMessage) string { return message.Location }),
+	"reason":   stringField(func(message *typedef.SenzingMessage) string { return message.Reason }),
+	"remediation": stringField(func(message *typedef.SenzingMessage) string {
+		return message.Remediation
+	}),
+	"stack.file":     stackField(kindString, func(frame typedef.Frame) interface{} { return frame.File }),
+	"stack.function": stackField(kindString, func(frame typedef.Frame) interface{} { return frame.Function }),
+	"stack.line":     stackField(kindNumber, func(frame typedef.Frame) interface{} { return int64(frame.Line) }),
+	"stack.package":  stackField(kindString, func(frame typedef.Frame) interface{} { return frame.Package }),
+	"status":         stringField(func(message *typedef.SenzingMessage) string { return message.Status }),
+	"text":           stringField(func(message *typedef.SenzingMessage) string { return message.Text }),
+	"time":           {get: func(message *typedef.SenzingMessage) []interface{} { return []interface{}{message.Time} }, kind: kindTime},
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Find a field by name.
+func lookupField(name string) (field, bool) {
+	if name, isDetail := strings.CutPrefix(name, detailPrefix); isDetail && len(name) > 0 {
+		return detailField(name), true
+	}
+
+	result, isKnown := fields[name]
+
+	return result, isKnown
+}
+
+// Create the predicate of a comparison of a field with a literal value.
+func newPredicate(aField field, operator string, literal string) (func(value interface{}) bool, error) {
+	if operator == operatorMatches {
+		pattern, err := regexp.Compile(literal)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrValue, err)
+		}
+
+		return func(value interface{}) bool { return pattern.MatchString(valueText(value)) }, nil
+	}
+
+	switch aField.kind {
+	case kindLevel:
+		if operator == operatorContains {
+			break
+		}
+
+		literalLevel, isLevel := messenger.TextToLevelMap[strings.ToUpper(literal)]
+		if !isLevel {
+			return nil, fmt.Errorf("%w: %q is not a level", ErrValue, literal)
+		}
+
+		return func(value interface{}) bool {
+			level, isLevel := messenger.TextToLevelMap[strings.ToUpper(value.(string))]
+
+			return isLevel && compare(int(level), int(literalLevel), operator)
+		}, nil
+	case kindNumber:
+		number, err := parseNumber(literal, aField.isDuration)
+		if err != nil || operator == operatorContains {
+			return nil, fmt.Errorf("%w: %s %q", ErrValue, operator, literal)
+		}
+
+		return func(value interface{}) bool { return compare(value.(int64), number, operator) }, nil
+	case kindTime:
+		literalTime, err := time.Parse(time.RFC3339Nano, literal)
+		if err != nil || operator == operatorContains {
+			return nil, fmt.Errorf("%w: %s %q", ErrValue, operator, literal)
+		}
+
+		return func(value interface{}) bool { return compare(value.(time.Time).Compare(literalTime), 0, operator) }, nil
+	case kindString:
+	}
+
+	if operator == operatorContains {
+		return func(value interface{}) bool { return strings.Contains(value.(string), literal) }, nil
+	}
+
+	return func(value interface{}) bool { return compare(value.(string), literal, operator) }, nil
+}
+
+// Compare with ==, <, <=, >, or >=.
+func compare[T int | int64 | string](left T, right T, operator string) bool {
+	switch operator {
+	case operatorLess:
+		return left < right
+	case operatorLessOrEqual:
+		return left <= right
+	case operatorGreater:
+		return left > right
+	case operatorGreaterOrEqual:
+		return left >= right
+	default:
+		return left == right
+	}
+}
+
+// Determine if a value is not empty.
+func isPresent(value interface{}) bool {
+	switch typedValue := value.(type) {
+	case string:
+		return len(typedValue) > 0
+	case int64:
+		return typedValue != 0
+	case time.Time:
+		return !typedValue.IsZero()
+	default:
+		return false
+	}
+}
+
+// Format a value for "matches".
+func valueText(value interface{}) string {
+	switch typedValue := value.(type) {
+	case int64:
+		return strconv.FormatInt(typedValue, 10)
+	case time.Time:
+		return typedValue.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// Parse an integer or, for durations, a value such as "1.5s".
+func parseNumber(literal string, isDuration bool) (int64, error) {
+	result, err := strconv.ParseInt(literal, 10, 64)
+	if err == nil || !isDuration {
+		return result, err //nolint:wrapcheck
+	}
+
+	duration, err := time.ParseDuration(literal)
+
+	return int64(duration), err //nolint:wrapcheck
+}
+
+// ----------------------------------------------------------------------------
+// Private functions - field constructors
+// ----------------------------------------------------------------------------
+
+func callerField(kind fieldKind, get func(frame *typedef.Frame) interface{}) field {
+	return field{
+		get: func(message *typedef.SenzingMessage) []interface{} {
+			if message.Caller == nil {
+				return nil
+			}
+
+			return []interface{}{get(message.Caller)}
+		},
+		kind: kind,
+	}
+}
+
+// The values of the details whose key is name or, without a key, whose position is name.
+func detailField(name string) field {
+	return field{
+		get: func(message *typedef.SenzingMessage) []interface{} {
+			var result []interface{}
+
+			for _, detail := range message.Details {
+				if detail.Key == name || (len(detail.Key) == 0 && strconv.Itoa(int(detail.Position)) == name) {
+					result = append(result, detail.Value)
+				}
+			}
+
+			return result
+		},
+		kind: kindString,
+	}
+}
+
+func detailsField(kind fieldKind, get func(detail typedef.Detail) interface{}) field {
+	return field{
+		get: func(message *typedef.SenzingMessage) []interface{} {
+			result := make([]interface{}, 0, len(message.Details))
+			for _, detail := range message.Details {
+				result = append(result, get(detail))
+			}
+
+			return result
+		},
+		kind: kind,
+	}
+}
+
+func stackField(kind fieldKind, get func(frame typedef.Frame) interface{}) field {
+	return field{
+		get: func(message *typedef.SenzingMessage) []interface{} {
+			result := make([]interface{}, 0, len(message.Stack))
+			for _, frame := range message.Stack {
+				result = append(result, get(frame))
+			}
+
+			return result
+		},
+		kind: kind,
+	}
+}
+
+func stringField(get func(message *typedef.SenzingMessage) string) field {
+	return field{
+		get:  func(message *typedef.SenzingMessage) []interface{} { return []interface{}{get(message)} },
+		kind: kindString,
+	}
+}
@@ -0,0 +1,316 @@
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+type tokenKind int
+
+// A lexical token.  Position is the byte offset in the query.
+type token struct {
+	kind     tokenKind
+	position int
+	text     string // For tokenString, the unquoted value.
+}
+
+// A recursive descent parser:
+//
+//	or         = and { ("or" | "||") and }
+//	and        = not { ("and" | "&&") not }
+//	not        = ("not" | "!") not | primary
+//	primary    = "(" or ")" | field [ operator value ]
+type queryParser struct {
+	next   int
+	text   string
+	tokens []token
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	tokenEnd tokenKind = iota
+	tokenLeft
+	tokenOperator
+	tokenRight
+	tokenString
+	tokenWord
+)
+
+// Comparison operators.
+const (
+	operatorContains       = "contains"
+	operatorEqual          = "=="
+	operatorGreater        = ">"
+	operatorGreaterOrEqual = ">="
+	operatorLess           = "<"
+	operatorLessOrEqual    = "<="
+	operatorMatches        = "matches"
+	operatorNotEqual       = "!="
+)
+
+// Characters that end a bare word.
+const wordDelimiters = `()<>=!&|"'`
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// Symbols of comparison operators.  "=" is the same as "==".
+var comparisonSymbols = []string{"==", "!=", "<=", ">=", "<", ">", "="}
+
+// Symbols, longest first so that "<=" is not read as "<".
+var symbols = []string{"&&", "||", "==", "!=", "<=", ">=", "(", ")", "<", ">", "!", "="}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+func (aParser *queryParser) parse() (node, error) {
+	err := aParser.tokenize()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := aParser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if aToken := aParser.peek(); aToken.kind != tokenEnd {
+		return nil, aParser.errorf(aToken, ErrSyntax, "unexpected %q", aToken.text)
+	}
+
+	return result, nil
+}
+
+func (aParser *queryParser) parseOr() (node, error) {
+	left, err := aParser.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for aParser.accept("or", "||") {
+		right, err := aParser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &orNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (aParser *queryParser) parseAnd() (node, error) {
+	left, err := aParser.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for aParser.accept("and", "&&") {
+		right, err := aParser.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &andNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (aParser *queryParser) parseNot() (node, error) {
+	if aParser.accept("not", "!") {
+		operand, err := aParser.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return &notNode{operand: operand}, nil
+	}
+
+	return aParser.parsePrimary()
+}
+
+func (aParser *queryParser) parsePrimary() (node, error) {
+	aToken := aParser.take()
+
+	switch aToken.kind {
+	case tokenLeft:
+		result, err := aParser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := aParser.take(); closing.kind != tokenRight {
+			return nil, aParser.errorf(closing, ErrSyntax, "expected \")\"")
+		}
+
+		return result, nil
+	case tokenWord:
+		return aParser.parseComparison(aToken)
+	case tokenEnd, tokenOperator, tokenRight, tokenString:
+	}
+
+	return nil, aParser.errorf(aToken, ErrSyntax, "expected a field or \"(\"")
+}
+
+// Parse the rest of a comparison, or an existence test, after its field.
+func (aParser *queryParser) parseComparison(fieldToken token) (node, error) {
+	aField, isKnown := lookupField(fieldToken.text)
+	if !isKnown {
+		return nil, aParser.errorf(fieldToken, ErrUnknownField, "%q", fieldToken.text)
+	}
+
+	operatorToken := aParser.peek()
+	operator := operatorToken.text
+
+	switch {
+	case operatorToken.kind == tokenOperator && slices.Contains(comparisonSymbols, operator):
+	case operatorToken.kind == tokenWord && (strings.EqualFold(operator, operatorContains) || strings.EqualFold(operator, operatorMatches)):
+		operator = strings.ToLower(operator)
+	default:
+		return &existsNode{field: aField}, nil
+	}
+
+	aParser.take()
+
+	if operator == "=" {
+		operator = operatorEqual
+	}
+
+	valueToken := aParser.take()
+	if valueToken.kind != tokenWord && valueToken.kind != tokenString {
+		return nil, aParser.errorf(valueToken, ErrSyntax, "expected a value after %q", operatorToken.text)
+	}
+
+	negate := operator == operatorNotEqual
+	if negate {
+		operator = operatorEqual
+	}
+
+	predicate, err := newPredicate(aField, operator, valueToken.text)
+	if err != nil {
+		return nil, aParser.errorf(valueToken, err, "for %q", fieldToken.text)
+	}
+
+	return &comparisonNode{field: aField, negate: negate, predicate: predicate}, nil
+}
+
+// Take the next token if it is one of the keywords or symbols.  Keywords are case-insensitive.
+func (aParser *queryParser) accept(keyword string, symbol string) bool {
+	aToken := aParser.peek()
+
+	isMatch := (aToken.kind == tokenWord && strings.EqualFold(aToken.text, keyword)) ||
+		(aToken.kind == tokenOperator && aToken.text == symbol)
+	if isMatch {
+		aParser.take()
+	}
+
+	return isMatch
+}
+
+func (aParser *queryParser) errorf(aToken token, err error, format string, args ...interface{}) error {
+	return fmt.Errorf("query.Compile error: at %d: %s: %w", aToken.position+1, fmt.Sprintf(format, args...), err)
+}
+
+func (aParser *queryParser) peek() token {
+	return aParser.tokens[aParser.next]
+}
+
+func (aParser *queryParser) take() token {
+	result := aParser.tokens[aParser.next]
+	if result.kind != tokenEnd {
+		aParser.next++
+	}
+
+	return result
+}
+
+// Split the query into tokens, ending with tokenEnd.
+func (aParser *queryParser) tokenize() error {
+	text := aParser.text
+
+	for position := 0; position < len(text); {
+		character, size := utf8.DecodeRuneInString(text[position:])
+
+		switch {
+		case unicode.IsSpace(character):
+			position += size
+		case character == '"':
+			quoted, err := strconv.QuotedPrefix(text[position:])
+			if err != nil {
+				return aParser.errorf(token{position: position}, ErrSyntax, "unterminated string")
+			}
+
+			value, _ := strconv.Unquote(quoted)
+			aParser.tokens = append(aParser.tokens, token{kind: tokenString, position: position, text: value})
+			position += len(quoted)
+		case character == '\'':
+			end := strings.IndexByte(text[position+1:], '\'')
+			if end < 0 {
+				return aParser.errorf(token{position: position}, ErrSyntax, "unterminated string")
+			}
+
+			aParser.tokens = append(aParser.tokens, token{kind: tokenString, position: position, text: text[position+1 : position+1+end]})
+			position += end + 2 //nolint:mnd
+		case strings.ContainsRune(wordDelimiters, character):
+			symbol := ""
+
+			for _, candidate := range symbols {
+				if strings.HasPrefix(text[position:], candidate) {
+					symbol = candidate
+
+					break
+				}
+			}
+
+			if len(symbol) == 0 {
+				return aParser.errorf(token{position: position}, ErrSyntax, "unexpected %q", character)
+			}
+
+			aParser.tokens = append(aParser.tokens, token{kind: symbolKind(symbol), position: position, text: symbol})
+			position += len(symbol)
+		default:
+			end := strings.IndexFunc(text[position:], func(r rune) bool {
+				return unicode.IsSpace(r) || strings.ContainsRune(wordDelimiters, r)
+			})
+			if end < 0 {
+				end = len(text) - position
+			}
+
+			aParser.tokens = append(aParser.tokens, token{kind: tokenWord, position: position, text: text[position : position+end]})
+			position += end
+		}
+	}
+
+	aParser.tokens = append(aParser.tokens, token{kind: tokenEnd, position: len(text), text: "end of query"})
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func symbolKind(symbol string) tokenKind {
+	switch symbol {
+	case "(":
+		return tokenLeft
+	case ")":
+		return tokenRight
+	default:
+		return tokenOperator
+	}
+}
@@ -0,0 +1,121 @@
+package query_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/senzing-garage/go-messaging/go/typedef"
+	"github.com/senzing-garage/go-messaging/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMessage = &typedef.SenzingMessage{
+	Caller:   &typedef.Frame{File: "main.go", Function: "main", Line: 27, Package: "main"},
+	Code:     "E27",
+	Details:  typedef.Details{{Key: "DATA_SOURCE", Position: 1, Type: "string", Value: "TEST"}, {Position: 2, Type: "integer", Value: "5"}},
+	Duration: 1500000,
+	Errors:   typedef.Errors{"0027E Invalid data", "wrapped"},
+	ID:       "SZSDK99974001",
+	Level:    "ERROR",
+	Stack:    typedef.Stack{{File: "a.go", Function: "f", Line: 10, Package: "example.com/a"}},
+	Text:     "Bob failed",
+	Time:     time.Date(2000, 1, 1, 0, 0, 2, 0, time.UTC),
+}
+
+var testCasesForMatch = []struct {
+	name     string
+	query    string
+	expected bool
+}{
+	{name: "match-0001", query: `level >= WARN and details.key == "DATA_SOURCE" and errors contains "0027E"`, expected: true},
+	{name: "match-0002", query: `level >= fatal`, expected: false},
+	{name: "match-0003", query: `level < PANIC && level > INFO`, expected: true},
+	{name: "match-0004", query: `level == ERROR`, expected: true},
+	{name: "match-0005", query: `level contains RR`, expected: true},
+	{name: "match-0006", query: `id = SZSDK99974001`, expected: true},
+	{name: "match-0007", query: `id != SZSDK99974001 or code == 'E27'`, expected: true},
+	{name: "match-0008", query: `not (id == SZSDK99974001 and code == E27)`, expected: false},
+	{name: "match-0009", query: `!status`, expected: true},
+	{name: "match-0010", query: `code AND NOT reason`, expected: true},
+	{name: "match-0011", query: `errors matches "^0027E "`, expected: true},
+	{name: "match-0012", query: `errors != wrapped`, expected: false},
+	{name: "match-0013", query: `errors != other`, expected: true},
+	{name: "match-0014", query: `detail.DATA_SOURCE == TEST and detail.2 == 5`, expected: true},
+	{name: "match-0015", query: `detail.MISSING`, expected: false},
+	{name: "match-0016", query: `detail.MISSING != x`, expected: true},
+	{name: "match-0017", query: `details.position > 1 and details.type == integer`, expected: true},
+	{name: "match-0018", query: `duration >= 1.5ms and duration < 2000000`, expected: true},
+	{name: "match-0019", query: `time >= "2000-01-01T00:00:01Z" and time < "2000-01-01T00:00:03Z"`, expected: true},
+	{name: "match-0020", query: `time matches "^2000-01-01T00:00:02"`, expected: true},
+	{name: "match-0021", query: `caller.line == 27 and caller.function == main`, expected: true},
+	{name: "match-0022", query: `stack.package matches "^example\\.com/" and stack.line <= 10`, expected: true},
+	{name: "match-0023", query: `text == "Bob failed" || text contains "Jane"`, expected: true},
+	{name: "match-0024", query: `text > "Bob"`, expected: true},
+	{name: "match-0025", query: `remediation or help or location`, expected: false},
+	{name: "match-0026", query: `code matches "E2[0-9]" and duration matches "^15"`, expected: true},
+}
+
+var testCasesForCompile = []struct {
+	name          string
+	query         string
+	expectedError error
+}{
+	{name: "compile-0001", query: ``, expectedError: query.ErrSyntax},
+	{name: "compile-0002", query: `level >=`, expectedError: query.ErrSyntax},
+	{name: "compile-0003", query: `(level == WARN`, expectedError: query.ErrSyntax},
+	{name: "compile-0004", query: `level == WARN code`, expectedError: query.ErrSyntax},
+	{name: "compile-0005", query: `text == "unterminated`, expectedError: query.ErrSyntax},
+	{name: "compile-0006", query: `bogus == 1`, expectedError: query.ErrUnknownField},
+	{name: "compile-0007", query: `level >= LOUD`, expectedError: query.ErrValue},
+	{name: "compile-0008", query: `duration > soon`, expectedError: query.ErrValue},
+	{name: "compile-0009", query: `time > yesterday`, expectedError: query.ErrValue},
+	{name: "compile-0010", query: `text matches "("`, expectedError: query.ErrValue},
+	{name: "compile-0011", query: `caller.line contains 2`, expectedError: query.ErrValue},
+	{name: "compile-0012", query: `id == a | b`, expectedError: query.ErrSyntax},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestQuery_Match(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForMatch {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			aQuery, err := query.Compile(testCase.query)
+			require.NoError(test, err)
+			assert.Equal(test, testCase.expected, aQuery.Match(testMessage))
+			assert.Equal(test, testCase.query, aQuery.String())
+		})
+	}
+}
+
+func TestQuery_Match_nil(test *testing.T) {
+	test.Parallel()
+	assert.False(test, query.MustCompile("not code").Match(nil))
+	assert.True(test, query.MustCompile("not code").Match(&typedef.SenzingMessage{}))
+	assert.False(test, query.MustCompile("level >= TRACE").Match(&typedef.SenzingMessage{Level: "LOUD"}))
+	assert.True(test, query.MustCompile("level != TRACE").Match(&typedef.SenzingMessage{Level: "LOUD"}))
+}
+
+func TestCompile(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForCompile {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			_, err := query.Compile(testCase.query)
+			require.ErrorIs(test, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestMustCompile(test *testing.T) {
+	test.Parallel()
+	assert.Panics(test, func() { query.MustCompile("level >= LOUD") })
+}
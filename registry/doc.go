@@ -0,0 +1,14 @@
+/*
+Package registry records the message catalogs of the components of a system and
+detects message-ID collisions among them.
+
+Each component registers its name, its id template (the value given to
+messenger.OptionMessageIDTemplate, such as "SZSDK9998%04d"), its range of message
+numbers, and its catalog of messages and statuses.
+Register fails if any id the component can emit is already claimed by another component,
+so registering in init() detects collisions at startup.
+
+The combined registry can be written as JSON, and an emitted id can be resolved
+back to its component, message number, level, and template.
+*/
+package registry
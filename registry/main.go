@@ -0,0 +1,91 @@
+package registry
+
+import (
+	"errors"
+	"regexp"
+	"sync"
+)
+
+// ----------------------------------------------------------------------------
+// Types - struct
+// ----------------------------------------------------------------------------
+
+// A Component is the message catalog of one component.
+type Component struct {
+	Name       string         `json:"name"`                 // Unique name of the component.
+	IDTemplate string         `json:"idTemplate"`           // As in messenger.OptionMessageIDTemplate, e.g. "SZSDK9998%04d".
+	IDMin      int            `json:"idMin"`                // Smallest message number.
+	IDMax      int            `json:"idMax"`                // Largest message number.  If IDMin and IDMax are zero, the range of catalog keys.
+	IDMessages map[int]string `json:"idMessages,omitempty"` // Message number to text template, as in messenger.OptionIDMessages.
+	IDStatuses map[int]string `json:"idStatuses,omitempty"` // Message number to status, as in messenger.OptionIDStatuses.
+
+	// Optional text for reference documentation and the "remediation" and "help" fields.
+
+	Descriptions    map[int]string `json:"descriptions,omitempty"`    // Message number to a long description.
+	HelpURLTemplate string         `json:"helpUrlTemplate,omitempty"` // As in messenger.OptionHelpURLTemplate.
+	Remediations    map[int]string `json:"remediations,omitempty"`    // Message number to what the user should do.
+}
+
+// A Registry holds the components of a system.  It is safe for concurrent use.
+type Registry struct {
+	components map[string]Component // Component name to component.
+	mutex      sync.RWMutex
+}
+
+// A Resolution identifies where an emitted id came from.
+type Resolution struct {
+	Component     string `json:"component"`             // Name of the component.
+	Help          string `json:"help,omitempty"`        // Documentation URL from the component's help URL template.
+	ID            string `json:"id"`                    // The emitted id.
+	IDTemplate    string `json:"idTemplate"`            // The component's id template.
+	Level         string `json:"level"`                 // Level derived from messenger.IDLevelRangesAsString.
+	MessageNumber int    `json:"messageNumber"`         // The message number formatted into the id.
+	Remediation   string `json:"remediation,omitempty"` // Remediation text from the catalog.
+	Status        string `json:"status,omitempty"`      // Status from the catalog.
+	Template      string `json:"template,omitempty"`    // Text template from the catalog.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Largest number of message numbers a component may claim.
+const MaxRangeSize = 1000000
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var (
+	ErrCollision         = errors.New("message id collision")
+	ErrDuplicateName     = errors.New("component name already registered")
+	ErrEmptyName         = errors.New("component name is empty")
+	ErrInvalidIDTemplate = errors.New("id template must contain exactly one integer verb, such as %04d")
+	ErrInvalidRange      = errors.New("invalid message number range")
+	ErrUnknownID         = errors.New("message id not registered")
+)
+
+// An id template: literal text, with "%%" escapes, around one integer verb.
+var idTemplatePattern = regexp.MustCompile(`^((?:[^%]|%%)*)(%[-+ 0]*[0-9]*d)((?:[^%]|%%)*)$`)
+
+var defaultRegistry = New()
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Default function returns the registry shared by all components of a program.
+*/
+func Default() *Registry {
+	return defaultRegistry
+}
+
+/*
+The New function creates an empty Registry.
+*/
+func New() *Registry {
+	return &Registry{
+		components: map[string]Component{},
+	}
+}
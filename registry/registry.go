@@ -0,0 +1,326 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+)
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Components method returns the registered components, sorted by name.
+
+Output
+  - The registered components.
+*/
+func (registry *Registry) Components() []Component {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	result := make([]Component, 0, len(registry.components))
+	for _, component := range registry.components {
+		result = append(result, component)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result
+}
+
+/*
+The MarshalJSON method writes the combined registry as {"components": [...]}, sorted by component name.
+
+Output
+  - The JSON representation of the registry.
+*/
+func (registry *Registry) MarshalJSON() ([]byte, error) {
+	result, err := json.Marshal(struct {
+		Components []Component `json:"components"`
+	}{
+		Components: registry.Components(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("registry.MarshalJSON error: %w", err)
+	}
+
+	return result, nil
+}
+
+/*
+The Register method adds a component to the registry.
+Nothing is added if the component is invalid or any id it can emit is already registered.
+
+Input
+  - component: The component's name, id template, message number range, and catalog.
+
+Output
+  - An error wrapping ErrCollision, ErrDuplicateName, ErrEmptyName, ErrInvalidIDTemplate, or ErrInvalidRange.
+*/
+func (registry *Registry) Register(component Component) error {
+	if len(component.Name) == 0 {
+		return fmt.Errorf("registry.Register error: %w", ErrEmptyName)
+	}
+
+	if !idTemplatePattern.MatchString(component.IDTemplate) {
+		return fmt.Errorf("registry.Register error: component %q template %q: %w",
+			component.Name, component.IDTemplate, ErrInvalidIDTemplate)
+	}
+
+	component = withRange(component)
+
+	err := checkRange(component)
+	if err != nil {
+		return fmt.Errorf("registry.Register error: component %q: %w", component.Name, err)
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	if _, isRegistered := registry.components[component.Name]; isRegistered {
+		return fmt.Errorf("registry.Register error: component %q: %w", component.Name, ErrDuplicateName)
+	}
+
+	for _, name := range sortedNames(registry.components) {
+		previous := registry.components[name]
+
+		messageNumber, previousMessageNumber, isCollision := collision(component, previous)
+		if isCollision {
+			return fmt.Errorf("registry.Register error: id %q of component %q, message %d, is claimed by component %q, message %d: %w",
+				fmt.Sprintf(component.IDTemplate, messageNumber), component.Name, messageNumber,
+				previous.Name, previousMessageNumber, ErrCollision)
+		}
+	}
+
+	registry.components[component.Name] = component
+
+	return nil
+}
+
+/*
+The Resolve method identifies the component and catalog entry of an emitted id.
+
+Input
+  - id: The "id" field of a message, e.g. "SZSDK99982001".
+
+Output
+  - Where the id came from.
+  - An error wrapping ErrUnknownID if no component can emit the id.
+*/
+func (registry *Registry) Resolve(id string) (*Resolution, error) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	for _, name := range sortedNames(registry.components) {
+		component := registry.components[name]
+
+		messageNumber, isClaimed := component.claims(id)
+		if !isClaimed {
+			continue
+		}
+
+		result := &Resolution{
+			Component:     component.Name,
+			Help:          helpURL(component.HelpURLTemplate, id),
+			ID:            id,
+			IDTemplate:    component.IDTemplate,
+			Level:         messenger.MessageNumberLevel(messageNumber),
+			MessageNumber: messageNumber,
+			Remediation:   component.Remediations[messageNumber],
+			Status:        component.IDStatuses[messageNumber],
+			Template:      component.IDMessages[messageNumber],
+		}
+
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("registry.Resolve error: %q: %w", id, ErrUnknownID)
+}
+
+/*
+The Options method returns the options for messenger.New() that produce this component's messages.
+
+Output
+  - OptionMessageIDTemplate, OptionIDMessages, and, if set, OptionIDStatuses,
+    OptionIDRemediations, and OptionHelpURLTemplate.
+*/
+func (component Component) Options() []interface{} {
+	result := []interface{}{
+		messenger.OptionMessageIDTemplate{Value: component.IDTemplate},
+		messenger.OptionIDMessages{Value: component.IDMessages},
+	}
+
+	if component.IDStatuses != nil {
+		result = append(result, messenger.OptionIDStatuses{Value: component.IDStatuses})
+	}
+
+	if component.Remediations != nil {
+		result = append(result, messenger.OptionIDRemediations{Value: component.Remediations})
+	}
+
+	if len(component.HelpURLTemplate) > 0 {
+		result = append(result, messenger.OptionHelpURLTemplate{Value: component.HelpURLTemplate})
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// The message number of an id which the component can emit.
+// The id is parsed rather than formatting every message number in the range.
+func (component Component) claims(id string) (int, bool) {
+	prefix, verb, suffix := splitIDTemplate(component.IDTemplate)
+
+	formatted, hasPrefix := strings.CutPrefix(id, prefix)
+	formatted, hasSuffix := strings.CutSuffix(formatted, suffix)
+
+	if !hasPrefix || !hasSuffix {
+		return 0, false
+	}
+
+	result, err := strconv.Atoi(strings.TrimSpace(formatted))
+	if err != nil || result < component.IDMin || result > component.IDMax {
+		return 0, false
+	}
+
+	return result, fmt.Sprintf(verb, result) == formatted
+}
+
+// The maps from message number to text.
+func (component Component) catalogs() []map[int]string {
+	return []map[int]string{component.IDMessages, component.IDStatuses, component.Descriptions, component.Remediations}
+}
+
+// The message numbers in any catalog, in ascending order.
+func (component Component) messageNumbers() []int {
+	unique := map[int]bool{}
+
+	for _, catalog := range component.catalogs() {
+		for messageNumber := range catalog {
+			unique[messageNumber] = true
+		}
+	}
+
+	result := make([]int, 0, len(unique))
+	for messageNumber := range unique {
+		result = append(result, messageNumber)
+	}
+
+	sort.Ints(result)
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Verify that the range is ordered, not too large, and includes the catalog.
+func checkRange(component Component) error {
+	if component.IDMin == 0 && component.IDMax == 0 && len(component.messageNumbers()) == 0 {
+		return fmt.Errorf("no range and no catalog: %w", ErrInvalidRange)
+	}
+
+	if component.IDMax < component.IDMin || component.IDMax-component.IDMin >= MaxRangeSize {
+		return fmt.Errorf("%d to %d: %w", component.IDMin, component.IDMax, ErrInvalidRange)
+	}
+
+	for _, catalog := range component.catalogs() {
+		for messageNumber := range catalog {
+			if messageNumber < component.IDMin || messageNumber > component.IDMax {
+				return fmt.Errorf("message %d is outside %d to %d: %w",
+					messageNumber, component.IDMin, component.IDMax, ErrInvalidRange)
+			}
+		}
+	}
+
+	return nil
+}
+
+// The first message numbers of two components which give the same id.
+// Identical templates collide where the ranges overlap.  Templates whose literal text cannot line up never collide.
+// Otherwise, each id of the smaller range is parsed by the other component.
+func collision(component Component, other Component) (int, int, bool) {
+	if component.IDTemplate == other.IDTemplate {
+		first := max(component.IDMin, other.IDMin)
+
+		return first, first, first <= min(component.IDMax, other.IDMax)
+	}
+
+	prefix, _, suffix := splitIDTemplate(component.IDTemplate)
+	otherPrefix, _, otherSuffix := splitIDTemplate(other.IDTemplate)
+
+	if !strings.HasPrefix(prefix, otherPrefix) && !strings.HasPrefix(otherPrefix, prefix) {
+		return 0, 0, false
+	}
+
+	if !strings.HasSuffix(suffix, otherSuffix) && !strings.HasSuffix(otherSuffix, suffix) {
+		return 0, 0, false
+	}
+
+	if component.IDMax-component.IDMin <= other.IDMax-other.IDMin {
+		for messageNumber := component.IDMin; messageNumber <= component.IDMax; messageNumber++ {
+			if otherMessageNumber, isClaimed := other.claims(fmt.Sprintf(component.IDTemplate, messageNumber)); isClaimed {
+				return messageNumber, otherMessageNumber, true
+			}
+		}
+
+		return 0, 0, false
+	}
+
+	otherMessageNumber, messageNumber, isCollision := collision(other, component)
+
+	return messageNumber, otherMessageNumber, isCollision
+}
+
+// Format the help URL of an id, or "" if there is no template.
+func helpURL(helpURLTemplate string, id string) string {
+	if len(helpURLTemplate) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(helpURLTemplate, id)
+}
+
+// The names of components, sorted.
+func sortedNames(components map[string]Component) []string {
+	result := make([]string, 0, len(components))
+	for name := range components {
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// Split a valid id template into the literal text before the verb, the verb, and the literal text after it.
+func splitIDTemplate(idTemplate string) (string, string, string) {
+	matches := idTemplatePattern.FindStringSubmatch(idTemplate)
+
+	return strings.ReplaceAll(matches[1], "%%", "%"), matches[2], strings.ReplaceAll(matches[3], "%%", "%")
+}
+
+// If the range is unset, use the range of the catalog keys.
+func withRange(component Component) Component {
+	if component.IDMin != 0 || component.IDMax != 0 {
+		return component
+	}
+
+	messageNumbers := component.messageNumbers()
+	if len(messageNumbers) > 0 {
+		component.IDMin = messageNumbers[0]
+		component.IDMax = messageNumbers[len(messageNumbers)-1]
+	}
+
+	return component
+}
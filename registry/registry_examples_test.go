@@ -0,0 +1,50 @@
+package registry_test
+
+import (
+	"fmt"
+
+	"github.com/senzing-garage/go-messaging/registry"
+)
+
+// ----------------------------------------------------------------------------
+// Examples for godoc documentation
+// ----------------------------------------------------------------------------
+
+func ExampleRegistry_Register() {
+	// For more information, visit https://github.com/senzing-garage/go-messaging/blob/main/registry/registry_examples_test.go
+	aRegistry := registry.New()
+
+	err := aRegistry.Register(registry.Component{Name: "first", IDTemplate: "SZSDK9998%04d", IDMax: 9999})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	err = aRegistry.Register(registry.Component{Name: "second", IDTemplate: "SZSDK9998%04d", IDMin: 2000, IDMax: 2999})
+	if err != nil {
+		fmt.Println(err)
+	}
+	//Output: registry.Register error: id "SZSDK99982000" of component "second", message 2000, is claimed by component "first", message 2000: message id collision
+}
+
+func ExampleRegistry_Resolve() {
+	// For more information, visit https://github.com/senzing-garage/go-messaging/blob/main/registry/registry_examples_test.go
+	aRegistry := registry.New()
+
+	err := aRegistry.Register(registry.Component{
+		Name:       "example",
+		IDTemplate: "SZSDK9998%04d",
+		IDMax:      9999,
+		IDMessages: map[int]string{2001: "%s works with %s"},
+	})
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	resolution, err := aRegistry.Resolve("SZSDK99982001")
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Println(resolution.Component, resolution.MessageNumber, resolution.Level, resolution.Template)
+	//Output: example 2001 INFO %s works with %s
+}
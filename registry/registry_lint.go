@@ -0,0 +1,333 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// A Finding is a problem in a catalog reported by Lint.
+type Finding struct {
+	Component     string `json:"component"`               // Name of the component.
+	ID            string `json:"id,omitempty"`            // The id of the message, if the finding is about one message.
+	Message       string `json:"message"`                 // Description of the problem.
+	MessageNumber *int   `json:"messageNumber,omitempty"` // The message number, if the finding is about one message.
+	Rule          string `json:"rule"`                    // One of the Rule... constants.
+	Severity      string `json:"severity"`                // SeverityError or SeverityWarning.
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Rules checked by Lint.
+const (
+	RuleCollision     = "collision"      // An id is claimed by more than one component.
+	RuleDuplicateText = "duplicate-text" // Two messages have the same text, ignoring level prefixes, verbs, case, and spacing.
+	RuleIDRange       = "id-range"       // A message number is outside IDMin to IDMax.
+	RuleIDTemplate    = "id-template"    // The id template is invalid, or an id does not fit its width.
+	RuleLevelPrefix   = "level-prefix"   // A text begins with a level, e.g. "ERROR:", other than the derived level.
+	RuleLevelRange    = "level-range"    // A message number is outside the ranges of messenger.IDLevelRangesAsString.
+	RuleMissingStatus = "missing-status" // A message has no status, while others at its level do.
+	RulePrintfVerb    = "printf-verb"    // A text has a malformed fmt verb.
+)
+
+// Severities of findings.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+// A fmt verb: flags, width, precision, argument index, and verb character.
+var printfVerbPattern = regexp.MustCompile(`%[-+# 0]*(?:\[\d+\])?(?:\d+|\*)?(?:\.(?:\d+|\*)?)?(?:\[\d+\])?[vTtbcdoOqxXUeEfFgGsp%]`)
+
+// A leading level name, e.g. "ERROR:" or "warn -".
+var levelPrefixPattern = regexp.MustCompile(`^\s*([A-Za-z]+)\s*[:\-]`)
+
+// The width of a zero-padded integer verb, e.g. 4 in "%04d".
+var idWidthPattern = regexp.MustCompile(`%0(\d+)d`)
+
+// ----------------------------------------------------------------------------
+// Public functions
+// ----------------------------------------------------------------------------
+
+/*
+The Lint function checks the catalogs of components for problems.
+Findings are in component and message number order.
+
+Input
+  - components: The components to check, together, so that collisions among them are found.
+
+Output
+  - The problems found.
+*/
+func Lint(components ...Component) []Finding {
+	result := []Finding{}
+	aRegistry := New()
+
+	for _, component := range components {
+		result = append(result, lintComponent(component)...)
+
+		err := aRegistry.Register(component)
+		if errors.Is(err, ErrCollision) {
+			result = append(result, Finding{
+				Component: component.Name,
+				Message:   strings.TrimPrefix(err.Error(), "registry.Register error: "),
+				Rule:      RuleCollision,
+				Severity:  SeverityError,
+			})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Component != result[j].Component {
+			return result[i].Component < result[j].Component
+		}
+
+		return messageNumberOf(result[i]) < messageNumberOf(result[j])
+	})
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The Lint method checks the catalogs of all registered components.
+Collisions cannot occur among registered components, so they are not reported.
+*/
+func (registry *Registry) Lint() []Finding {
+	return Lint(registry.Components()...)
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+func lintComponent(component Component) []Finding {
+	var result []Finding
+
+	if !idTemplatePattern.MatchString(component.IDTemplate) {
+		result = append(result, Finding{
+			Component: component.Name,
+			Message:   fmt.Sprintf("id template %q must contain exactly one integer verb, such as %%04d", component.IDTemplate),
+			Rule:      RuleIDTemplate,
+			Severity:  SeverityError,
+		})
+	}
+
+	isRangeSet := component.IDMin != 0 || component.IDMax != 0
+	levelRangeMax := levelRangeMaximum()
+
+	levelsWithStatuses := map[string]bool{}
+	for messageNumber := range component.IDStatuses {
+		levelsWithStatuses[messenger.MessageNumberLevel(messageNumber)] = true
+	}
+
+	for _, messageNumber := range component.messageNumbers() {
+		finding := func(rule string, severity string, format string, args ...interface{}) Finding {
+			number := messageNumber
+
+			return Finding{
+				Component:     component.Name,
+				ID:            formatID(component.IDTemplate, messageNumber),
+				Message:       fmt.Sprintf(format, args...),
+				MessageNumber: &number,
+				Rule:          rule,
+				Severity:      severity,
+			}
+		}
+
+		if messageNumber < 0 || messageNumber >= levelRangeMax {
+			result = append(result, finding(RuleLevelRange, SeverityError,
+				"message number %d is outside the level ranges, 0 to %d", messageNumber, levelRangeMax-1))
+		}
+
+		if isRangeSet && (messageNumber < component.IDMin || messageNumber > component.IDMax) {
+			result = append(result, finding(RuleIDRange, SeverityError,
+				"message number %d is outside %d to %d", messageNumber, component.IDMin, component.IDMax))
+		}
+
+		if width := idWidth(component.IDTemplate); width > 0 && len(strconv.Itoa(messageNumber)) > width {
+			result = append(result, finding(RuleIDTemplate, SeverityError,
+				"message number %d is wider than the %d digits of id template %q", messageNumber, width, component.IDTemplate))
+		}
+
+		text, hasText := component.IDMessages[messageNumber]
+		if !hasText {
+			continue
+		}
+
+		if malformed := malformedVerb(text); len(malformed) > 0 {
+			result = append(result, finding(RulePrintfVerb, SeverityError, "malformed verb %q in %q", malformed, text))
+		}
+
+		derivedLevel := messenger.MessageNumberLevel(messageNumber)
+		if prefixLevel := textLevel(text); len(prefixLevel) > 0 && prefixLevel != derivedLevel {
+			result = append(result, finding(RuleLevelPrefix, SeverityWarning,
+				"text begins with %s, but message number %d is %s", prefixLevel, messageNumber, derivedLevel))
+		}
+
+		if _, hasStatus := component.IDStatuses[messageNumber]; !hasStatus && levelsWithStatuses[derivedLevel] {
+			result = append(result, finding(RuleMissingStatus, SeverityWarning,
+				"message has no status, while other %s messages do", derivedLevel))
+		}
+	}
+
+	return append(result, lintDuplicateTexts(component)...)
+}
+
+// Find messages whose texts are the same after normalization.
+func lintDuplicateTexts(component Component) []Finding {
+	var result []Finding
+
+	firstByText := map[string]int{}
+
+	for _, messageNumber := range component.messageNumbers() {
+		text, hasText := component.IDMessages[messageNumber]
+		if !hasText {
+			continue
+		}
+
+		normalized := normalizeText(text)
+		if len(normalized) == 0 {
+			continue
+		}
+
+		first, isDuplicate := firstByText[normalized]
+		if !isDuplicate {
+			firstByText[normalized] = messageNumber
+
+			continue
+		}
+
+		kind := "near-duplicate"
+		if component.IDMessages[first] == text {
+			kind = "duplicate"
+		}
+
+		number := messageNumber
+		result = append(result, Finding{
+			Component:     component.Name,
+			ID:            formatID(component.IDTemplate, messageNumber),
+			Message:       fmt.Sprintf("text is a %s of message %d: %q", kind, first, text),
+			MessageNumber: &number,
+			Rule:          RuleDuplicateText,
+			Severity:      SeverityWarning,
+		})
+	}
+
+	return result
+}
+
+// Format an id, or "" if the template is invalid.
+func formatID(idTemplate string, messageNumber int) string {
+	if !idTemplatePattern.MatchString(idTemplate) {
+		return ""
+	}
+
+	return fmt.Sprintf(idTemplate, messageNumber)
+}
+
+// The digits of a zero-padded id template, or 0.
+func idWidth(idTemplate string) int {
+	matches := idWidthPattern.FindStringSubmatch(idTemplate)
+	if matches == nil {
+		return 0
+	}
+
+	result, _ := strconv.Atoi(matches[1])
+
+	return result
+}
+
+// The first message number past the highest level range, assuming equally sized ranges.
+func levelRangeMaximum() int {
+	lowBounds := make([]int, 0, len(messenger.IDLevelRangesAsString))
+	for lowBound := range messenger.IDLevelRangesAsString {
+		lowBounds = append(lowBounds, lowBound)
+	}
+
+	sort.Ints(lowBounds)
+
+	if len(lowBounds) < 2 { //nolint:mnd
+		return math.MaxInt
+	}
+
+	last := lowBounds[len(lowBounds)-1]
+
+	return last + (last - lowBounds[len(lowBounds)-2])
+}
+
+// The first malformed fmt verb in a text, or "".
+func malformedVerb(text string) string {
+	for index := 0; index < len(text); index++ {
+		if text[index] != '%' {
+			continue
+		}
+
+		location := printfVerbPattern.FindStringIndex(text[index:])
+		if location == nil || location[0] != 0 {
+			end := min(index+3, len(text)) //nolint:mnd
+
+			return text[index:end]
+		}
+
+		index += location[1] - 1
+	}
+
+	return ""
+}
+
+// The message number of a finding, with findings about the whole component first.
+func messageNumberOf(finding Finding) int {
+	if finding.MessageNumber == nil {
+		return math.MinInt
+	}
+
+	return *finding.MessageNumber
+}
+
+// Remove a level prefix, verbs, case, punctuation, and extra spacing.
+func normalizeText(text string) string {
+	if len(textLevel(text)) > 0 {
+		text = text[len(levelPrefixPattern.FindString(text)):]
+	}
+
+	text = printfVerbPattern.ReplaceAllString(text, "")
+
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}), " ")
+}
+
+// The level named at the start of a text, e.g. "ERROR" for "ERROR: failed", or "".
+func textLevel(text string) string {
+	matches := levelPrefixPattern.FindStringSubmatch(text)
+	if matches == nil {
+		return ""
+	}
+
+	candidate := strings.ToUpper(matches[1])
+	if _, isLevel := messenger.TextToLevelMap[candidate]; isLevel {
+		return candidate
+	}
+
+	return ""
+}
@@ -0,0 +1,150 @@
+package registry_test
+
+import (
+	"testing"
+
+	"github.com/senzing-garage/go-messaging/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+var testCasesForLint = []struct {
+	name          string
+	components    []registry.Component
+	expectedRules []string
+}{
+	{
+		name:       "lint-0001",
+		components: []registry.Component{componentA},
+	},
+	{
+		name: "lint-0002",
+		components: []registry.Component{{
+			Name:       "level-range",
+			IDTemplate: "SZSDK9996%04d",
+			IDMessages: map[int]string{2001: "Loaded", 7001: "Beyond PANIC"},
+		}},
+		expectedRules: []string{registry.RuleLevelRange},
+	},
+	{
+		name: "lint-0003",
+		components: []registry.Component{{
+			Name:       "printf-verb",
+			IDTemplate: "SZSDK9996%04d",
+			IDMessages: map[int]string{2001: "Loaded %d of %-8.2f %[1]v%% records", 2002: "Loaded %y records", 2003: "Loaded 100%"},
+		}},
+		expectedRules: []string{registry.RulePrintfVerb, registry.RulePrintfVerb},
+	},
+	{
+		name: "lint-0004",
+		components: []registry.Component{{
+			Name:       "level-prefix",
+			IDTemplate: "SZSDK9996%04d",
+			IDMessages: map[int]string{2001: "INFO: Loaded", 2002: "ERROR: Cannot load", 2003: "Note: something"},
+		}},
+		expectedRules: []string{registry.RuleLevelPrefix},
+	},
+	{
+		name: "lint-0005",
+		components: []registry.Component{{
+			Name:       "duplicate-text",
+			IDTemplate: "SZSDK9996%04d",
+			IDMessages: map[int]string{2001: "Loaded %d records", 2002: "Loaded %d records", 3001: "WARN: loaded  %s records."},
+		}},
+		expectedRules: []string{registry.RuleDuplicateText, registry.RuleDuplicateText},
+	},
+	{
+		name: "lint-0006",
+		components: []registry.Component{{
+			Name:       "missing-status",
+			IDTemplate: "SZSDK9996%04d",
+			IDMessages: map[int]string{4001: "Cannot load", 4002: "Cannot save"},
+			IDStatuses: map[int]string{4001: "FAILED"},
+		}},
+		expectedRules: []string{registry.RuleMissingStatus},
+	},
+	{
+		name: "lint-0007",
+		components: []registry.Component{{
+			Name:       "id-template",
+			IDTemplate: "SZSDK9996%02d",
+			IDMessages: map[int]string{1: "Trace", 2001: "Loaded"},
+		}},
+		expectedRules: []string{registry.RuleIDTemplate},
+	},
+	{
+		name: "lint-0008",
+		components: []registry.Component{{
+			Name:       "bad-template",
+			IDTemplate: "SZSDK9996",
+			IDMessages: map[int]string{2001: "Loaded"},
+		}},
+		expectedRules: []string{registry.RuleIDTemplate},
+	},
+	{
+		name: "lint-0009",
+		components: []registry.Component{{
+			Name:       "id-range",
+			IDTemplate: "SZSDK9996%04d",
+			IDMin:      2000,
+			IDMax:      2999,
+			IDMessages: map[int]string{2001: "Loaded", 4001: "Failed"},
+		}},
+		expectedRules: []string{registry.RuleIDRange},
+	},
+	{
+		name: "lint-0010",
+		components: []registry.Component{
+			componentA,
+			{Name: "collision", IDTemplate: "SZSDK9998%04d", IDMessages: map[int]string{2001: "Other"}},
+		},
+		expectedRules: []string{registry.RuleCollision},
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestLint(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForLint {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			actualRules := []string{}
+			for _, finding := range registry.Lint(testCase.components...) {
+				actualRules = append(actualRules, finding.Rule)
+			}
+
+			if testCase.expectedRules == nil {
+				testCase.expectedRules = []string{}
+			}
+
+			assert.Equal(test, testCase.expectedRules, actualRules)
+		})
+	}
+}
+
+func TestRegistry_Lint(test *testing.T) {
+	test.Parallel()
+
+	aRegistry := registry.New()
+	assert.NoError(test, aRegistry.Register(registry.Component{
+		Name:       "example",
+		IDTemplate: "SZSDK9996%04d",
+		IDMessages: map[int]string{4001: "WARN: Cannot load"},
+	}))
+
+	findings := aRegistry.Lint()
+	assert.Len(test, findings, 1)
+	assert.Equal(test, registry.Finding{
+		Component:     "example",
+		ID:            "SZSDK99964001",
+		Message:       "text begins with WARN, but message number 4001 is ERROR",
+		MessageNumber: findings[0].MessageNumber,
+		Rule:          registry.RuleLevelPrefix,
+		Severity:      registry.SeverityWarning,
+	}, findings[0])
+	assert.Equal(test, 4001, *findings[0].MessageNumber)
+}
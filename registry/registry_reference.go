@@ -0,0 +1,229 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// The content of a reference page.
+type reference struct {
+	Components []referenceComponent
+	Title      string
+}
+
+type referenceComponent struct {
+	Entries    []referenceEntry
+	IDTemplate string
+	Name       string
+}
+
+// One message.  ID is also the anchor of the entry.
+type referenceEntry struct {
+	Description string
+	ID          string
+	Level       string
+	Remediation string
+	Status      string
+	Text        string
+}
+
+// ----------------------------------------------------------------------------
+// Variables
+// ----------------------------------------------------------------------------
+
+var markdownTemplate = texttemplate.Must(texttemplate.New("markdown").Funcs(texttemplate.FuncMap{
+	"code": markdownCode,
+}).Parse(`# {{.Title}}
+{{range .Components}}
+## {{.Name}}
+
+Message ids: {{code .IDTemplate}}
+{{range .Entries}}
+<a id="{{.ID}}"></a>
+
+### {{.ID}}
+
+- Level: {{.Level}}
+{{- if .Status}}
+- Status: {{.Status}}
+{{- end}}
+{{- if .Text}}
+- Text: {{code .Text}}
+{{- end}}
+{{- if .Description}}
+
+{{.Description}}
+{{- end}}
+{{- if .Remediation}}
+
+Remediation: {{.Remediation}}
+{{- end}}
+{{end}}{{end}}`))
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("html").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- range .Components}}
+<section id="{{.Name}}">
+<h2>{{.Name}}</h2>
+<p>Message ids: <code>{{.IDTemplate}}</code></p>
+{{- range .Entries}}
+<article id="{{.ID}}">
+<h3><a href="#{{.ID}}">{{.ID}}</a></h3>
+<dl>
+<dt>Level</dt><dd>{{.Level}}</dd>
+{{- if .Status}}
+<dt>Status</dt><dd>{{.Status}}</dd>
+{{- end}}
+{{- if .Text}}
+<dt>Text</dt><dd><code>{{.Text}}</code></dd>
+{{- end}}
+</dl>
+{{- if .Description}}
+<p>{{.Description}}</p>
+{{- end}}
+{{- if .Remediation}}
+<p><strong>Remediation:</strong> {{.Remediation}}</p>
+{{- end}}
+</article>
+{{- end}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+// ----------------------------------------------------------------------------
+// Interface methods
+// ----------------------------------------------------------------------------
+
+/*
+The UnmarshalJSON method registers the components of a registry written by MarshalJSON.
+
+Input
+  - data: JSON of the form {"components": [...]}.
+
+Output
+  - An error if the JSON is invalid or a component cannot be registered.
+*/
+func (registry *Registry) UnmarshalJSON(data []byte) error {
+	var dump struct {
+		Components []Component `json:"components"`
+	}
+
+	err := json.Unmarshal(data, &dump)
+	if err != nil {
+		return fmt.Errorf("registry.UnmarshalJSON error: %w", err)
+	}
+
+	if registry.components == nil {
+		registry.components = map[string]Component{}
+	}
+
+	for _, component := range dump.Components {
+		err = registry.Register(component)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+The WriteHTML method writes an HTML reference page of every registered message.
+Each message is an element whose id is the message id, so "page.html#SZSDK99984001" links to it.
+
+Input
+  - writer: Where to write the page.
+  - title: The title of the page.
+*/
+func (registry *Registry) WriteHTML(writer io.Writer, title string) error {
+	err := htmlTemplate.Execute(writer, registry.reference(title))
+	if err != nil {
+		return fmt.Errorf("registry.WriteHTML error: %w", err)
+	}
+
+	return nil
+}
+
+/*
+The WriteMarkdown method writes a Markdown reference page of every registered message.
+Each message has an anchor named by the message id, so "page.md#SZSDK99984001" links to it.
+
+Input
+  - writer: Where to write the page.
+  - title: The title of the page.
+*/
+func (registry *Registry) WriteMarkdown(writer io.Writer, title string) error {
+	err := markdownTemplate.Execute(writer, registry.reference(title))
+	if err != nil {
+		return fmt.Errorf("registry.WriteMarkdown error: %w", err)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Private methods
+// ----------------------------------------------------------------------------
+
+// Collect the content of a reference page, in component name and message number order.
+func (registry *Registry) reference(title string) reference {
+	result := reference{Title: title}
+
+	for _, component := range registry.Components() {
+		pageComponent := referenceComponent{
+			IDTemplate: component.IDTemplate,
+			Name:       component.Name,
+		}
+
+		for _, messageNumber := range component.messageNumbers() {
+			pageComponent.Entries = append(pageComponent.Entries, referenceEntry{
+				Description: component.Descriptions[messageNumber],
+				ID:          fmt.Sprintf(component.IDTemplate, messageNumber),
+				Level:       messenger.MessageNumberLevel(messageNumber),
+				Remediation: component.Remediations[messageNumber],
+				Status:      component.IDStatuses[messageNumber],
+				Text:        component.IDMessages[messageNumber],
+			})
+		}
+
+		result.Components = append(result.Components, pageComponent)
+	}
+
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Private functions
+// ----------------------------------------------------------------------------
+
+// Format text as a Markdown code span, using a fence longer than any backtick run in the text.
+func markdownCode(text string) string {
+	fence := "`"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		return fence + " " + text + " " + fence
+	}
+
+	return fence + text + fence
+}
@@ -0,0 +1,83 @@
+package registry_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/go-messaging/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var componentReference = registry.Component{
+	Name:         "component-reference",
+	IDTemplate:   "SZSDK9995%04d",
+	IDMax:        9999,
+	IDMessages:   map[int]string{2001: "Loaded %d records", 4001: "Cannot open `%s` <file>"},
+	IDStatuses:   map[int]string{4001: "FAILED"},
+	Descriptions: map[int]string{4001: "The input file could not be opened."},
+	Remediations: map[int]string{4001: "Verify the file exists & is readable."},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRegistry_WriteMarkdown(test *testing.T) {
+	test.Parallel()
+
+	aRegistry := registry.New()
+	require.NoError(test, aRegistry.Register(componentReference))
+
+	var buffer bytes.Buffer
+	require.NoError(test, aRegistry.WriteMarkdown(&buffer, "Error reference"))
+
+	expected := "# Error reference\n" +
+		"\n## component-reference\n\nMessage ids: `SZSDK9995%04d`\n" +
+		"\n<a id=\"SZSDK99952001\"></a>\n\n### SZSDK99952001\n\n" +
+		"- Level: INFO\n- Text: `Loaded %d records`\n" +
+		"\n<a id=\"SZSDK99954001\"></a>\n\n### SZSDK99954001\n\n" +
+		"- Level: ERROR\n- Status: FAILED\n- Text: ``Cannot open `%s` <file>``\n" +
+		"\nThe input file could not be opened.\n" +
+		"\nRemediation: Verify the file exists & is readable.\n"
+	assert.Equal(test, expected, buffer.String())
+}
+
+func TestRegistry_WriteHTML(test *testing.T) {
+	test.Parallel()
+
+	aRegistry := registry.New()
+	require.NoError(test, aRegistry.Register(componentReference))
+
+	var buffer bytes.Buffer
+	require.NoError(test, aRegistry.WriteHTML(&buffer, "Error reference"))
+
+	actual := buffer.String()
+	assert.Contains(test, actual, `<title>Error reference</title>`)
+	assert.Contains(test, actual, `<article id="SZSDK99954001">`)
+	assert.Contains(test, actual, `<h3><a href="#SZSDK99954001">SZSDK99954001</a></h3>`)
+	assert.Contains(test, actual, "<code>Cannot open `%s` &lt;file&gt;</code>")
+	assert.Contains(test, actual, `<p><strong>Remediation:</strong> Verify the file exists &amp; is readable.</p>`)
+}
+
+func TestRegistry_UnmarshalJSON(test *testing.T) {
+	test.Parallel()
+
+	original := registry.New()
+	require.NoError(test, original.Register(componentA))
+	require.NoError(test, original.Register(componentReference))
+
+	originalJSON, err := json.Marshal(original)
+	require.NoError(test, err)
+
+	actual := registry.New()
+	require.NoError(test, json.Unmarshal(originalJSON, actual))
+	assert.Equal(test, original.Components(), actual.Components())
+
+	resolution, err := actual.Resolve("SZSDK99954001")
+	require.NoError(test, err)
+	assert.Equal(test, "component-reference", resolution.Component)
+
+	require.ErrorIs(test, json.Unmarshal(originalJSON, actual), registry.ErrDuplicateName)
+}
@@ -0,0 +1,178 @@
+package registry_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/senzing-garage/go-messaging/messenger"
+	"github.com/senzing-garage/go-messaging/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var componentA = registry.Component{
+	Name:       "component-a",
+	IDTemplate: "SZSDK9998%04d",
+	IDMin:      0,
+	IDMax:      9999,
+	IDMessages: map[int]string{2001: "%s works with %s", 4001: "%s failed"},
+	IDStatuses: map[int]string{4001: "FAILED"},
+
+	HelpURLTemplate: "https://example.com/errors.html#%s",
+	Remediations:    map[int]string{4001: "Retry."},
+}
+
+var testCasesForRegister = []struct {
+	name          string
+	component     registry.Component
+	expectedError error
+}{
+	{
+		name:      "register-0001",
+		component: registry.Component{Name: "other-prefix", IDTemplate: "SZSDK9997%04d", IDMax: 9999},
+	},
+	{
+		name:          "register-0002",
+		component:     registry.Component{Name: "same-prefix", IDTemplate: "SZSDK9998%04d", IDMin: 9000, IDMax: 9999},
+		expectedError: registry.ErrCollision,
+	},
+	{
+		name:          "register-0003",
+		component:     registry.Component{Name: "shorter-prefix", IDTemplate: "SZSDK999%05d", IDMin: 80000, IDMax: 80001},
+		expectedError: registry.ErrCollision,
+	},
+	{
+		name:          "register-0004",
+		component:     registry.Component{Name: "component-a", IDTemplate: "SZSDK9996%04d", IDMax: 9999},
+		expectedError: registry.ErrDuplicateName,
+	},
+	{
+		name:          "register-0005",
+		component:     registry.Component{IDTemplate: "SZSDK9996%04d", IDMax: 9999},
+		expectedError: registry.ErrEmptyName,
+	},
+	{
+		name:          "register-0006",
+		component:     registry.Component{Name: "no-verb", IDTemplate: "SZSDK9996", IDMax: 9999},
+		expectedError: registry.ErrInvalidIDTemplate,
+	},
+	{
+		name:          "register-0007",
+		component:     registry.Component{Name: "two-verbs", IDTemplate: "SZSDK%04d%04d", IDMax: 9999},
+		expectedError: registry.ErrInvalidIDTemplate,
+	},
+	{
+		name: "register-0008",
+		component: registry.Component{
+			Name:       "outside-range",
+			IDTemplate: "SZSDK9996%04d",
+			IDMax:      999,
+			IDMessages: map[int]string{1001: "text"},
+		},
+		expectedError: registry.ErrInvalidRange,
+	},
+	{
+		name:          "register-0009",
+		component:     registry.Component{Name: "empty", IDTemplate: "SZSDK9996%04d"},
+		expectedError: registry.ErrInvalidRange,
+	},
+	{
+		name:      "register-0010",
+		component: registry.Component{Name: "catalog-range", IDTemplate: "SZSDK9998%04d", IDMessages: map[int]string{10000: "text"}},
+	},
+	{
+		name:      "register-0011",
+		component: registry.Component{Name: "wider", IDTemplate: "SZSDK9998%05d", IDMax: registry.MaxRangeSize - 1},
+	},
+	{
+		name:          "register-0012",
+		component:     registry.Component{Name: "unpadded", IDTemplate: "SZSDK99%d", IDMin: 900000, IDMax: 989999},
+		expectedError: registry.ErrCollision,
+	},
+	{
+		name:      "register-0013",
+		component: registry.Component{Name: "escaped", IDTemplate: "SZSDK%%9998%04d", IDMax: 9999},
+	},
+}
+
+// ----------------------------------------------------------------------------
+// Test interface functions
+// ----------------------------------------------------------------------------
+
+func TestRegistry_Register(test *testing.T) {
+	test.Parallel()
+
+	for _, testCase := range testCasesForRegister {
+		test.Run(testCase.name, func(test *testing.T) {
+			test.Parallel()
+
+			aRegistry := registry.New()
+			require.NoError(test, aRegistry.Register(componentA))
+
+			err := aRegistry.Register(testCase.component)
+			if testCase.expectedError != nil {
+				require.ErrorIs(test, err, testCase.expectedError)
+				assert.Len(test, aRegistry.Components(), 1)
+
+				return
+			}
+
+			require.NoError(test, err)
+			assert.Len(test, aRegistry.Components(), 2)
+		})
+	}
+}
+
+func TestRegistry_Resolve(test *testing.T) {
+	test.Parallel()
+
+	aRegistry := registry.New()
+	require.NoError(test, aRegistry.Register(componentA))
+
+	options := append(componentA.Options(), messenger.OptionMessageFields{Value: []string{"id", "remediation", "help"}})
+	aMessenger, err := messenger.New(options...)
+	require.NoError(test, err)
+
+	messageFormat := &messenger.MessageFormat{}
+	require.NoError(test, json.Unmarshal([]byte(aMessenger.NewJSON(4001, "Bob")), messageFormat))
+	assert.Equal(test, "Retry.", messageFormat.Remediation)
+	assert.Equal(test, "https://example.com/errors.html#SZSDK99984001", messageFormat.Help)
+
+	resolution, err := aRegistry.Resolve(messageFormat.ID)
+	require.NoError(test, err)
+	assert.Equal(test, &registry.Resolution{
+		Component:     "component-a",
+		Help:          "https://example.com/errors.html#SZSDK99984001",
+		ID:            "SZSDK99984001",
+		IDTemplate:    "SZSDK9998%04d",
+		Level:         messenger.LevelErrorName,
+		MessageNumber: 4001,
+		Remediation:   "Retry.",
+		Status:        "FAILED",
+		Template:      "%s failed",
+	}, resolution)
+
+	require.NoError(test, aRegistry.Register(registry.Component{Name: "unpadded", IDTemplate: "SZSDK99%d", IDMin: 970000, IDMax: 979999}))
+
+	resolution, err = aRegistry.Resolve("SZSDK99975001")
+	require.NoError(test, err)
+	assert.Equal(test, "unpadded", resolution.Component)
+	assert.Equal(test, 975001, resolution.MessageNumber)
+
+	_, err = aRegistry.Resolve("SZSDK99994001")
+	require.ErrorIs(test, err, registry.ErrUnknownID)
+}
+
+func TestRegistry_MarshalJSON(test *testing.T) {
+	test.Parallel()
+
+	aRegistry := registry.New()
+	require.NoError(test, aRegistry.Register(registry.Component{Name: "b", IDTemplate: "B%d", IDMessages: map[int]string{1: "one"}}))
+	require.NoError(test, aRegistry.Register(registry.Component{Name: "a", IDTemplate: "A%d", IDMin: 1, IDMax: 2}))
+
+	actual, err := json.Marshal(aRegistry)
+	require.NoError(test, err)
+	assert.JSONEq(test, `{"components":[`+
+		`{"name":"a","idTemplate":"A%d","idMin":1,"idMax":2},`+
+		`{"name":"b","idTemplate":"B%d","idMin":1,"idMax":1,"idMessages":{"1":"one"}}]}`, string(actual))
+}
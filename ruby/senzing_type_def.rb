@@ -40,6 +40,18 @@ module SenzingTypeDef
     # Time message was generated in RFC3339 format.
     attr_accessor :time
 
+    # Location in the code, as package, function, file, and line.
+    attr_accessor :caller
+
+    # URL of documentation for the message.
+    attr_accessor :help
+
+    # What to do about the message.
+    attr_accessor :remediation
+
+    # Stack trace where the message or its error was created.
+    attr_accessor :stack
+
     def self.from_json_data(data)
       out = SenzingMessage.new
       out.code = SenzingTypeDef::from_json_data(String, data["code"])
@@ -53,6 +65,10 @@ module SenzingTypeDef
       out.status = SenzingTypeDef::from_json_data(String, data["status"])
       out.text = SenzingTypeDef::from_json_data(String, data["text"])
       out.time = SenzingTypeDef::from_json_data(DateTime, data["time"])
+      out.caller = SenzingTypeDef::from_json_data(Frame, data["caller"])
+      out.help = SenzingTypeDef::from_json_data(String, data["help"])
+      out.remediation = SenzingTypeDef::from_json_data(String, data["remediation"])
+      out.stack = SenzingTypeDef::from_json_data(Stack, data["stack"])
       out
     end
 
@@ -69,6 +85,10 @@ module SenzingTypeDef
       data["status"] = SenzingTypeDef::to_json_data(status)
       data["text"] = SenzingTypeDef::to_json_data(text)
       data["time"] = SenzingTypeDef::to_json_data(time)
+      data["caller"] = SenzingTypeDef::to_json_data(caller) unless caller.nil?
+      data["help"] = SenzingTypeDef::to_json_data(help) unless help.nil?
+      data["remediation"] = SenzingTypeDef::to_json_data(remediation) unless remediation.nil?
+      data["stack"] = SenzingTypeDef::to_json_data(stack) unless stack.nil?
       data
     end
   end
@@ -156,6 +176,54 @@ module SenzingTypeDef
     end
   end
 
+  # A location in the code.
+  class Frame
+    # Source file of the frame.
+    attr_accessor :file
+
+    # Function, without the package path.
+    attr_accessor :function
+
+    # Line number in the source file.
+    attr_accessor :line
+
+    # Package, as an import path.
+    attr_accessor :package
+
+    def self.from_json_data(data)
+      out = Frame.new
+      out.file = SenzingTypeDef::from_json_data(String, data["file"])
+      out.function = SenzingTypeDef::from_json_data(String, data["function"])
+      out.line = SenzingTypeDef::from_json_data(Integer, data["line"])
+      out.package = SenzingTypeDef::from_json_data(String, data["package"])
+      out
+    end
+
+    def to_json_data
+      data = {}
+      data["file"] = SenzingTypeDef::to_json_data(file)
+      data["function"] = SenzingTypeDef::to_json_data(function)
+      data["line"] = SenzingTypeDef::to_json_data(line)
+      data["package"] = SenzingTypeDef::to_json_data(package)
+      data
+    end
+  end
+
+  # A list of frames.  The innermost frame is first.
+  class Stack
+    attr_accessor :value
+
+    def self.from_json_data(data)
+      out = Stack.new
+      out.value = SenzingTypeDef.from_json_data(Array[Frame], data)
+      out
+    end
+
+    def to_json_data
+      SenzingTypeDef.to_json_data(value)
+    end
+  end
+
   private
 
   def self.from_json_data(type, data)
@@ -50,6 +50,26 @@ pub struct SenzingMessage {
     /// Time message was generated in RFC3339 format.
     #[serde(rename = "time")]
     pub time: DateTime<FixedOffset>,
+
+    /// Location in the code, as package, function, file, and line.
+    #[serde(rename = "caller")]
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub caller: Option<Box<Frame>>,
+
+    /// URL of documentation for the message.
+    #[serde(rename = "help")]
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub help: Option<Box<String>>,
+
+    /// What to do about the message.
+    #[serde(rename = "remediation")]
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub remediation: Option<Box<String>>,
+
+    /// Stack trace where the message or its error was created.
+    #[serde(rename = "stack")]
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub stack: Option<Box<Stack>>,
 }
 
 /// A detail published by the message generator.
@@ -84,3 +104,26 @@ pub type Error = String;
 
 /// A list of errors.  Usually a stack of errors.
 pub type Errors = Vec<Error>;
+
+/// A location in the code.
+#[derive(Serialize, Deserialize)]
+pub struct Frame {
+    /// Source file of the frame.
+    #[serde(rename = "file")]
+    pub file: String,
+
+    /// Function, without the package path.
+    #[serde(rename = "function")]
+    pub function: String,
+
+    /// Line number in the source file.
+    #[serde(rename = "line")]
+    pub line: i32,
+
+    /// Package, as an import path.
+    #[serde(rename = "package")]
+    pub package: String,
+}
+
+/// A list of frames.  The innermost frame is first.
+pub type Stack = Vec<Frame>;
@@ -56,6 +56,26 @@ export interface SenzingMessage {
    * Time message was generated in RFC3339 format.
    */
   time: string;
+
+  /**
+   * Location in the code, as package, function, file, and line.
+   */
+  caller?: Frame;
+
+  /**
+   * URL of documentation for the message.
+   */
+  help?: string;
+
+  /**
+   * What to do about the message.
+   */
+  remediation?: string;
+
+  /**
+   * Stack trace where the message or its error was created.
+   */
+  stack?: Stack;
 }
 
 /**
@@ -102,3 +122,33 @@ export type Error = string;
  * A list of errors.  Usually a stack of errors.
  */
 export type Errors = Error[];
+
+/**
+ * A location in the code.
+ */
+export interface Frame {
+  /**
+   * Source file of the frame.
+   */
+  file: string;
+
+  /**
+   * Function, without the package path.
+   */
+  function: string;
+
+  /**
+   * Line number in the source file.
+   */
+  line: number;
+
+  /**
+   * Package, as an import path.
+   */
+  package: string;
+}
+
+/**
+ * A list of frames.  The innermost frame is first.
+ */
+export type Stack = Frame[];